@@ -12,6 +12,7 @@ import (
 
 	"apisrv/pkg/app"
 	"apisrv/pkg/db"
+	"apisrv/pkg/migrate"
 
 	"github.com/BurntSushi/toml"
 	"github.com/getsentry/sentry-go"
@@ -27,6 +28,7 @@ var (
 	flVerbose          = fs.Bool("verbose", false, "enable debug output")
 	flVerboseSql       = fs.Bool("verbose-sql", false, "enable all sql output")
 	flGenerateTSClient = fs.Bool("ts_client", false, "generate TypeScript vt rpc client and exit")
+	flMigrationsDir    = fs.String("migrations-dir", migrate.DefaultDir, "Path to migrations directory for migrate create")
 	cfg                app.Config
 	version            string
 )
@@ -37,6 +39,12 @@ func main() {
 	exitOnError(fs.Parse(os.Args[1:]))
 	fixStdLog(*flVerbose)
 
+	// run migrate subcommand
+	if fs.Arg(0) == "migrate" {
+		exitOnError(runMigrate(fs.Args()[1:]))
+		return
+	}
+
 	log.Printf("starting %v version=%v", appName, "1")
 	if _, err := toml.DecodeFile(*flConfigPath, &cfg); err != nil {
 		exitOnError(err)
@@ -58,10 +66,27 @@ func main() {
 	exitOnError(err)
 	log.Println(v)
 
+	// connect read replicas
+	var replicas []*pg.DB
+	for _, opts := range cfg.Replica.Databases {
+		replicas = append(replicas, pg.Connect(opts))
+	}
+	if len(replicas) > 0 {
+		dbc = dbc.WithReplicas(replicas, cfg.Replica.MaxLag)
+	}
+
+	// apply pending migrations
+	if cfg.Migrate.OnStart {
+		exitOnError(migrateOnStart(dbc))
+	}
+
 	// log all sql queries
 	if *flVerboseSql {
 		sqlLogger := log.New(os.Stdout, "Q", log.LstdFlags)
 		dbconn.AddQueryHook(db.NewQueryLogger(sqlLogger))
+		for _, r := range replicas {
+			r.AddQueryHook(db.NewQueryLogger(sqlLogger))
+		}
 	}
 
 	// create & run app
@@ -93,6 +118,15 @@ func main() {
 	<-quit
 	application.Shutdown(5 * time.Second)
 
+	// close connection pools after server is stopped
+	for _, r := range replicas {
+		if err := r.Close(); err != nil {
+			log.Printf("close replica err=%q", err)
+		}
+	}
+	if err := dbconn.Close(); err != nil {
+		log.Printf("close database err=%q", err)
+	}
 }
 
 // fixStdLog sets additional params to std logger (prefix D, filename & line).
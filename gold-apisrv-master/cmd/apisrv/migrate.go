@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/migrate"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-pg/pg/v10"
+)
+
+const migrateUsage = `usage: apisrv [flags] migrate command
+  up           apply all pending migrations
+  down N       roll back N last migrations
+  status       show migrations status
+  baseline V   mark migrations up to version V as applied without running them
+  create NAME  add new migration files to -migrations-dir`
+
+var errMigrateUsage = errors.New(migrateUsage)
+
+// runMigrate runs migrate subcommand with args.
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return errMigrateUsage
+		}
+
+		files, err := migrate.Create(*flMigrationsDir, args[1])
+		for _, f := range files {
+			fmt.Println("created", f)
+		}
+		return err
+	}
+
+	ctx := context.Background()
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		m, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		list, err := m.Up(ctx)
+		printMigrations("applied", list)
+		return err
+	case args[0] == "down" && len(args) == 2:
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			return errMigrateUsage
+		}
+
+		m, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		list, err := m.Down(ctx, n)
+		printMigrations("rolled back", list)
+		return err
+	case args[0] == "baseline" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version <= 0 {
+			return errMigrateUsage
+		}
+
+		m, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		list, err := m.Baseline(ctx, version)
+		printMigrations("marked", list)
+		return err
+	case args[0] == "status" && len(args) == 1:
+		m, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		list, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(list)
+		return nil
+	}
+
+	return errMigrateUsage
+}
+
+// newMigrator returns migrator of embedded migrations for database from config.
+func newMigrator() (*migrate.Migrator, error) {
+	migrations, err := migrate.Embedded()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := toml.DecodeFile(*flConfigPath, &cfg); err != nil {
+		return nil, err
+	}
+
+	return migrate.New(db.New(pg.Connect(cfg.Database)), migrations), nil
+}
+
+// printMigrations prints migrations with action or nothing to do message.
+func printMigrations(action string, list []migrate.Migration) {
+	if len(list) == 0 {
+		fmt.Println("no migrations", action)
+	}
+
+	for _, mg := range list {
+		fmt.Println(action, mg)
+	}
+}
+
+// printStatus prints migrations status table.
+func printStatus(list []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tSTATE")
+	for _, s := range list {
+		appliedAt, state := "-", "pending"
+		if s.AppliedAt != nil {
+			appliedAt, state = s.AppliedAt.Format(time.RFC3339), "applied"
+		}
+
+		switch {
+		case s.IsUnknown:
+			state = "unknown"
+		case s.IsChanged:
+			state = "changed"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, appliedAt, state)
+	}
+	w.Flush()
+}
+
+// migrateOnStart applies pending embedded migrations before app start.
+func migrateOnStart(dbc db.DB) error {
+	migrations, err := migrate.Embedded()
+	if err != nil {
+		return err
+	}
+
+	list, err := migrate.New(dbc, migrations).Up(context.Background())
+	for _, mg := range list {
+		log.Println("applied migration", mg)
+	}
+
+	return err
+}
@@ -15,10 +15,17 @@ CREATE TABLE "statuses" (
 CREATE TABLE "users" (
 	"userId" SERIAL NOT NULL,
 	"login" varchar(64) NOT NULL,
-	"password" varchar(64) NOT NULL,
-	"authKey" varchar(32),
+	"email" varchar(255),
+	"oidcSubject" varchar(255),
+	"password" varchar(255) NOT NULL,
 	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
 	"lastActivityAt" timestamp with time zone,
+	"role" varchar(16) NOT NULL DEFAULT 'viewer',
+	"totpSecret" varchar(64),
+	"isTotpEnabled" bool NOT NULL DEFAULT false,
+	"recoveryCodes" varchar(64)[],
+	"totpLastStep" int8,
+	"version" int4 NOT NULL DEFAULT 1,
 	"statusId" int4 NOT NULL,
 	CONSTRAINT "users_pkey" PRIMARY KEY("userId")
 );
@@ -27,6 +34,154 @@ CREATE INDEX "IX_FK_users_statusId_users" ON "users" USING BTREE (
 	"statusId"
 );
 
+CREATE INDEX "IX_users_email" ON "users" USING BTREE (
+	"email"
+);
+
+CREATE INDEX "IX_users_oidcSubject" ON "users" USING BTREE (
+	"oidcSubject"
+);
+
+
+CREATE TABLE "sessions" (
+	"sessionId" SERIAL NOT NULL,
+	"userId" int4 NOT NULL,
+	"tokenHash" varchar(64) NOT NULL,
+	"isRemember" bool NOT NULL DEFAULT false,
+	"device" varchar(64),
+	"ip" varchar(45),
+	"userAgent" text,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"lastActivityAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	"idleExpiresAt" timestamp with time zone NOT NULL,
+	CONSTRAINT "sessions_pkey" PRIMARY KEY("sessionId")
+);
+
+CREATE UNIQUE INDEX "IX_sessions_tokenHash" ON "sessions" USING BTREE (
+	"tokenHash"
+);
+
+CREATE INDEX "IX_FK_sessions_userId_sessions" ON "sessions" USING BTREE (
+	"userId"
+);
+
+
+CREATE TABLE "userTokens" (
+	"userTokenId" SERIAL NOT NULL,
+	"userId" int4 NOT NULL,
+	"kind" varchar(16) NOT NULL,
+	"tokenHash" varchar(64) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	"usedAt" timestamp with time zone,
+	CONSTRAINT "userTokens_pkey" PRIMARY KEY("userTokenId")
+);
+
+CREATE UNIQUE INDEX "IX_userTokens_tokenHash" ON "userTokens" USING BTREE (
+	"tokenHash"
+);
+
+CREATE INDEX "IX_FK_userTokens_userId_userTokens" ON "userTokens" USING BTREE (
+	"userId"
+);
+
+
+CREATE TABLE "apiKeys" (
+	"apiKeyId" SERIAL NOT NULL,
+	"userId" int4 NOT NULL,
+	"title" varchar(128) NOT NULL,
+	"keyPrefix" varchar(16) NOT NULL,
+	"keyHash" varchar(64) NOT NULL,
+	"scopes" jsonb NOT NULL,
+	"isReadOnly" bool NOT NULL DEFAULT false,
+	"expiresAt" timestamp with time zone,
+	"lastUsedAt" timestamp with time zone,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"statusId" int4 NOT NULL,
+	CONSTRAINT "apiKeys_pkey" PRIMARY KEY("apiKeyId")
+);
+
+CREATE UNIQUE INDEX "IX_apiKeys_keyHash" ON "apiKeys" USING BTREE (
+	"keyHash"
+);
+
+CREATE INDEX "IX_FK_apiKeys_userId_apiKeys" ON "apiKeys" USING BTREE (
+	"userId"
+);
+
+CREATE INDEX "IX_FK_apiKeys_statusId_apiKeys" ON "apiKeys" USING BTREE (
+	"statusId"
+);
+
+
+CREATE TABLE "auditLog" (
+	"auditLogId" SERIAL NOT NULL,
+	"userId" int4,
+	"namespace" varchar(32) NOT NULL,
+	"method" varchar(64) NOT NULL,
+	"objectId" int4,
+	"params" jsonb,
+	"resultCode" int4 NOT NULL DEFAULT 0,
+	"ip" varchar(45),
+	"requestId" varchar(64),
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "auditLog_pkey" PRIMARY KEY("auditLogId")
+);
+
+CREATE INDEX "IX_FK_auditLog_userId_auditLog" ON "auditLog" USING BTREE (
+	"userId"
+);
+
+CREATE INDEX "IX_auditLog_namespace_objectId" ON "auditLog" USING BTREE (
+	"namespace",
+	"objectId"
+);
+
+CREATE INDEX "IX_auditLog_createdAt" ON "auditLog" USING BTREE (
+	"createdAt"
+);
+
+
+CREATE TABLE "loginChallenges" (
+	"loginChallengeId" SERIAL NOT NULL,
+	"userId" int4 NOT NULL,
+	"tokenHash" varchar(64) NOT NULL,
+	"isRemember" bool NOT NULL DEFAULT false,
+	"attempts" int4 NOT NULL DEFAULT 0,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	CONSTRAINT "loginChallenges_pkey" PRIMARY KEY("loginChallengeId")
+);
+
+CREATE UNIQUE INDEX "IX_loginChallenges_tokenHash" ON "loginChallenges" USING BTREE (
+	"tokenHash"
+);
+
+CREATE INDEX "IX_FK_loginChallenges_userId_loginChallenges" ON "loginChallenges" USING BTREE (
+	"userId"
+);
+
+
+CREATE TABLE "oidcStates" (
+	"stateHash" varchar(64) NOT NULL,
+	"nonce" varchar(64) NOT NULL,
+	"verifier" varchar(128) NOT NULL,
+	"isRemember" bool NOT NULL DEFAULT false,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	CONSTRAINT "oidcStates_pkey" PRIMARY KEY("stateHash")
+);
+
+
+CREATE TABLE "loginAttempts" (
+	"key" varchar(128) NOT NULL,
+	"failures" int4 NOT NULL DEFAULT 0,
+	"lastFailureAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"lockedUntil" timestamp with time zone,
+	CONSTRAINT "loginAttempts_pkey" PRIMARY KEY("key")
+);
+
 
 CREATE TABLE "vfsFiles" (
 	"fileId" SERIAL NOT NULL,
@@ -52,12 +207,17 @@ CREATE INDEX "IX_FK_vfsFiles_statusId_vfsFiles" ON "vfsFiles" USING BTREE (
 	"statusId"
 );
 
+CREATE INDEX "IX_vfsFiles_path" ON "vfsFiles" USING BTREE (
+	"path"
+);
+
 
 CREATE TABLE "vfsFolders" (
 	"folderId" SERIAL NOT NULL,
 	"parentFolderId" int4,
 	"title" varchar(255) NOT NULL,
 	"isFavorite" bool DEFAULT false,
+	"isPrivate" bool NOT NULL DEFAULT false,
 	"createdAt" timestamp NOT NULL DEFAULT now(),
 	"statusId" int4 NOT NULL,
 	CONSTRAINT "vfsFolders_pkey" PRIMARY KEY("folderId")
@@ -99,10 +259,16 @@ CREATE INDEX "IX_vfsHashes_indexedAt" ON "vfsHashes" USING BTREE (
 CREATE TABLE "tags" (
     "tagId" SERIAL NOT NULL,
     "title" varchar(256) NOT NULL,
+    "alias" varchar(256) NOT NULL,
+    "version" int4 NOT NULL DEFAULT 1,
     "statusId" int4 NOT NULL,
     PRIMARY KEY("tagId")
 );
 
+CREATE UNIQUE INDEX "IX_tags_alias" ON "tags" USING BTREE (
+    "alias"
+);
+
 CREATE TABLE "news" (
     "newsId" SERIAL NOT NULL,
     "title" varchar(256) NOT NULL,
@@ -112,15 +278,50 @@ CREATE TABLE "news" (
     "createdAt" timestamp with time zone NOT NULL DEFAULT now(),
     "updatedAt" timestamp with time zone,
     "publicationDate" timestamp with time zone NOT NULL,
+    "unpublishDate" timestamp with time zone,
+    "publishedAt" timestamp with time zone,
+    "unpublishedAt" timestamp with time zone,
     "tagIds" int4[],
+    "coverImage" varchar(40),
+    "galleryImages" varchar(40)[],
+    "version" int4 NOT NULL DEFAULT 1,
     "statusId" int4 NOT NULL,
+    "searchVector" tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce("title", '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce("content", '')), 'B')
+    ) STORED,
     PRIMARY KEY("newsId")
 );
 
+CREATE INDEX "IX_news_searchVector" ON "news" USING GIN (
+    "searchVector"
+);
+
+CREATE TABLE "newsRevisions" (
+    "revisionId" SERIAL NOT NULL,
+    "newsId" int4 NOT NULL,
+    "title" varchar(256) NOT NULL,
+    "alias" varchar(256) NOT NULL,
+    "content" text,
+    "categoryId" int4 NOT NULL,
+    "tagIds" int4[],
+    "statusId" int4 NOT NULL,
+    "userId" int4,
+    "createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY("revisionId")
+);
+
+CREATE INDEX "IX_FK_newsRevisions_newsId_newsRevisions" ON "newsRevisions" USING BTREE (
+    "newsId"
+);
+
 CREATE TABLE "categories" (
       "categoryId" SERIAL NOT NULL,
+      "parentCategoryId" int4,
       "title" varchar(256) NOT NULL,
       "orderNumber" int4 NOT NULL,
+      "coverImage" varchar(40),
+      "version" int4 NOT NULL DEFAULT 1,
       "statusId" int4 NOT NULL,
       PRIMARY KEY("categoryId")
 );
@@ -147,6 +348,31 @@ ALTER TABLE "news" ADD CONSTRAINT "Ref_news_to_categories" FOREIGN KEY ("categor
     ON UPDATE NO ACTION
     NOT DEFERRABLE;
 
+ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_categories" FOREIGN KEY ("parentCategoryId")
+    REFERENCES "categories"("categoryId")
+        MATCH SIMPLE
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION
+    NOT DEFERRABLE;
+
+CREATE INDEX "IX_FK_categories_parentCategoryId_categories" ON "categories" USING BTREE (
+    "parentCategoryId"
+);
+
+ALTER TABLE "newsRevisions" ADD CONSTRAINT "Ref_newsRevisions_to_news" FOREIGN KEY ("newsId")
+    REFERENCES "news"("newsId")
+        MATCH SIMPLE
+    ON DELETE CASCADE
+    ON UPDATE NO ACTION
+    NOT DEFERRABLE;
+
+ALTER TABLE "newsRevisions" ADD CONSTRAINT "Ref_newsRevisions_to_users" FOREIGN KEY ("userId")
+    REFERENCES "users"("userId")
+        MATCH SIMPLE
+    ON DELETE SET NULL
+    ON UPDATE NO ACTION
+    NOT DEFERRABLE;
+
 ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_statuses" FOREIGN KEY ("statusId")
     REFERENCES "statuses"("statusId")
         MATCH SIMPLE
@@ -164,6 +390,48 @@ ALTER TABLE "users" ADD CONSTRAINT "FK_users_statusId" FOREIGN KEY ("statusId")
 	ON UPDATE RESTRICT
 	NOT DEFERRABLE;
 
+ALTER TABLE "sessions" ADD CONSTRAINT "FK_sessions_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "userTokens" ADD CONSTRAINT "FK_userTokens_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "loginChallenges" ADD CONSTRAINT "FK_loginChallenges_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "apiKeys" ADD CONSTRAINT "FK_apiKeys_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "apiKeys" ADD CONSTRAINT "FK_apiKeys_statusId" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "auditLog" ADD CONSTRAINT "FK_auditLog_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
 ALTER TABLE "vfsFiles" ADD CONSTRAINT "vfsFiles_folderId_fkey" FOREIGN KEY ("folderId")
 	REFERENCES "vfsFolders"("folderId")
 	MATCH SIMPLE
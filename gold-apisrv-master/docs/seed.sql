@@ -0,0 +1,4 @@
+-- development and test data, never load it into production database
+
+-- password is 12345
+INSERT INTO "users" ( "login", "password", "role", "statusId" ) VALUES ( 'admin', '$2y$14$4IpqlaJ2Rvfgs.wb8f6lPODVLb/Ygl6zw1ZCUKz5CuT6WB6CV44AG', 'admin', 1 );
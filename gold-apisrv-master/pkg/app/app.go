@@ -2,10 +2,13 @@ package app
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mailer"
+	"apisrv/pkg/password"
 	"apisrv/pkg/vt"
 
 	"github.com/go-pg/pg/v10"
@@ -18,7 +21,12 @@ import (
 
 type Config struct {
 	Database *pg.Options
-	Server   struct {
+	Replica  struct {
+		Databases     []*pg.Options // read replicas, reads of repos are made on primary if empty
+		MaxLag        time.Duration // max replication lag of replica used for reads, 5s by default
+		CheckInterval time.Duration // replication lag check interval, 5s by default
+	}
+	Server struct {
 		Host      string
 		Port      int
 		IsDevel   bool
@@ -28,7 +36,40 @@ type Config struct {
 		Environment string
 		DSN         string
 	}
-	VFS vfs.Config
+	Migrate struct {
+		OnStart bool // apply pending embedded migrations on start
+	}
+	Scheduler struct {
+		Disabled bool
+		Interval time.Duration
+	}
+	LoginCleanup struct {
+		Interval time.Duration // interval of stale login attempts removal, 10m by default
+	}
+	Site struct {
+		Title       string
+		Description string
+		Language    string // ISO 639 language code of publication, en by default
+		URL         string // public site url
+		NewsURL     string // public news url template, {alias} is replaced with news alias
+		CategoryURL string // public category url template, {id} is replaced with category id
+		TagURL      string // public tag url template, {alias} is replaced with tag alias
+	}
+	Feed struct {
+		Limit int // items count in feeds
+	}
+	Sitemap struct {
+		PageSize int // urls count in one sitemap
+	}
+	VFS      vfs.Config
+	Mail     mailer.Config
+	Password password.Config
+	OIDC     vt.OIDCConfig
+	VT       struct {
+		PasswordResetURL string // vt password reset page url template, {token} is replaced with reset token
+		InviteURL        string // vt invitation page url template, {token} is replaced with invite token
+		VfsURLSecret     string // HMAC key of signed vfs urls, files of private folders are not served if empty
+	}
 }
 
 type App struct {
@@ -39,6 +80,8 @@ type App struct {
 	dbc     *pg.DB
 	echo    *echo.Echo
 	vtsrv   zenrpc.Server
+	quit    chan struct{}
+	stop    sync.Once
 }
 
 func New(appName string, verbose bool, cfg Config, db db.DB, dbc *pg.DB) *App {
@@ -48,26 +91,67 @@ func New(appName string, verbose bool, cfg Config, db db.DB, dbc *pg.DB) *App {
 		db:      db,
 		dbc:     dbc,
 		echo:    echo.New(),
+		quit:    make(chan struct{}),
 	}
 	a.SetStdLoggers(verbose)
 	a.echo.HideBanner = true
 	a.echo.HidePort = true
 	a.echo.IPExtractor = echo.ExtractIPFromRealIPHeader()
-	a.vtsrv = vt.New(a.db, a.Logger, a.cfg.Server.IsDevel)
+	vt.TotpIssuer = appName
+	vt.PasswordHasher = password.NewHasher(a.cfg.Password)
+	vt.PasswordPolicy = a.cfg.Password.Policy
+	if a.cfg.VT.PasswordResetURL != "" {
+		vt.PasswordResetURL = a.cfg.VT.PasswordResetURL
+	}
+	if a.cfg.VT.InviteURL != "" {
+		vt.InviteURL = a.cfg.VT.InviteURL
+	}
+	if a.cfg.VT.VfsURLSecret != "" {
+		vt.VfsURLSecret = []byte(a.cfg.VT.VfsURLSecret)
+	}
+	a.vtsrv = vt.New(a.db, a.Logger, mailer.New(a.cfg.Mail, a.Logger), a.cfg.Server.IsDevel)
 
 	return a
 }
 
 // Run is a function that runs application.
 func (a *App) Run() error {
+	if err := a.checkSiteLanguage(); err != nil {
+		return err
+	}
+
 	a.registerMetrics()
 	a.registerHandlers()
 	a.registerDebugHandlers()
 	a.registerAPIHandlers()
 	a.registerVTApiHandlers()
+	if err := a.registerOIDCHandlers(); err != nil {
+		return err
+	}
+
+	if !a.cfg.Scheduler.Disabled {
+		go a.runJob("news scheduler", a.cfg.Scheduler.Interval, defaultSchedulerInterval, a.scheduleNews)
+	}
+	go a.runJob("login attempts cleanup", a.cfg.LoginCleanup.Interval, defaultLoginCleanupInterval, a.cleanupLoginAttempts)
+
+	if len(a.db.Replicas()) > 0 {
+		go a.watchReplicas()
+	}
+
 	return a.runHTTPServer(a.cfg.Server.Host, a.cfg.Server.Port)
 }
 
+// watchReplicas checks replication lag of read replicas until a.quit is closed.
+func (a *App) watchReplicas() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-a.quit
+		cancel()
+	}()
+
+	a.db.WatchReplicas(ctx, a.cfg.Replica.CheckInterval)
+}
+
 // VTTypeScriptClient returns TypeScript client for VT.
 func (a *App) VTTypeScriptClient() ([]byte, error) {
 	gen := rpcgen.FromSMD(a.vtsrv.SMD())
@@ -79,6 +163,7 @@ func (a *App) VTTypeScriptClient() ([]byte, error) {
 func (a *App) Shutdown(timeout time.Duration) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
+	a.stop.Do(func() { close(a.quit) })
 
 	if err := a.echo.Shutdown(ctx); err != nil {
 		a.Errorf("shutting down server err=%q", err)
@@ -0,0 +1,232 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/feed"
+	"apisrv/pkg/rpc"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+	feedFormatJSON = "json"
+
+	defaultFeedLimit   = 50
+	maxFeedLimit       = 500
+	feedImageSize      = "1024"
+	publicCacheControl = "public, max-age=300"
+)
+
+// newsFeedHandler serves feed of all published news.
+func (a *App) newsFeedHandler(c echo.Context) error {
+	return a.serveFeed(c, a.cfg.Site.Title, a.cfg.Site.URL, nil)
+}
+
+// categoryFeedHandler serves feed of published news from the category.
+func (a *App) categoryFeedHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	category, err := a.feedRepo().CategoryByID(c.Request().Context(), id)
+	if err != nil {
+		return err
+	} else if category == nil {
+		return echo.ErrNotFound
+	}
+
+	return a.serveFeed(c, a.cfg.Site.Title+": "+category.Title, a.categoryURL(id), &db.NewsSearch{CategoryID: &id})
+}
+
+// tagFeedHandler serves feed of published news marked with the tag.
+func (a *App) tagFeedHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	tag, err := a.feedRepo().TagByID(c.Request().Context(), id)
+	if err != nil {
+		return err
+	} else if tag == nil {
+		return echo.ErrNotFound
+	}
+
+	return a.serveFeed(c, a.cfg.Site.Title+": "+tag.Title, a.tagURL(tag.Alias), &db.NewsSearch{TagID: &id})
+}
+
+// feedRepo returns news repo with published news only.
+func (a *App) feedRepo() db.NewsRepo {
+	return db.NewNewsRepo(a.db).WithPublishedOnly()
+}
+
+// serveFeed renders feed in format from :format param with conditional GET support.
+func (a *App) serveFeed(c echo.Context, title, link string, search *db.NewsSearch) error {
+	var render func(feed.Feed) ([]byte, error)
+	var contentType string
+	switch c.Param("format") {
+	case feedFormatRSS:
+		render, contentType = feed.Feed.RSS, feed.RSSContentType
+	case feedFormatAtom:
+		render, contentType = feed.Feed.Atom, feed.AtomContentType
+	case feedFormatJSON:
+		render, contentType = feed.Feed.JSON, feed.JSONContentType
+	default:
+		return echo.ErrNotFound
+	}
+
+	f, err := a.newsFeed(c.Request().Context(), search)
+	if err != nil {
+		return err
+	}
+
+	f.Title = title
+	f.Description = a.cfg.Site.Description
+	f.Link = link
+	f.SelfLink = requestURL(c, c.Request().URL.Path)
+
+	body, err := render(f)
+	if err != nil {
+		return err
+	}
+
+	return writeCacheable(c, contentType, body, f.LastModified())
+}
+
+// newsFeed returns feed with the latest published news.
+func (a *App) newsFeed(ctx context.Context, search *db.NewsSearch) (feed.Feed, error) {
+	repo := a.feedRepo()
+	sort := db.WithSort(
+		db.SortField{Column: db.Columns.News.PublicationDate, Direction: db.SortDesc},
+		db.SortField{Column: db.Columns.News.ID, Direction: db.SortDesc},
+	)
+
+	list, err := repo.NewsByFilters(ctx, search, db.Pager{PageSize: a.feedLimit()}, sort, repo.FullNews())
+	if err != nil {
+		return feed.Feed{}, err
+	}
+
+	tags, err := a.newsTags(ctx, repo, list)
+	if err != nil {
+		return feed.Feed{}, err
+	}
+
+	f := feed.Feed{Items: make([]feed.Item, 0, len(list))}
+	for _, n := range list {
+		link := a.newsURL(n.Alias)
+		item := feed.Item{
+			ID:          link,
+			Title:       n.Title,
+			Link:        link,
+			PublishedAt: n.PublicationDate,
+		}
+
+		if n.Content != nil {
+			item.Content = *n.Content
+		}
+		if n.UpdatedAt != nil {
+			item.UpdatedAt = *n.UpdatedAt
+		}
+		if n.Category != nil {
+			item.Category = n.Category.Title
+		}
+		if img := rpc.NewVfsHashImage(n.CoverImage); img != nil {
+			item.Image = a.absoluteURL(img.Sizes[feedImageSize])
+		}
+		for _, id := range n.TagIDs {
+			if t, ok := tags[id]; ok {
+				item.Tags = append(item.Tags, t)
+			}
+		}
+
+		f.Items = append(f.Items, item)
+	}
+
+	return f, nil
+}
+
+// newsTags returns titles of tags used in news list by tag id.
+func (a *App) newsTags(ctx context.Context, repo db.NewsRepo, list []db.News) (map[int]string, error) {
+	var ids []int
+	for _, n := range list {
+		ids = append(ids, n.TagIDs...)
+	}
+
+	if len(ids) == 0 {
+		return map[int]string{}, nil
+	}
+
+	tags, err := repo.TagsByFilters(ctx, &db.TagSearch{IDs: ids}, db.PagerNoLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[int]string, len(tags))
+	for _, t := range tags {
+		r[t.ID] = t.Title
+	}
+
+	return r, nil
+}
+
+// feedLimit returns configured feed items limit.
+func (a *App) feedLimit() int {
+	limit := a.cfg.Feed.Limit
+	if limit <= 0 {
+		return defaultFeedLimit
+	} else if limit > maxFeedLimit {
+		return maxFeedLimit
+	}
+
+	return limit
+}
+
+// writeCacheable writes body with ETag and Last-Modified headers or 304 if client copy is fresh.
+func writeCacheable(c echo.Context, contentType string, body []byte, lastModified time.Time) error {
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+
+	h := c.Response().Header()
+	h.Set("ETag", etag)
+	h.Set("Cache-Control", publicCacheControl)
+	if !lastModified.IsZero() {
+		h.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if isNotModified(c.Request(), etag, lastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+// isNotModified checks If-None-Match and If-Modified-Since request headers.
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, v := range strings.Split(inm, ",") {
+			v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+			if v == etag || v == "*" {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(ims)
+}
@@ -1,11 +1,15 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
+	"time"
 
+	"apisrv/pkg/oidc"
 	"apisrv/pkg/rpc"
+	"apisrv/pkg/vt"
 
 	sentryecho "github.com/getsentry/sentry-go/echo"
 	"github.com/labstack/echo/v4"
@@ -16,6 +20,9 @@ import (
 	"github.com/vmkteam/zenrpc/v2"
 )
 
+// oidcTimeout limits discovery and requests to identity provider.
+const oidcTimeout = 10 * time.Second
+
 // runHTTPServer is a function that starts http listener using labstack/echo.
 func (a *App) runHTTPServer(host string, port int) error {
 	listenAddress := fmt.Sprintf("%s:%d", host, port)
@@ -28,7 +35,7 @@ func (a *App) registerHandlers() {
 	a.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
 		AllowMethods: []string{echo.GET, echo.PUT, echo.POST, echo.DELETE},
-		AllowHeaders: []string{"Authorization", "Authorization2", "Origin", "X-Requested-With", "Content-Type", "Accept", "Platform", "Version"},
+		AllowHeaders: []string{"Authorization", "Authorization2", vt.APIKeyHeader, "Origin", "X-Requested-With", "Content-Type", "Accept", "Platform", "Version"},
 	}))
 
 	// sentry middleware
@@ -38,6 +45,16 @@ func (a *App) registerHandlers() {
 	}))
 
 	a.echo.Use(zm.EchoIPContext(), zm.EchoSentryHubContext())
+
+	// news feeds, :format is rss, atom or json
+	a.echo.GET("/v1/feed/:format", a.newsFeedHandler)
+	a.echo.GET("/v1/feed/category/:id/:format", a.categoryFeedHandler)
+	a.echo.GET("/v1/feed/tag/:id/:format", a.tagFeedHandler)
+
+	// sitemaps, :type is news, categories or tags
+	a.echo.GET("/sitemap.xml", a.sitemapIndexHandler)
+	a.echo.GET("/sitemap/google-news.xml", a.googleNewsSitemapHandler)
+	a.echo.GET("/sitemap/:type/:page", a.sitemapHandler)
 }
 
 // registerDebugHandlers adds /debug/pprof handlers into a.echo instance.
@@ -81,3 +98,26 @@ func (a *App) registerVTApiHandlers() {
 	a.echo.Any("/v1/vt/doc/", echo.WrapHandler(http.HandlerFunc(zenrpc.SMDBoxHandler)))
 	a.echo.Any("/v1/vt/api.ts", echo.WrapHandler(http.HandlerFunc(rpcgen.Handler(gen.TSCustomClient(tsSettings)))))
 }
+
+// registerOIDCHandlers adds OpenID Connect single sign-on handlers for VT if identity provider is configured.
+func (a *App) registerOIDCHandlers() error {
+	if a.cfg.OIDC.Issuer == "" {
+		return nil
+	} else if err := a.cfg.OIDC.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcTimeout)
+	defer cancel()
+
+	provider, err := oidc.New(ctx, a.cfg.OIDC.Config, &http.Client{Timeout: oidcTimeout})
+	if err != nil {
+		return fmt.Errorf("oidc provider: %w", err)
+	}
+
+	h := vt.NewOIDCHandler(a.db, a.Logger, a.cfg.OIDC, provider)
+	a.echo.GET("/v1/auth/oidc/login", echo.WrapHandler(http.HandlerFunc(h.Login)))
+	a.echo.GET("/v1/auth/oidc/callback", echo.WrapHandler(http.HandlerFunc(h.Callback)))
+
+	return nil
+}
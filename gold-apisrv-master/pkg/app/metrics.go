@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/vt"
 
 	"github.com/labstack/echo/v4"
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,10 +26,24 @@ func (a *App) registerMetrics() {
 
 	prometheus.MustRegister(statLogEvents)
 
+	statLogins := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: a.appName,
+		Subsystem: "vt",
+		Name:      "logins_total",
+		Help:      "Failed and locked VT logins.",
+	}, []string{"result"})
+
+	vt.SetStatLogins(statLogins)
+
+	prometheus.MustRegister(statLogins)
+
 	// add db conn metrics
 	metrics := NewConnectionPoolMetrics(a.appName)
 	prometheus.MustRegister(metrics)
 	metrics.ObserveRegularly(context.Background(), a.dbc, "default")
+	for i, r := range a.db.Replicas() {
+		metrics.ObserveRegularly(context.Background(), r, "replica"+strconv.Itoa(i+1))
+	}
 
 	a.echo.Use(httpMetrics(a.appName))
 	a.echo.Any("/metrics", echo.WrapHandler(promhttp.Handler()))
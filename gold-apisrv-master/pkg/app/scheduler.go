@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/vt"
+
+	"github.com/go-pg/pg/v10"
+)
+
+const (
+	defaultSchedulerInterval    = time.Minute
+	defaultLoginCleanupInterval = 10 * time.Minute
+
+	newsSchedulerLock = "news-scheduler"
+	loginCleanupLock  = "login-attempts-cleanup"
+)
+
+// runJob runs fn every interval until a.quit is closed, defaultInterval is used for empty interval.
+func (a *App) runJob(name string, interval, defaultInterval time.Duration, fn func(context.Context) error) {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.quit:
+			return
+		case <-ticker.C:
+			if err := fn(context.Background()); err != nil {
+				a.Errorf("%s err=%q", name, err)
+			}
+		}
+	}
+}
+
+// scheduleNews runs one scheduler iteration under advisory lock, so only one replica does the job.
+func (a *App) scheduleNews(ctx context.Context) error {
+	return a.db.RunInLock(ctx, newsSchedulerLock, func(tx *pg.Tx) error {
+		repo := db.NewNewsRepo(tx)
+
+		published, err := repo.PublishScheduledNews(ctx)
+		if err != nil {
+			return err
+		}
+
+		unpublished, err := repo.UnpublishExpiredNews(ctx)
+		if err != nil {
+			return err
+		}
+
+		if published > 0 || unpublished > 0 {
+			a.Printf("news scheduler published=%d unpublished=%d", published, unpublished)
+		}
+
+		return nil
+	})
+}
+
+// cleanupLoginAttempts removes stale login attempts under advisory lock, so only one replica does the job.
+func (a *App) cleanupLoginAttempts(ctx context.Context) error {
+	return a.db.RunInLock(ctx, loginCleanupLock, func(tx *pg.Tx) error {
+		deleted, err := vt.DeleteStaleLoginAttempts(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if deleted > 0 {
+			a.Printf("login attempts cleanup deleted=%d", deleted)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// defaultSiteLanguage is used for empty Site.Language.
+const defaultSiteLanguage = "en"
+
+// reSiteLanguage matches ISO 639 language code, zh-cn and zh-tw are used by Google News for Chinese.
+var reSiteLanguage = regexp.MustCompile(`^[a-z]{2,3}(-[a-z]{2})?$`)
+
+// checkSiteLanguage sets default Site.Language or checks that it is ISO 639 code.
+func (a *App) checkSiteLanguage() error {
+	if a.cfg.Site.Language == "" {
+		a.cfg.Site.Language = defaultSiteLanguage
+	} else if !reSiteLanguage.MatchString(a.cfg.Site.Language) {
+		return fmt.Errorf("invalid Site.Language %q, ISO 639 code is expected", a.cfg.Site.Language)
+	}
+
+	return nil
+}
+
+// newsURL returns public news url by Site.NewsURL template.
+func (a *App) newsURL(alias string) string {
+	return a.siteURL(a.cfg.Site.NewsURL, "/news/{alias}", "{alias}", alias)
+}
+
+// categoryURL returns public category url by Site.CategoryURL template.
+func (a *App) categoryURL(id int) string {
+	return a.siteURL(a.cfg.Site.CategoryURL, "/category/{id}", "{id}", strconv.Itoa(id))
+}
+
+// tagURL returns public tag url by Site.TagURL template.
+func (a *App) tagURL(alias string) string {
+	return a.siteURL(a.cfg.Site.TagURL, "/tag/{alias}", "{alias}", alias)
+}
+
+// siteURL replaces placeholder in template, defaultPath relative to Site.URL is used for empty template.
+func (a *App) siteURL(template, defaultPath, placeholder, value string) string {
+	if template == "" {
+		template = a.absoluteURL(defaultPath)
+	}
+
+	return strings.ReplaceAll(template, placeholder, value)
+}
+
+// absoluteURL prefixes relative path with Site.URL.
+func (a *App) absoluteURL(path string) string {
+	if path == "" || strings.Contains(path, "://") {
+		return path
+	}
+
+	return strings.TrimSuffix(a.cfg.Site.URL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// requestURL returns absolute url of path on the requested host.
+func requestURL(c echo.Context, path string) string {
+	return c.Scheme() + "://" + c.Request().Host + path
+}
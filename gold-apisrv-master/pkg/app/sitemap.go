@@ -0,0 +1,190 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/sitemap"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	sitemapNews       = "news"
+	sitemapCategories = "categories"
+	sitemapTags       = "tags"
+
+	defaultSitemapPageSize = 10000
+	googleNewsPeriod       = 48 * time.Hour
+)
+
+// sitemapIndexHandler serves sitemap index with links to all sitemap pages.
+func (a *App) sitemapIndexHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	repo := a.feedRepo()
+
+	counts := []struct {
+		name  string
+		count func(context.Context) (int, error)
+	}{
+		{sitemapNews, func(ctx context.Context) (int, error) { return repo.CountNews(ctx, nil) }},
+		{sitemapCategories, func(ctx context.Context) (int, error) { return repo.CountCategories(ctx, nil) }},
+		{sitemapTags, func(ctx context.Context) (int, error) { return repo.CountTags(ctx, nil) }},
+	}
+
+	sitemaps := []sitemap.Sitemap{{Loc: a.absoluteURL("/sitemap/google-news.xml")}}
+	for _, sc := range counts {
+		count, err := sc.count(ctx)
+		if err != nil {
+			return err
+		}
+
+		for page := 1; (page-1)*a.sitemapPageSize() < count; page++ {
+			sitemaps = append(sitemaps, sitemap.Sitemap{Loc: a.absoluteURL("/sitemap/" + sc.name + "/" + strconv.Itoa(page) + ".xml")})
+		}
+	}
+
+	body, err := sitemap.Index(sitemaps)
+	if err != nil {
+		return err
+	}
+
+	return writeCacheable(c, sitemap.ContentType, body, time.Time{})
+}
+
+// sitemapHandler serves page of news, categories or tags sitemap.
+func (a *App) sitemapHandler(c echo.Context) error {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil || page < 1 {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	pager := db.Pager{Page: page, PageSize: a.sitemapPageSize()}
+
+	var urls []sitemap.URL
+	switch c.Param("type") {
+	case sitemapNews:
+		urls, err = a.newsSitemap(ctx, pager)
+	case sitemapCategories:
+		urls, err = a.categoriesSitemap(ctx, pager)
+	case sitemapTags:
+		urls, err = a.tagsSitemap(ctx, pager)
+	default:
+		return echo.ErrNotFound
+	}
+	if err != nil {
+		return err
+	} else if len(urls) == 0 {
+		return echo.ErrNotFound
+	}
+
+	var lastModified time.Time
+	for _, u := range urls {
+		if u.LastMod.After(lastModified) {
+			lastModified = u.LastMod
+		}
+	}
+
+	body, err := sitemap.URLSet(urls)
+	if err != nil {
+		return err
+	}
+
+	return writeCacheable(c, sitemap.ContentType, body, lastModified)
+}
+
+// googleNewsSitemapHandler serves Google News sitemap with news published during the last 48 hours.
+func (a *App) googleNewsSitemapHandler(c echo.Context) error {
+	repo := a.feedRepo()
+	search := &db.NewsSearch{}
+	search.WithPublishedSince(time.Now().Add(-googleNewsPeriod))
+
+	list, err := repo.NewsByFilters(c.Request().Context(), search, db.Pager{PageSize: sitemap.MaxNewsURLs},
+		db.WithSort(db.SortField{Column: db.Columns.News.PublicationDate, Direction: db.SortDesc}),
+		db.WithColumns(db.Columns.News.Alias, db.Columns.News.Title, db.Columns.News.PublicationDate))
+	if err != nil {
+		return err
+	}
+
+	urls := make([]sitemap.NewsURL, 0, len(list))
+	for _, n := range list {
+		urls = append(urls, sitemap.NewsURL{Loc: a.newsURL(n.Alias), Title: n.Title, PublicationDate: n.PublicationDate})
+	}
+
+	body, err := sitemap.News(sitemap.Publication{Name: a.cfg.Site.Title, Language: a.cfg.Site.Language}, urls)
+	if err != nil {
+		return err
+	}
+
+	return writeCacheable(c, sitemap.ContentType, body, time.Time{})
+}
+
+// newsSitemap returns page of published news urls ordered by id.
+func (a *App) newsSitemap(ctx context.Context, pager db.Pager) ([]sitemap.URL, error) {
+	list, err := a.feedRepo().NewsByFilters(ctx, nil, pager,
+		db.WithSort(db.SortField{Column: db.Columns.News.ID, Direction: db.SortAsc}),
+		db.WithColumns(db.Columns.News.Alias, db.Columns.News.PublicationDate, db.Columns.News.UpdatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]sitemap.URL, 0, len(list))
+	for _, n := range list {
+		lastMod := n.PublicationDate
+		if n.UpdatedAt != nil && n.UpdatedAt.After(lastMod) {
+			lastMod = *n.UpdatedAt
+		}
+
+		urls = append(urls, sitemap.URL{Loc: a.newsURL(n.Alias), LastMod: lastMod})
+	}
+
+	return urls, nil
+}
+
+// categoriesSitemap returns page of enabled category urls.
+func (a *App) categoriesSitemap(ctx context.Context, pager db.Pager) ([]sitemap.URL, error) {
+	list, err := a.feedRepo().CategoriesByFilters(ctx, nil, pager,
+		db.WithSort(db.SortField{Column: db.Columns.Category.ID, Direction: db.SortAsc}))
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]sitemap.URL, 0, len(list))
+	for _, c := range list {
+		urls = append(urls, sitemap.URL{Loc: a.categoryURL(c.ID)})
+	}
+
+	return urls, nil
+}
+
+// tagsSitemap returns page of enabled tag urls.
+func (a *App) tagsSitemap(ctx context.Context, pager db.Pager) ([]sitemap.URL, error) {
+	list, err := a.feedRepo().TagsByFilters(ctx, nil, pager,
+		db.WithSort(db.SortField{Column: db.Columns.Tag.ID, Direction: db.SortAsc}))
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]sitemap.URL, 0, len(list))
+	for _, t := range list {
+		urls = append(urls, sitemap.URL{Loc: a.tagURL(t.Alias)})
+	}
+
+	return urls, nil
+}
+
+// sitemapPageSize returns configured urls count in one sitemap.
+func (a *App) sitemapPageSize() int {
+	size := a.cfg.Sitemap.PageSize
+	if size <= 0 {
+		return defaultSitemapPageSize
+	} else if size > sitemap.MaxURLs {
+		return sitemap.MaxURLs
+	}
+
+	return size
+}
@@ -4,7 +4,7 @@ import (
 	"net/http"
 
 	"apisrv/pkg/db"
-
+	"apisrv/pkg/media"
 	"apisrv/pkg/vt"
 
 	"github.com/labstack/echo/v4"
@@ -13,7 +13,7 @@ import (
 	zm "github.com/vmkteam/zenrpc-middleware"
 )
 
-const NSVFS = "vfs"
+const NSVFS = vt.NSVFS
 
 // RegisterVFS register VFS handler and RPC service
 func (a *App) RegisterVFS(cfg vfs.Config) error {
@@ -23,13 +23,14 @@ func (a *App) RegisterVFS(cfg vfs.Config) error {
 	}
 
 	cr := db.NewCommonRepo(a.db)
+	vr := db.NewVfsRepo(a.db)
 	vfsRepo := vfsdb.NewVfsRepo(a.db)
 	a.echo.Any("/v1/vfs/upload/file", zm.EchoHandler(vt.HTTPAuthMiddleware(cr, vf.UploadHandler(vfsRepo))))
 	a.echo.Any("/v1/vfs/upload/hash", echo.WrapHandler(vt.HTTPAuthMiddleware(cr, vf.HashUploadHandler(&vfsRepo))))
-	a.echo.GET(a.cfg.VFS.WebPath, echo.WrapHandler(http.StripPrefix(a.cfg.VFS.WebPath, http.FileServer(http.Dir(a.cfg.VFS.Path)))))
-	vt.WebPath = a.cfg.VFS.WebPath
+	a.echo.GET(a.cfg.VFS.WebPath, echo.WrapHandler(http.StripPrefix(a.cfg.VFS.WebPath, vt.VfsFileHandler(vr, http.FileServer(http.Dir(a.cfg.VFS.Path))))))
+	media.WebPath = a.cfg.VFS.WebPath
 
-	a.vtsrv.Register(NSVFS, vfs.NewService(vfsRepo, vf, a.dbc))
+	a.vtsrv.Register(NSVFS, vt.NewVfsInvoker(vfs.NewService(vfsRepo, vf, a.dbc), vt.NewVfsService(a.db, a.Logger)))
 
 	return nil
 }
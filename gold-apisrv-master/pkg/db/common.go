@@ -20,13 +20,28 @@ func NewCommonRepo(db orm.DB) CommonRepo {
 	return CommonRepo{
 		db: db,
 		filters: map[string][]Filter{
-			Tables.User.Name: {StatusFilter},
+			Tables.User.Name:   {StatusFilter},
+			Tables.APIKey.Name: {StatusFilter},
 		},
 		sort: map[string][]SortField{
-			Tables.User.Name: {{Column: Columns.User.CreatedAt, Direction: SortDesc}},
+			Tables.User.Name:           {{Column: Columns.User.CreatedAt, Direction: SortDesc}},
+			Tables.Session.Name:        {{Column: Columns.Session.LastActivityAt, Direction: SortDesc}},
+			Tables.UserToken.Name:      {{Column: Columns.UserToken.CreatedAt, Direction: SortDesc}},
+			Tables.APIKey.Name:         {{Column: Columns.APIKey.CreatedAt, Direction: SortDesc}},
+			Tables.AuditLog.Name:       {{Column: Columns.AuditLog.ID, Direction: SortDesc}},
+			Tables.LoginAttempt.Name:   {{Column: Columns.LoginAttempt.LastFailureAt, Direction: SortDesc}},
+			Tables.LoginChallenge.Name: {{Column: Columns.LoginChallenge.CreatedAt, Direction: SortDesc}},
+			Tables.OIDCState.Name:      {{Column: Columns.OIDCState.CreatedAt, Direction: SortDesc}},
 		},
 		join: map[string][]string{
-			Tables.User.Name: {TableColumns},
+			Tables.User.Name:           {TableColumns},
+			Tables.Session.Name:        {TableColumns, Columns.Session.User},
+			Tables.UserToken.Name:      {TableColumns, Columns.UserToken.User},
+			Tables.APIKey.Name:         {TableColumns, Columns.APIKey.User},
+			Tables.AuditLog.Name:       {TableColumns, Columns.AuditLog.User},
+			Tables.LoginAttempt.Name:   {TableColumns},
+			Tables.LoginChallenge.Name: {TableColumns, Columns.LoginChallenge.User},
+			Tables.OIDCState.Name:      {TableColumns},
 		},
 	}
 }
@@ -125,3 +140,532 @@ func (cr CommonRepo) DeleteUser(ctx context.Context, id int) (deleted bool, err
 
 	return cr.UpdateUser(ctx, user, WithColumns(Columns.User.StatusID))
 }
+
+/*** Session ***/
+
+// FullSession returns full joins with all columns
+func (cr CommonRepo) FullSession() OpFunc {
+	return WithColumns(cr.join[Tables.Session.Name]...)
+}
+
+// DefaultSessionSort returns default sort.
+func (cr CommonRepo) DefaultSessionSort() OpFunc {
+	return WithSort(cr.sort[Tables.Session.Name]...)
+}
+
+// SessionByID is a function that returns Session by ID(s) or nil.
+func (cr CommonRepo) SessionByID(ctx context.Context, id int, ops ...OpFunc) (*Session, error) {
+	return cr.OneSession(ctx, &SessionSearch{ID: &id}, ops...)
+}
+
+// OneSession is a function that returns one Session by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneSession(ctx context.Context, search *SessionSearch, ops ...OpFunc) (*Session, error) {
+	obj := &Session{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.Session.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// SessionsByFilters returns Session list.
+func (cr CommonRepo) SessionsByFilters(ctx context.Context, search *SessionSearch, pager Pager, ops ...OpFunc) (sessions []Session, err error) {
+	err = buildQuery(ctx, cr.db, &sessions, search, cr.filters[Tables.Session.Name], pager, ops...).Select()
+	return
+}
+
+// CountSessions returns count
+func (cr CommonRepo) CountSessions(ctx context.Context, search *SessionSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &Session{}, search, cr.filters[Tables.Session.Name], PagerOne, ops...).Count()
+}
+
+// AddSession adds Session to DB.
+func (cr CommonRepo) AddSession(ctx context.Context, session *Session, ops ...OpFunc) (*Session, error) {
+	q := cr.db.ModelContext(ctx, session)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Session.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return session, err
+}
+
+// UpdateSession updates Session in DB.
+func (cr CommonRepo) UpdateSession(ctx context.Context, session *Session, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, session).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Session.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteSession deletes Session from DB.
+func (cr CommonRepo) DeleteSession(ctx context.Context, id int) (deleted bool, err error) {
+	session := &Session{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, session).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** UserToken ***/
+
+// FullUserToken returns full joins with all columns
+func (cr CommonRepo) FullUserToken() OpFunc {
+	return WithColumns(cr.join[Tables.UserToken.Name]...)
+}
+
+// DefaultUserTokenSort returns default sort.
+func (cr CommonRepo) DefaultUserTokenSort() OpFunc {
+	return WithSort(cr.sort[Tables.UserToken.Name]...)
+}
+
+// UserTokenByID is a function that returns UserToken by ID(s) or nil.
+func (cr CommonRepo) UserTokenByID(ctx context.Context, id int, ops ...OpFunc) (*UserToken, error) {
+	return cr.OneUserToken(ctx, &UserTokenSearch{ID: &id}, ops...)
+}
+
+// OneUserToken is a function that returns one UserToken by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneUserToken(ctx context.Context, search *UserTokenSearch, ops ...OpFunc) (*UserToken, error) {
+	obj := &UserToken{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.UserToken.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// UserTokensByFilters returns UserToken list.
+func (cr CommonRepo) UserTokensByFilters(ctx context.Context, search *UserTokenSearch, pager Pager, ops ...OpFunc) (userTokens []UserToken, err error) {
+	err = buildQuery(ctx, cr.db, &userTokens, search, cr.filters[Tables.UserToken.Name], pager, ops...).Select()
+	return
+}
+
+// CountUserTokens returns count
+func (cr CommonRepo) CountUserTokens(ctx context.Context, search *UserTokenSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &UserToken{}, search, cr.filters[Tables.UserToken.Name], PagerOne, ops...).Count()
+}
+
+// AddUserToken adds UserToken to DB.
+func (cr CommonRepo) AddUserToken(ctx context.Context, userToken *UserToken, ops ...OpFunc) (*UserToken, error) {
+	q := cr.db.ModelContext(ctx, userToken)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.UserToken.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return userToken, err
+}
+
+// UpdateUserToken updates UserToken in DB.
+func (cr CommonRepo) UpdateUserToken(ctx context.Context, userToken *UserToken, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, userToken).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.UserToken.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteUserToken deletes UserToken from DB.
+func (cr CommonRepo) DeleteUserToken(ctx context.Context, id int) (deleted bool, err error) {
+	userToken := &UserToken{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, userToken).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** APIKey ***/
+
+// FullAPIKey returns full joins with all columns
+func (cr CommonRepo) FullAPIKey() OpFunc {
+	return WithColumns(cr.join[Tables.APIKey.Name]...)
+}
+
+// DefaultAPIKeySort returns default sort.
+func (cr CommonRepo) DefaultAPIKeySort() OpFunc {
+	return WithSort(cr.sort[Tables.APIKey.Name]...)
+}
+
+// APIKeyByID is a function that returns APIKey by ID(s) or nil.
+func (cr CommonRepo) APIKeyByID(ctx context.Context, id int, ops ...OpFunc) (*APIKey, error) {
+	return cr.OneAPIKey(ctx, &APIKeySearch{ID: &id}, ops...)
+}
+
+// OneAPIKey is a function that returns one APIKey by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneAPIKey(ctx context.Context, search *APIKeySearch, ops ...OpFunc) (*APIKey, error) {
+	obj := &APIKey{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.APIKey.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// APIKeysByFilters returns APIKey list.
+func (cr CommonRepo) APIKeysByFilters(ctx context.Context, search *APIKeySearch, pager Pager, ops ...OpFunc) (apiKeys []APIKey, err error) {
+	err = buildQuery(ctx, cr.db, &apiKeys, search, cr.filters[Tables.APIKey.Name], pager, ops...).Select()
+	return
+}
+
+// CountAPIKeys returns count
+func (cr CommonRepo) CountAPIKeys(ctx context.Context, search *APIKeySearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &APIKey{}, search, cr.filters[Tables.APIKey.Name], PagerOne, ops...).Count()
+}
+
+// AddAPIKey adds APIKey to DB.
+func (cr CommonRepo) AddAPIKey(ctx context.Context, apiKey *APIKey, ops ...OpFunc) (*APIKey, error) {
+	q := cr.db.ModelContext(ctx, apiKey)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.APIKey.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return apiKey, err
+}
+
+// UpdateAPIKey updates APIKey in DB.
+func (cr CommonRepo) UpdateAPIKey(ctx context.Context, apiKey *APIKey, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, apiKey).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.APIKey.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteAPIKey set statusId to deleted in DB.
+func (cr CommonRepo) DeleteAPIKey(ctx context.Context, id int) (deleted bool, err error) {
+	apiKey := &APIKey{ID: id, StatusID: StatusDeleted}
+
+	return cr.UpdateAPIKey(ctx, apiKey, WithColumns(Columns.APIKey.StatusID))
+}
+
+/*** AuditLog ***/
+
+// FullAuditLog returns full joins with all columns
+func (cr CommonRepo) FullAuditLog() OpFunc {
+	return WithColumns(cr.join[Tables.AuditLog.Name]...)
+}
+
+// DefaultAuditLogSort returns default sort.
+func (cr CommonRepo) DefaultAuditLogSort() OpFunc {
+	return WithSort(cr.sort[Tables.AuditLog.Name]...)
+}
+
+// AuditLogByID is a function that returns AuditLog by ID(s) or nil.
+func (cr CommonRepo) AuditLogByID(ctx context.Context, id int, ops ...OpFunc) (*AuditLog, error) {
+	return cr.OneAuditLog(ctx, &AuditLogSearch{ID: &id}, ops...)
+}
+
+// OneAuditLog is a function that returns one AuditLog by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneAuditLog(ctx context.Context, search *AuditLogSearch, ops ...OpFunc) (*AuditLog, error) {
+	obj := &AuditLog{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.AuditLog.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// AuditLogsByFilters returns AuditLog list.
+func (cr CommonRepo) AuditLogsByFilters(ctx context.Context, search *AuditLogSearch, pager Pager, ops ...OpFunc) (auditLogs []AuditLog, err error) {
+	err = buildQuery(ctx, cr.db, &auditLogs, search, cr.filters[Tables.AuditLog.Name], pager, ops...).Select()
+	return
+}
+
+// CountAuditLogs returns count
+func (cr CommonRepo) CountAuditLogs(ctx context.Context, search *AuditLogSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &AuditLog{}, search, cr.filters[Tables.AuditLog.Name], PagerOne, ops...).Count()
+}
+
+// AddAuditLog adds AuditLog to DB.
+func (cr CommonRepo) AddAuditLog(ctx context.Context, auditLog *AuditLog, ops ...OpFunc) (*AuditLog, error) {
+	q := cr.db.ModelContext(ctx, auditLog)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.AuditLog.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return auditLog, err
+}
+
+/*** LoginAttempt ***/
+
+// FullLoginAttempt returns full joins with all columns
+func (cr CommonRepo) FullLoginAttempt() OpFunc {
+	return WithColumns(cr.join[Tables.LoginAttempt.Name]...)
+}
+
+// DefaultLoginAttemptSort returns default sort.
+func (cr CommonRepo) DefaultLoginAttemptSort() OpFunc {
+	return WithSort(cr.sort[Tables.LoginAttempt.Name]...)
+}
+
+// LoginAttemptByID is a function that returns LoginAttempt by ID(s) or nil.
+func (cr CommonRepo) LoginAttemptByID(ctx context.Context, id string, ops ...OpFunc) (*LoginAttempt, error) {
+	return cr.OneLoginAttempt(ctx, &LoginAttemptSearch{ID: &id}, ops...)
+}
+
+// OneLoginAttempt is a function that returns one LoginAttempt by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneLoginAttempt(ctx context.Context, search *LoginAttemptSearch, ops ...OpFunc) (*LoginAttempt, error) {
+	obj := &LoginAttempt{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.LoginAttempt.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// LoginAttemptsByFilters returns LoginAttempt list.
+func (cr CommonRepo) LoginAttemptsByFilters(ctx context.Context, search *LoginAttemptSearch, pager Pager, ops ...OpFunc) (loginAttempts []LoginAttempt, err error) {
+	err = buildQuery(ctx, cr.db, &loginAttempts, search, cr.filters[Tables.LoginAttempt.Name], pager, ops...).Select()
+	return
+}
+
+// CountLoginAttempts returns count
+func (cr CommonRepo) CountLoginAttempts(ctx context.Context, search *LoginAttemptSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &LoginAttempt{}, search, cr.filters[Tables.LoginAttempt.Name], PagerOne, ops...).Count()
+}
+
+// AddLoginAttempt adds LoginAttempt to DB.
+func (cr CommonRepo) AddLoginAttempt(ctx context.Context, loginAttempt *LoginAttempt, ops ...OpFunc) (*LoginAttempt, error) {
+	q := cr.db.ModelContext(ctx, loginAttempt)
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return loginAttempt, err
+}
+
+// UpdateLoginAttempt updates LoginAttempt in DB.
+func (cr CommonRepo) UpdateLoginAttempt(ctx context.Context, loginAttempt *LoginAttempt, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, loginAttempt).WherePK()
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteLoginAttempt deletes LoginAttempt from DB.
+func (cr CommonRepo) DeleteLoginAttempt(ctx context.Context, id string) (deleted bool, err error) {
+	loginAttempt := &LoginAttempt{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, loginAttempt).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** LoginChallenge ***/
+
+// FullLoginChallenge returns full joins with all columns
+func (cr CommonRepo) FullLoginChallenge() OpFunc {
+	return WithColumns(cr.join[Tables.LoginChallenge.Name]...)
+}
+
+// DefaultLoginChallengeSort returns default sort.
+func (cr CommonRepo) DefaultLoginChallengeSort() OpFunc {
+	return WithSort(cr.sort[Tables.LoginChallenge.Name]...)
+}
+
+// LoginChallengeByID is a function that returns LoginChallenge by ID(s) or nil.
+func (cr CommonRepo) LoginChallengeByID(ctx context.Context, id int, ops ...OpFunc) (*LoginChallenge, error) {
+	return cr.OneLoginChallenge(ctx, &LoginChallengeSearch{ID: &id}, ops...)
+}
+
+// OneLoginChallenge is a function that returns one LoginChallenge by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneLoginChallenge(ctx context.Context, search *LoginChallengeSearch, ops ...OpFunc) (*LoginChallenge, error) {
+	obj := &LoginChallenge{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.LoginChallenge.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// LoginChallengesByFilters returns LoginChallenge list.
+func (cr CommonRepo) LoginChallengesByFilters(ctx context.Context, search *LoginChallengeSearch, pager Pager, ops ...OpFunc) (loginChallenges []LoginChallenge, err error) {
+	err = buildQuery(ctx, cr.db, &loginChallenges, search, cr.filters[Tables.LoginChallenge.Name], pager, ops...).Select()
+	return
+}
+
+// CountLoginChallenges returns count
+func (cr CommonRepo) CountLoginChallenges(ctx context.Context, search *LoginChallengeSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &LoginChallenge{}, search, cr.filters[Tables.LoginChallenge.Name], PagerOne, ops...).Count()
+}
+
+// AddLoginChallenge adds LoginChallenge to DB.
+func (cr CommonRepo) AddLoginChallenge(ctx context.Context, loginChallenge *LoginChallenge, ops ...OpFunc) (*LoginChallenge, error) {
+	q := cr.db.ModelContext(ctx, loginChallenge)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.LoginChallenge.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return loginChallenge, err
+}
+
+// UpdateLoginChallenge updates LoginChallenge in DB.
+func (cr CommonRepo) UpdateLoginChallenge(ctx context.Context, loginChallenge *LoginChallenge, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, loginChallenge).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.LoginChallenge.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteLoginChallenge deletes LoginChallenge from DB.
+func (cr CommonRepo) DeleteLoginChallenge(ctx context.Context, id int) (deleted bool, err error) {
+	loginChallenge := &LoginChallenge{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, loginChallenge).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** OIDCState ***/
+
+// FullOIDCState returns full joins with all columns
+func (cr CommonRepo) FullOIDCState() OpFunc {
+	return WithColumns(cr.join[Tables.OIDCState.Name]...)
+}
+
+// DefaultOIDCStateSort returns default sort.
+func (cr CommonRepo) DefaultOIDCStateSort() OpFunc {
+	return WithSort(cr.sort[Tables.OIDCState.Name]...)
+}
+
+// OIDCStateByID is a function that returns OIDCState by ID(s) or nil.
+func (cr CommonRepo) OIDCStateByID(ctx context.Context, id string, ops ...OpFunc) (*OIDCState, error) {
+	return cr.OneOIDCState(ctx, &OIDCStateSearch{ID: &id}, ops...)
+}
+
+// OneOIDCState is a function that returns one OIDCState by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneOIDCState(ctx context.Context, search *OIDCStateSearch, ops ...OpFunc) (*OIDCState, error) {
+	obj := &OIDCState{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.OIDCState.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// OIDCStatesByFilters returns OIDCState list.
+func (cr CommonRepo) OIDCStatesByFilters(ctx context.Context, search *OIDCStateSearch, pager Pager, ops ...OpFunc) (oidcStates []OIDCState, err error) {
+	err = buildQuery(ctx, cr.db, &oidcStates, search, cr.filters[Tables.OIDCState.Name], pager, ops...).Select()
+	return
+}
+
+// CountOIDCStates returns count
+func (cr CommonRepo) CountOIDCStates(ctx context.Context, search *OIDCStateSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &OIDCState{}, search, cr.filters[Tables.OIDCState.Name], PagerOne, ops...).Count()
+}
+
+// AddOIDCState adds OIDCState to DB.
+func (cr CommonRepo) AddOIDCState(ctx context.Context, oidcState *OIDCState, ops ...OpFunc) (*OIDCState, error) {
+	q := cr.db.ModelContext(ctx, oidcState)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.OIDCState.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return oidcState, err
+}
+
+// UpdateOIDCState updates OIDCState in DB.
+func (cr CommonRepo) UpdateOIDCState(ctx context.Context, oidcState *OIDCState, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, oidcState).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.OIDCState.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteOIDCState deletes OIDCState from DB.
+func (cr CommonRepo) DeleteOIDCState(ctx context.Context, id string) (deleted bool, err error) {
+	oidcState := &OIDCState{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, oidcState).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
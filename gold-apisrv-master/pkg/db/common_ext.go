@@ -3,25 +3,32 @@ package db
 import (
 	"context"
 	"time"
+
+	"github.com/go-pg/pg/v10"
 )
 
-// AuthenticateUser update authKey and last activity while user login/logout
-func (cr CommonRepo) AuthenticateUser(ctx context.Context, dbu *User, authKey string) (bool, error) {
-	dbu.AuthKey = authKey
-	now := time.Now()
-	dbu.LastActivityAt = &now
-	return cr.UpdateUser(ctx, dbu, WithColumns(Columns.User.AuthKey, Columns.User.LastActivityAt))
-}
+const (
+	// user roles
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleAuthor = "author"
+	RoleViewer = "viewer"
+
+	// user token kinds
+	UserTokenPasswordReset = "reset"
+	UserTokenInvite        = "invite"
+)
 
+// UpdateUserActivity sets last activity of user. It is not a change of user, so user version is kept.
 func (cr CommonRepo) UpdateUserActivity(ctx context.Context, dbu *User) (bool, error) {
 	now := time.Now()
 	dbu.LastActivityAt = &now
-	return cr.UpdateUser(ctx, dbu, WithColumns(Columns.User.LastActivityAt))
-}
+	res, err := cr.db.ModelContext(ctx, dbu).Column(Columns.User.LastActivityAt).WherePK().Update()
+	if err != nil {
+		return false, err
+	}
 
-func (cr CommonRepo) EnabledUserByAuthKey(ctx context.Context, authKey string) (*User, error) {
-	s := StatusEnabled
-	return cr.OneUser(ctx, &UserSearch{AuthKey: &authKey, StatusID: &s})
+	return res.RowsAffected() > 0, nil
 }
 
 func (cr CommonRepo) EnabledUserByLogin(ctx context.Context, login string) (*User, error) {
@@ -29,6 +36,301 @@ func (cr CommonRepo) EnabledUserByLogin(ctx context.Context, login string) (*Use
 	return cr.OneUser(ctx, &UserSearch{Login: &login, StatusID: &s})
 }
 
+// EnabledUserByEmail returns enabled user by email or nil.
+func (cr CommonRepo) EnabledUserByEmail(ctx context.Context, email string) (*User, error) {
+	s := StatusEnabled
+	return cr.OneUser(ctx, &UserSearch{Email: &email, StatusID: &s})
+}
+
+// UserByOIDCSubject returns not deleted user linked to OpenID Connect subject or nil.
+func (cr CommonRepo) UserByOIDCSubject(ctx context.Context, subject string) (*User, error) {
+	return cr.OneUser(ctx, &UserSearch{OIDCSubject: &subject})
+}
+
 func (cr CommonRepo) UpdateUserPassword(ctx context.Context, dbu *User) (bool, error) {
-	return cr.UpdateUser(ctx, dbu, WithColumns(Columns.User.Password, Columns.User.AuthKey))
+	return cr.UpdateVersionedUser(ctx, dbu, WithColumns(Columns.User.Password))
+}
+
+// WithActive adds condition for sessions which are not expired by absolute and idle expiry.
+func (ss *SessionSearch) WithActive() {
+	ss.With("?.? > now() and ?.? > now()", pg.Ident(Tables.Session.Alias), pg.Ident(Columns.Session.ExpiresAt), pg.Ident(Tables.Session.Alias), pg.Ident(Columns.Session.IdleExpiresAt))
+}
+
+// ActiveSessionByTokenHash returns not expired session with its user by token hash or nil.
+func (cr CommonRepo) ActiveSessionByTokenHash(ctx context.Context, tokenHash string) (*Session, error) {
+	search := &SessionSearch{TokenHash: &tokenHash}
+	search.WithActive()
+	return cr.OneSession(ctx, search, cr.FullSession())
+}
+
+// TouchSession updates session last activity and prolongs idle expiry.
+func (cr CommonRepo) TouchSession(ctx context.Context, session *Session, idleTimeout time.Duration) (bool, error) {
+	session.LastActivityAt = time.Now()
+	session.IdleExpiresAt = session.LastActivityAt.Add(idleTimeout)
+	return cr.UpdateSession(ctx, session, WithColumns(Columns.Session.LastActivityAt, Columns.Session.IdleExpiresAt))
+}
+
+// DeleteUserSessions deletes all user sessions except exceptID if passed.
+func (cr CommonRepo) DeleteUserSessions(ctx context.Context, userID int, exceptID *int) (int, error) {
+	q := cr.db.ModelContext(ctx, (*Session)(nil)).
+		Where("?.? = ?", pg.Ident(Tables.Session.Alias), pg.Ident(Columns.Session.UserID), userID)
+	if exceptID != nil {
+		q.Where("?.? != ?", pg.Ident(Tables.Session.Alias), pg.Ident(Columns.Session.ID), *exceptID)
+	}
+
+	res, err := q.Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// DeleteExpiredSessions deletes expired sessions of the user.
+func (cr CommonRepo) DeleteExpiredSessions(ctx context.Context, userID int) (int, error) {
+	res, err := cr.db.ModelContext(ctx, (*Session)(nil)).
+		Where("?.? = ?", pg.Ident(Tables.Session.Alias), pg.Ident(Columns.Session.UserID), userID).
+		Where("(?.? <= now() or ?.? <= now())", pg.Ident(Tables.Session.Alias), pg.Ident(Columns.Session.ExpiresAt), pg.Ident(Tables.Session.Alias), pg.Ident(Columns.Session.IdleExpiresAt)).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// WithActive adds condition for user tokens which are not used and not expired.
+func (uts *UserTokenSearch) WithActive() {
+	uts.With("?.? is null and ?.? > now()", pg.Ident(Tables.UserToken.Alias), pg.Ident(Columns.UserToken.UsedAt), pg.Ident(Tables.UserToken.Alias), pg.Ident(Columns.UserToken.ExpiresAt))
+}
+
+// ActiveUserTokenByHash returns not used and not expired user token of kind with its user by token hash or nil.
+func (cr CommonRepo) ActiveUserTokenByHash(ctx context.Context, kind, tokenHash string) (*UserToken, error) {
+	search := &UserTokenSearch{Kind: &kind, TokenHash: &tokenHash}
+	search.WithActive()
+	return cr.OneUserToken(ctx, search, cr.FullUserToken())
+}
+
+// UseUserToken marks user token as used, returns false if token was already used.
+func (cr CommonRepo) UseUserToken(ctx context.Context, token *UserToken) (bool, error) {
+	now := time.Now()
+	res, err := cr.db.ModelContext(ctx, &UserToken{ID: token.ID, UsedAt: &now}).
+		Column(Columns.UserToken.UsedAt).
+		WherePK().
+		Where("?.? is null", pg.Ident(Tables.UserToken.Alias), pg.Ident(Columns.UserToken.UsedAt)).
+		Update()
+	if err != nil {
+		return false, err
+	} else if res.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	token.UsedAt = &now
+	return true, nil
+}
+
+// DeleteUnusedUserTokens deletes not used user tokens of kind.
+func (cr CommonRepo) DeleteUnusedUserTokens(ctx context.Context, userID int, kind string) (int, error) {
+	res, err := cr.db.ModelContext(ctx, (*UserToken)(nil)).
+		Where("?.? = ?", pg.Ident(Tables.UserToken.Alias), pg.Ident(Columns.UserToken.UserID), userID).
+		Where("?.? = ?", pg.Ident(Tables.UserToken.Alias), pg.Ident(Columns.UserToken.Kind), kind).
+		Where("?.? is null", pg.Ident(Tables.UserToken.Alias), pg.Ident(Columns.UserToken.UsedAt)).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// ActiveAPIKeyByHash returns enabled not expired api key with its user by key hash or nil.
+func (cr CommonRepo) ActiveAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	s := StatusEnabled
+	search := &APIKeySearch{KeyHash: &keyHash, StatusID: &s}
+	search.With("?.? is null or ?.? > now()", pg.Ident(Tables.APIKey.Alias), pg.Ident(Columns.APIKey.ExpiresAt), pg.Ident(Tables.APIKey.Alias), pg.Ident(Columns.APIKey.ExpiresAt))
+	return cr.OneAPIKey(ctx, search, cr.FullAPIKey())
+}
+
+// TouchAPIKey updates api key last usage time.
+func (cr CommonRepo) TouchAPIKey(ctx context.Context, apiKey *APIKey) (bool, error) {
+	now := time.Now()
+	apiKey.LastUsedAt = &now
+	return cr.UpdateAPIKey(ctx, apiKey, WithColumns(Columns.APIKey.LastUsedAt))
+}
+
+// FailLoginAttempt registers failed attempt for key and returns count of failures.
+// Failures are counted from scratch if last failure was before window and key is not locked.
+func (cr CommonRepo) FailLoginAttempt(ctx context.Context, key string, window time.Duration) (int, error) {
+	attempt := &LoginAttempt{ID: key, Failures: 1}
+	alias := pg.Ident(Tables.LoginAttempt.Alias)
+	_, err := cr.db.ModelContext(ctx, attempt).
+		Value(Columns.LoginAttempt.LastFailureAt, "now()").
+		OnConflict("(?) DO UPDATE", pg.Ident(Columns.LoginAttempt.ID)).
+		Set("? = CASE WHEN ?.? < now() - ? * interval '1 second' AND (?.? is null or ?.? <= now()) THEN 1 ELSE ?.? + 1 END",
+			pg.Ident(Columns.LoginAttempt.Failures),
+			alias, pg.Ident(Columns.LoginAttempt.LastFailureAt), window.Seconds(),
+			alias, pg.Ident(Columns.LoginAttempt.LockedUntil), alias, pg.Ident(Columns.LoginAttempt.LockedUntil),
+			alias, pg.Ident(Columns.LoginAttempt.Failures)).
+		Set("? = now()", pg.Ident(Columns.LoginAttempt.LastFailureAt)).
+		Returning("?", pg.Ident(Columns.LoginAttempt.Failures)).
+		Insert()
+
+	return attempt.Failures, err
+}
+
+// LockLoginAttempt locks key for duration d from now.
+func (cr CommonRepo) LockLoginAttempt(ctx context.Context, key string, d time.Duration) error {
+	_, err := cr.db.ModelContext(ctx, &LoginAttempt{ID: key}).
+		Set("? = now() + ? * interval '1 second'", pg.Ident(Columns.LoginAttempt.LockedUntil), d.Seconds()).
+		WherePK().
+		Update()
+	return err
+}
+
+// WithLocked adds condition for login attempts with active lock.
+func (las *LoginAttemptSearch) WithLocked() {
+	las.With("?.? > now()", pg.Ident(Tables.LoginAttempt.Alias), pg.Ident(Columns.LoginAttempt.LockedUntil))
+}
+
+// LoginAttemptsLockedFor returns the longest remaining lock duration of keys, zero if keys are not locked.
+func (cr CommonRepo) LoginAttemptsLockedFor(ctx context.Context, keys []string) (time.Duration, error) {
+	search := &LoginAttemptSearch{IDs: keys}
+	search.WithLocked()
+
+	var seconds float64
+	err := search.Apply(cr.db.ModelContext(ctx, (*LoginAttempt)(nil))).
+		ColumnExpr("coalesce(extract(epoch FROM max(?.?) - now()), 0)", pg.Ident(Tables.LoginAttempt.Alias), pg.Ident(Columns.LoginAttempt.LockedUntil)).
+		Select(pg.Scan(&seconds))
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// DeleteStaleLoginAttempts deletes keys without failures during window and without active lock.
+func (cr CommonRepo) DeleteStaleLoginAttempts(ctx context.Context, window time.Duration) (int, error) {
+	alias := pg.Ident(Tables.LoginAttempt.Alias)
+	res, err := cr.db.ModelContext(ctx, (*LoginAttempt)(nil)).
+		Where("?.? < now() - ? * interval '1 second'", alias, pg.Ident(Columns.LoginAttempt.LastFailureAt), window.Seconds()).
+		Where("?.? is null or ?.? <= now()", alias, pg.Ident(Columns.LoginAttempt.LockedUntil), alias, pg.Ident(Columns.LoginAttempt.LockedUntil)).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// WithActive adds condition for login challenges which are not expired and have attempts less than maxAttempts.
+func (lcs *LoginChallengeSearch) WithActive(maxAttempts int) {
+	lcs.With("?.? > now() and ?.? < ?", pg.Ident(Tables.LoginChallenge.Alias), pg.Ident(Columns.LoginChallenge.ExpiresAt), pg.Ident(Tables.LoginChallenge.Alias), pg.Ident(Columns.LoginChallenge.Attempts), maxAttempts)
+}
+
+// ActiveLoginChallengeByHash returns not expired login challenge with attempts less than maxAttempts by token hash or nil.
+func (cr CommonRepo) ActiveLoginChallengeByHash(ctx context.Context, tokenHash string, maxAttempts int) (*LoginChallenge, error) {
+	search := &LoginChallengeSearch{TokenHash: &tokenHash}
+	search.WithActive(maxAttempts)
+	return cr.OneLoginChallenge(ctx, search)
+}
+
+// FailLoginChallenge increments attempts of login challenge.
+func (cr CommonRepo) FailLoginChallenge(ctx context.Context, id int) error {
+	_, err := cr.db.ModelContext(ctx, &LoginChallenge{ID: id}).
+		Set("? = ?.? + 1", pg.Ident(Columns.LoginChallenge.Attempts), pg.Ident(Tables.LoginChallenge.Alias), pg.Ident(Columns.LoginChallenge.Attempts)).
+		WherePK().
+		Update()
+	return err
+}
+
+// DeleteExpiredLoginChallenges deletes expired login challenges of all users.
+func (cr CommonRepo) DeleteExpiredLoginChallenges(ctx context.Context) (int, error) {
+	res, err := cr.db.ModelContext(ctx, (*LoginChallenge)(nil)).
+		Where("?.? <= now()", pg.Ident(Tables.LoginChallenge.Alias), pg.Ident(Columns.LoginChallenge.ExpiresAt)).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// UseUserTotpStep saves last accepted TOTP time step of user, returns false if step or later one was already used.
+func (cr CommonRepo) UseUserTotpStep(ctx context.Context, userID int, step int64) (bool, error) {
+	alias := pg.Ident(Tables.User.Alias)
+	res, err := cr.db.ModelContext(ctx, &User{ID: userID, TotpLastStep: &step}).
+		Column(Columns.User.TotpLastStep).
+		WherePK().
+		Where("?.? is null or ?.? < ?", alias, pg.Ident(Columns.User.TotpLastStep), alias, pg.Ident(Columns.User.TotpLastStep), step).
+		Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// UseUserRecoveryCode removes recovery code hash of user, returns false if user has no such hash.
+// Code is removed by single conditional update, so it can't be used twice concurrently.
+func (cr CommonRepo) UseUserRecoveryCode(ctx context.Context, userID int, hash string) (bool, error) {
+	alias := pg.Ident(Tables.User.Alias)
+	res, err := cr.db.ModelContext(ctx, &User{ID: userID}).
+		Column(Columns.User.RecoveryCodes).
+		Value(Columns.User.RecoveryCodes, "array_remove(?.?, ?)", alias, pg.Ident(Columns.User.RecoveryCodes), hash).
+		WherePK().
+		Where("?.? @> ?", alias, pg.Ident(Columns.User.RecoveryCodes), pg.Array([]string{hash})).
+		Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// DeleteExpiredOIDCStates deletes expired authorization requests.
+func (cr CommonRepo) DeleteExpiredOIDCStates(ctx context.Context) (int, error) {
+	res, err := cr.db.ModelContext(ctx, (*OIDCState)(nil)).
+		Where("?.? <= now()", pg.Ident(Tables.OIDCState.Alias), pg.Ident(Columns.OIDCState.ExpiresAt)).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// PopOIDCState deletes and returns not expired authorization request by state hash or nil.
+// Request is deleted by single query, so it is used only once.
+func (cr CommonRepo) PopOIDCState(ctx context.Context, stateHash string) (*OIDCState, error) {
+	state := &OIDCState{ID: stateHash}
+	res, err := cr.db.ModelContext(ctx, state).
+		WherePK().
+		Where("?.? > now()", pg.Ident(Tables.OIDCState.Alias), pg.Ident(Columns.OIDCState.ExpiresAt)).
+		Returning("*").
+		Delete()
+	if err != nil {
+		return nil, err
+	} else if res.RowsAffected() == 0 {
+		return nil, nil
+	}
+
+	return state, nil
+}
+
+// AddVersionedUser adds User to DB with the first version.
+func (cr CommonRepo) AddVersionedUser(ctx context.Context, user *User, ops ...OpFunc) (*User, error) {
+	user.Version = firstVersion
+	return cr.AddUser(ctx, user, ops...)
+}
+
+// UpdateVersionedUser updates User in DB and increments its version.
+// Update is made only if User has the same version in DB, ErrVersionConflict is returned otherwise.
+func (cr CommonRepo) UpdateVersionedUser(ctx context.Context, user *User, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, user).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.User.CreatedAt)
+	}
+	applyOps(q, ops...)
+
+	return updateVersioned(q, Columns.User.Version, user.Version, len(ops) > 0)
 }
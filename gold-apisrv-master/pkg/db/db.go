@@ -19,6 +19,7 @@ type DB struct {
 	embedlog.Logger
 
 	crcTable *crc64.Table
+	replicas *replicaSet
 }
 
 // New is a function that returns DB as wrapper on postgres connection.
@@ -70,9 +71,9 @@ func (db *DB) RunInLock(ctx context.Context, lockName string, fns ...func(*pg.Tx
 	})
 }
 
-// buildQuery applies all functions to orm query.
+// buildQuery applies all functions to orm query. Query is made on replica if db has healthy replicas.
 func buildQuery(ctx context.Context, db orm.DB, model interface{}, search Searcher, filters []Filter, pager Pager, ops ...OpFunc) *orm.Query {
-	q := db.ModelContext(ctx, model)
+	q := reader(ctx, db).ModelContext(ctx, model)
 	for _, filter := range filters {
 		filter.Apply(q)
 	}
@@ -23,8 +23,13 @@ const (
 	SearchTypeArrayContained
 	SearchTypeArrayIntersect
 	SearchTypeJsonbPath
+	SearchTypeNullOrGreater
+	SearchTypeFullText
 )
 
+// FullTextConfig is a text search configuration for tsvector columns and queries.
+const FullTextConfig = "russian"
+
 var formatter = orm.Formatter{}
 
 var searchTypes = map[bool]map[int]string{
@@ -43,6 +48,8 @@ var searchTypes = map[bool]map[int]string{
 		SearchTypeArrayContained: "ARRAY[?] <@",
 		SearchTypeArrayIntersect: "ARRAY[?] &&",
 		SearchTypeJsonbPath:      "@> ?",
+		SearchTypeNullOrGreater:  "> ?",
+		SearchTypeFullText:       "@@ websearch_to_tsquery(?, ?)",
 	},
 	// exclude
 	true: {
@@ -56,6 +63,7 @@ var searchTypes = map[bool]map[int]string{
 		SearchTypeILike:         "not (ilike ?)",
 		SearchTypeArray:         "not in (?)",
 		SearchTypeArrayContains: "!= all (?)",
+		SearchTypeFullText:      "@@ !!websearch_to_tsquery(?, ?)",
 	},
 }
 
@@ -109,6 +117,10 @@ func (f Filter) prepare() (field, value types.ValueAppender) {
 	case SearchTypeArrayContained, SearchTypeArrayIntersect:
 		f.Value = pg.In(f.Value)
 		return pg.SafeQuery(st, f.Value), pg.SafeQuery("?", pg.Ident(f.Field))
+	case SearchTypeNullOrGreater:
+		return pg.SafeQuery("(? is null or ?", pg.Ident(f.Field), pg.Ident(f.Field)), pg.SafeQuery(st+")", f.Value)
+	case SearchTypeFullText:
+		return pg.Ident(f.Field), pg.SafeQuery(st, FullTextConfig, f.Value)
 	}
 
 	return pg.Ident(f.Field), pg.SafeQuery(st, f.Value)
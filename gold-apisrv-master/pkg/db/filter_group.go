@@ -0,0 +1,203 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+var (
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrFilterTooLarge is returned for filter group exceeding depth or conditions limit.
+	ErrFilterTooLarge = errors.New("filter is too large")
+)
+
+// FilterFields are allowed search types by filter field.
+type FilterFields map[string][]int
+
+// Allows checks that search type of field is allowed.
+func (ff FilterFields) Allows(field string, searchType int) bool {
+	for _, st := range ff[field] {
+		if st == searchType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterError is an error of filter group element, path is relative to the group, e.g. groups[0].filters[1].value.
+type FilterError struct {
+	Path string
+	Err  error
+}
+
+// FilterGroup is a filter expression tree: filters and nested groups joined by AND or OR.
+type FilterGroup struct {
+	Or      bool          `json:"or,omitempty"`      //join conditions by OR instead of AND
+	Not     bool          `json:"not,omitempty"`     //negate the whole group
+	Filters []Filter      `json:"filters,omitempty"` //group conditions
+	Groups  []FilterGroup `json:"groups,omitempty"`  //nested groups
+}
+
+// IsEmpty checks that group and nested groups have no filters.
+func (g FilterGroup) IsEmpty() bool {
+	if len(g.Filters) > 0 {
+		return false
+	}
+
+	for _, sg := range g.Groups {
+		if !sg.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Apply applies filter group to go-pg orm as one condition
+func (g FilterGroup) Apply(query *orm.Query) *orm.Query {
+	if g.IsEmpty() {
+		return query
+	}
+
+	if g.Not {
+		return query.WhereNotGroup(g.apply)
+	}
+
+	return query.WhereGroup(g.apply)
+}
+
+// Q returns group applier for search.
+func (g FilterGroup) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		return g.Apply(query), nil
+	}
+}
+
+// apply adds group conditions to query.
+func (g FilterGroup) apply(query *orm.Query) (*orm.Query, error) {
+	for _, f := range g.Filters {
+		fld, val := f.prepare()
+		if g.Or {
+			query.WhereOr("? ?", fld, val)
+		} else {
+			query.Where("? ?", fld, val)
+		}
+	}
+
+	for _, sg := range g.Groups {
+		if sg.IsEmpty() {
+			continue
+		}
+
+		switch {
+		case g.Or && sg.Not:
+			query.WhereOrNotGroup(sg.apply)
+		case g.Or:
+			query.WhereOrGroup(sg.apply)
+		case sg.Not:
+			query.WhereNotGroup(sg.apply)
+		default:
+			query.WhereGroup(sg.apply)
+		}
+	}
+
+	return query, nil
+}
+
+// Validate checks group size, filter fields by allowed fields and filter values. Groups deeper than maxDepth are not checked.
+func (g FilterGroup) Validate(fields FilterFields, maxDepth, maxConditions int) []FilterError {
+	fv := filterValidator{fields: fields, maxDepth: maxDepth}
+	fv.validateGroup(g, "", 1)
+	if fv.count > maxConditions {
+		fv.errs = append(fv.errs, FilterError{Err: fmt.Errorf("%w: more than %d conditions", ErrFilterTooLarge, maxConditions)})
+	}
+
+	return fv.errs
+}
+
+// filterValidator collects errors and counts conditions of filter group.
+type filterValidator struct {
+	fields   FilterFields
+	maxDepth int
+	count    int
+	errs     []FilterError
+}
+
+func (fv *filterValidator) validateGroup(g FilterGroup, path string, depth int) {
+	if depth > fv.maxDepth {
+		fv.errs = append(fv.errs, FilterError{Path: path, Err: fmt.Errorf("%w: more than %d levels", ErrFilterTooLarge, fv.maxDepth)})
+		return
+	}
+
+	for i, f := range g.Filters {
+		fv.count++
+		filterPath := elementPath(path, "filters", i)
+		if !fv.fields.Allows(f.Field, f.SearchType) {
+			fv.errs = append(fv.errs, FilterError{Path: filterPath + ".field", Err: fmt.Errorf("%w: type %d of %s is not allowed", ErrInvalidFilter, f.SearchType, f.Field)})
+			continue
+		}
+
+		if err := f.Validate(); err != nil {
+			fv.errs = append(fv.errs, FilterError{Path: filterPath + ".value", Err: err})
+		}
+	}
+
+	for i := range g.Groups {
+		fv.validateGroup(g.Groups[i], elementPath(path, "groups", i), depth+1)
+	}
+}
+
+// elementPath returns path of i-th element of list in group path.
+func elementPath(path, list string, i int) string {
+	if path == "" {
+		return fmt.Sprintf("%s[%d]", list, i)
+	}
+
+	return fmt.Sprintf("%s.%s[%d]", path, list, i)
+}
+
+// Validate checks that search type is supported and value fits it. Values are expected to be decoded from json.
+func (f Filter) Validate() error {
+	if _, ok := searchTypes[f.Exclude][f.SearchType]; !ok {
+		return fmt.Errorf("%w: unsupported type %d of %s", ErrInvalidFilter, f.SearchType, f.Field)
+	}
+
+	var ok bool
+	switch f.SearchType {
+	case SearchTypeNull:
+		ok = f.Value == nil
+	case SearchTypeLike, SearchTypeILike, SearchTypeFullText, SearchTypeJsonbPath:
+		s, isString := f.Value.(string)
+		ok = isString && s != ""
+	case SearchTypeArray, SearchTypeArrayContained, SearchTypeArrayIntersect:
+		v := reflect.ValueOf(f.Value)
+		ok = v.Kind() == reflect.Slice && v.Len() > 0
+		for i := 0; ok && i < v.Len(); i++ {
+			ok = isScalar(v.Index(i).Interface())
+		}
+	default:
+		ok = isScalar(f.Value)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: unexpected value of %s", ErrInvalidFilter, f.Field)
+	}
+
+	return nil
+}
+
+// isScalar checks that value is a string, number or bool.
+func isScalar(v interface{}) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
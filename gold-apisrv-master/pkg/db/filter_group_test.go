@@ -0,0 +1,43 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// filterErrorPaths returns paths of filter errors and checks that all errors wrap err.
+func filterErrorPaths(errs []FilterError, err error) []string {
+	var paths []string
+	for _, e := range errs {
+		So(errors.Is(e.Err, err), ShouldBeTrue)
+		paths = append(paths, e.Path)
+	}
+	return paths
+}
+
+func TestFilterGroup(t *testing.T) {
+	Convey("Test filter group validation", t, func() {
+		fields := FilterFields{Columns.News.ID: {SearchTypeEquals, SearchTypeArray}, Columns.News.Title: {SearchTypeILike}}
+
+		Convey("Fields and values are checked by path", func() {
+			g := FilterGroup{
+				Filters: []Filter{{Field: Columns.News.ID, Value: 1}, {Field: Columns.News.Title, SearchType: SearchTypeLike, Value: "a"}},
+				Groups: []FilterGroup{{Or: true, Filters: []Filter{
+					{Field: Columns.News.ID, SearchType: SearchTypeArray, Value: []interface{}{}},
+					{Field: Columns.News.Title, SearchType: SearchTypeILike, Value: "a"},
+				}}},
+			}
+			So(g.Validate(fields, 2, 10), ShouldHaveLength, 2)
+			So(filterErrorPaths(g.Validate(fields, 2, 10), ErrInvalidFilter), ShouldResemble, []string{"filters[1].field", "groups[0].filters[0].value"})
+		})
+
+		Convey("Depth and conditions are limited", func() {
+			g := FilterGroup{Filters: []Filter{{Field: Columns.News.ID, Value: 1}, {Field: Columns.News.ID, Value: 2}}, Groups: []FilterGroup{{Groups: []FilterGroup{{}}}}}
+			So(g.Validate(fields, 3, 2), ShouldBeEmpty)
+			So(filterErrorPaths(g.Validate(fields, 2, 2), ErrFilterTooLarge), ShouldResemble, []string{"groups[0].groups[0]"})
+			So(filterErrorPaths(g.Validate(fields, 3, 1), ErrFilterTooLarge), ShouldResemble, []string{""})
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10/types"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is a position of keyset pagination: sort column value and primary key of the last row of previous page.
+type Cursor struct {
+	Column string      `json:"c"`
+	Desc   bool        `json:"d,omitempty"`
+	Value  interface{} `json:"v"`
+	PK     interface{} `json:"k"`
+}
+
+// String returns opaque cursor representation.
+func (c Cursor) String() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseCursor parses cursor from its string representation.
+func ParseCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err = d.Decode(&c); err != nil || c.Column == "" || c.Value == nil || c.PK == nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// Keyset is a keyset pagination by sort column with primary key as a tie breaker.
+// Unlike offset it doesn't skip or repeat rows on inserts and is fast on deep pages. Sort column must be not null.
+type Keyset struct {
+	Column string
+	PK     string
+	Desc   bool
+	After  *Cursor // rows after cursor are selected, first page if nil
+}
+
+// NewKeyset returns keyset by sort column and primary key, cursor must be empty or made by the same sort.
+func NewKeyset(column, pk string, desc bool, cursor string) (*Keyset, error) {
+	k := &Keyset{Column: column, PK: pk, Desc: desc}
+	if cursor == "" {
+		return k, nil
+	}
+
+	c, err := ParseCursor(cursor)
+	if err != nil {
+		return nil, err
+	} else if c.Column != column || c.Desc != desc {
+		return nil, fmt.Errorf("%w: sort mismatch", ErrInvalidCursor)
+	}
+	k.After = c
+
+	return k, nil
+}
+
+// apply adds keyset condition and sort to query.
+func (k Keyset) apply(query *orm.Query) {
+	op, dir := ">", SortAsc
+	if k.Desc {
+		op, dir = "<", SortDesc
+	}
+
+	t, col, pk := types.Ident(TablePrefix), types.Ident(k.Column), types.Ident(k.PK)
+	if k.After != nil {
+		query.Where("(?.?, ?.?) "+op+" (?, ?)", t, col, t, pk, k.After.Value, k.After.PK)
+	}
+
+	query.OrderExpr("?.? ?", t, col, types.Safe(dir))
+	query.OrderExpr("?.? ?", t, pk, types.Safe(dir))
+}
+
+// cursor returns cursor after row, which is a struct or pointer to struct.
+func (k Keyset) cursor(row reflect.Value) (*Cursor, error) {
+	row = reflect.Indirect(row)
+	table := orm.GetTable(row.Type())
+
+	values := make([]interface{}, 2)
+	for i, name := range []string{k.Column, k.PK} {
+		f, err := table.GetField(name)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = f.Value(row).Interface()
+	}
+
+	return &Cursor{Column: k.Column, Desc: k.Desc, Value: values[0], PK: values[1]}, nil
+}
@@ -10,7 +10,38 @@ import (
 
 var Columns = struct {
 	User struct {
-		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID string
+		ID, CreatedAt, Login, Email, OIDCSubject, Password, LastActivityAt, Role, TotpSecret, IsTotpEnabled, RecoveryCodes, TotpLastStep, Version, StatusID string
+	}
+	Session struct {
+		ID, UserID, TokenHash, IsRemember, Device, IP, UserAgent, CreatedAt, LastActivityAt, ExpiresAt, IdleExpiresAt string
+
+		User string
+	}
+	UserToken struct {
+		ID, UserID, Kind, TokenHash, CreatedAt, ExpiresAt, UsedAt string
+
+		User string
+	}
+	APIKey struct {
+		ID, UserID, Title, KeyPrefix, KeyHash, Scopes, IsReadOnly, ExpiresAt, LastUsedAt, CreatedAt, StatusID string
+
+		User string
+	}
+	AuditLog struct {
+		ID, UserID, Namespace, Method, ObjectID, Params, ResultCode, IP, RequestID, CreatedAt string
+
+		User string
+	}
+	LoginAttempt struct {
+		ID, Failures, LastFailureAt, LockedUntil string
+	}
+	LoginChallenge struct {
+		ID, UserID, TokenHash, IsRemember, Attempts, CreatedAt, ExpiresAt string
+
+		User string
+	}
+	OIDCState struct {
+		ID, Nonce, Verifier, IsRemember, CreatedAt, ExpiresAt string
 	}
 	VfsFile struct {
 		ID, FolderID, Title, Path, Params, IsFavorite, MimeType, FileSize, FileExists, CreatedAt, StatusID string
@@ -18,33 +49,151 @@ var Columns = struct {
 		Folder string
 	}
 	VfsFolder struct {
-		ID, ParentFolderID, Title, IsFavorite, CreatedAt, StatusID string
+		ID, ParentFolderID, Title, IsFavorite, IsPrivate, CreatedAt, StatusID string
 
 		ParentFolder string
 	}
 	Category struct {
-		ID, Title, OrderNumber, StatusID string
+		ID, ParentCategoryID, Title, OrderNumber, CoverImage, Version, StatusID string
+
+		ParentCategory string
 	}
 	News struct {
-		ID, Title, Alias, Content, CategoryID, CreatedAt, UpdatedAt, PublicationDate, TagIDs, StatusID string
+		ID, Title, Alias, Content, CategoryID, CreatedAt, UpdatedAt, PublicationDate, UnpublishDate, PublishedAt, UnpublishedAt, TagIDs, CoverImage, GalleryImages, Version, StatusID string
 
 		Category string
 	}
+	NewsRevision struct {
+		ID, NewsID, Title, Alias, Content, CategoryID, TagIDs, StatusID, UserID, CreatedAt string
+
+		News, User string
+	}
 	Tag struct {
-		ID, Title, StatusID string
+		ID, Title, Alias, Version, StatusID string
 	}
 }{
 	User: struct {
-		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID string
+		ID, CreatedAt, Login, Email, OIDCSubject, Password, LastActivityAt, Role, TotpSecret, IsTotpEnabled, RecoveryCodes, TotpLastStep, Version, StatusID string
 	}{
 		ID:             "userId",
 		CreatedAt:      "createdAt",
 		Login:          "login",
+		Email:          "email",
+		OIDCSubject:    "oidcSubject",
 		Password:       "password",
-		AuthKey:        "authKey",
 		LastActivityAt: "lastActivityAt",
+		Role:           "role",
+		TotpSecret:     "totpSecret",
+		IsTotpEnabled:  "isTotpEnabled",
+		RecoveryCodes:  "recoveryCodes",
+		TotpLastStep:   "totpLastStep",
+		Version:        "version",
 		StatusID:       "statusId",
 	},
+	Session: struct {
+		ID, UserID, TokenHash, IsRemember, Device, IP, UserAgent, CreatedAt, LastActivityAt, ExpiresAt, IdleExpiresAt string
+
+		User string
+	}{
+		ID:             "sessionId",
+		UserID:         "userId",
+		TokenHash:      "tokenHash",
+		IsRemember:     "isRemember",
+		Device:         "device",
+		IP:             "ip",
+		UserAgent:      "userAgent",
+		CreatedAt:      "createdAt",
+		LastActivityAt: "lastActivityAt",
+		ExpiresAt:      "expiresAt",
+		IdleExpiresAt:  "idleExpiresAt",
+
+		User: "User",
+	},
+	UserToken: struct {
+		ID, UserID, Kind, TokenHash, CreatedAt, ExpiresAt, UsedAt string
+
+		User string
+	}{
+		ID:        "userTokenId",
+		UserID:    "userId",
+		Kind:      "kind",
+		TokenHash: "tokenHash",
+		CreatedAt: "createdAt",
+		ExpiresAt: "expiresAt",
+		UsedAt:    "usedAt",
+
+		User: "User",
+	},
+	APIKey: struct {
+		ID, UserID, Title, KeyPrefix, KeyHash, Scopes, IsReadOnly, ExpiresAt, LastUsedAt, CreatedAt, StatusID string
+
+		User string
+	}{
+		ID:         "apiKeyId",
+		UserID:     "userId",
+		Title:      "title",
+		KeyPrefix:  "keyPrefix",
+		KeyHash:    "keyHash",
+		Scopes:     "scopes",
+		IsReadOnly: "isReadOnly",
+		ExpiresAt:  "expiresAt",
+		LastUsedAt: "lastUsedAt",
+		CreatedAt:  "createdAt",
+		StatusID:   "statusId",
+
+		User: "User",
+	},
+	AuditLog: struct {
+		ID, UserID, Namespace, Method, ObjectID, Params, ResultCode, IP, RequestID, CreatedAt string
+
+		User string
+	}{
+		ID:         "auditLogId",
+		UserID:     "userId",
+		Namespace:  "namespace",
+		Method:     "method",
+		ObjectID:   "objectId",
+		Params:     "params",
+		ResultCode: "resultCode",
+		IP:         "ip",
+		RequestID:  "requestId",
+		CreatedAt:  "createdAt",
+
+		User: "User",
+	},
+	LoginAttempt: struct {
+		ID, Failures, LastFailureAt, LockedUntil string
+	}{
+		ID:            "key",
+		Failures:      "failures",
+		LastFailureAt: "lastFailureAt",
+		LockedUntil:   "lockedUntil",
+	},
+	LoginChallenge: struct {
+		ID, UserID, TokenHash, IsRemember, Attempts, CreatedAt, ExpiresAt string
+
+		User string
+	}{
+		ID:         "loginChallengeId",
+		UserID:     "userId",
+		TokenHash:  "tokenHash",
+		IsRemember: "isRemember",
+		Attempts:   "attempts",
+		CreatedAt:  "createdAt",
+		ExpiresAt:  "expiresAt",
+
+		User: "User",
+	},
+	OIDCState: struct {
+		ID, Nonce, Verifier, IsRemember, CreatedAt, ExpiresAt string
+	}{
+		ID:         "stateHash",
+		Nonce:      "nonce",
+		Verifier:   "verifier",
+		IsRemember: "isRemember",
+		CreatedAt:  "createdAt",
+		ExpiresAt:  "expiresAt",
+	},
 	VfsFile: struct {
 		ID, FolderID, Title, Path, Params, IsFavorite, MimeType, FileSize, FileExists, CreatedAt, StatusID string
 
@@ -65,7 +214,7 @@ var Columns = struct {
 		Folder: "Folder",
 	},
 	VfsFolder: struct {
-		ID, ParentFolderID, Title, IsFavorite, CreatedAt, StatusID string
+		ID, ParentFolderID, Title, IsFavorite, IsPrivate, CreatedAt, StatusID string
 
 		ParentFolder string
 	}{
@@ -73,21 +222,29 @@ var Columns = struct {
 		ParentFolderID: "parentFolderId",
 		Title:          "title",
 		IsFavorite:     "isFavorite",
+		IsPrivate:      "isPrivate",
 		CreatedAt:      "createdAt",
 		StatusID:       "statusId",
 
 		ParentFolder: "ParentFolder",
 	},
 	Category: struct {
-		ID, Title, OrderNumber, StatusID string
+		ID, ParentCategoryID, Title, OrderNumber, CoverImage, Version, StatusID string
+
+		ParentCategory string
 	}{
-		ID:          "categoryId",
-		Title:       "title",
-		OrderNumber: "orderNumber",
-		StatusID:    "statusId",
+		ID:               "categoryId",
+		ParentCategoryID: "parentCategoryId",
+		Title:            "title",
+		OrderNumber:      "orderNumber",
+		CoverImage:       "coverImage",
+		Version:          "version",
+		StatusID:         "statusId",
+
+		ParentCategory: "ParentCategory",
 	},
 	News: struct {
-		ID, Title, Alias, Content, CategoryID, CreatedAt, UpdatedAt, PublicationDate, TagIDs, StatusID string
+		ID, Title, Alias, Content, CategoryID, CreatedAt, UpdatedAt, PublicationDate, UnpublishDate, PublishedAt, UnpublishedAt, TagIDs, CoverImage, GalleryImages, Version, StatusID string
 
 		Category string
 	}{
@@ -99,16 +256,43 @@ var Columns = struct {
 		CreatedAt:       "createdAt",
 		UpdatedAt:       "updatedAt",
 		PublicationDate: "publicationDate",
+		UnpublishDate:   "unpublishDate",
+		PublishedAt:     "publishedAt",
+		UnpublishedAt:   "unpublishedAt",
 		TagIDs:          "tagIds",
+		CoverImage:      "coverImage",
+		GalleryImages:   "galleryImages",
+		Version:         "version",
 		StatusID:        "statusId",
 
 		Category: "Category",
 	},
+	NewsRevision: struct {
+		ID, NewsID, Title, Alias, Content, CategoryID, TagIDs, StatusID, UserID, CreatedAt string
+
+		News, User string
+	}{
+		ID:         "revisionId",
+		NewsID:     "newsId",
+		Title:      "title",
+		Alias:      "alias",
+		Content:    "content",
+		CategoryID: "categoryId",
+		TagIDs:     "tagIds",
+		StatusID:   "statusId",
+		UserID:     "userId",
+		CreatedAt:  "createdAt",
+
+		News: "News",
+		User: "User",
+	},
 	Tag: struct {
-		ID, Title, StatusID string
+		ID, Title, Alias, Version, StatusID string
 	}{
 		ID:       "tagId",
 		Title:    "title",
+		Alias:    "alias",
+		Version:  "version",
 		StatusID: "statusId",
 	},
 }
@@ -117,6 +301,27 @@ var Tables = struct {
 	User struct {
 		Name, Alias string
 	}
+	Session struct {
+		Name, Alias string
+	}
+	UserToken struct {
+		Name, Alias string
+	}
+	APIKey struct {
+		Name, Alias string
+	}
+	AuditLog struct {
+		Name, Alias string
+	}
+	LoginAttempt struct {
+		Name, Alias string
+	}
+	LoginChallenge struct {
+		Name, Alias string
+	}
+	OIDCState struct {
+		Name, Alias string
+	}
 	VfsFile struct {
 		Name, Alias string
 	}
@@ -129,6 +334,9 @@ var Tables = struct {
 	News struct {
 		Name, Alias string
 	}
+	NewsRevision struct {
+		Name, Alias string
+	}
 	Tag struct {
 		Name, Alias string
 	}
@@ -139,6 +347,48 @@ var Tables = struct {
 		Name:  "users",
 		Alias: "t",
 	},
+	Session: struct {
+		Name, Alias string
+	}{
+		Name:  "sessions",
+		Alias: "t",
+	},
+	UserToken: struct {
+		Name, Alias string
+	}{
+		Name:  "userTokens",
+		Alias: "t",
+	},
+	APIKey: struct {
+		Name, Alias string
+	}{
+		Name:  "apiKeys",
+		Alias: "t",
+	},
+	AuditLog: struct {
+		Name, Alias string
+	}{
+		Name:  "auditLog",
+		Alias: "t",
+	},
+	LoginAttempt: struct {
+		Name, Alias string
+	}{
+		Name:  "loginAttempts",
+		Alias: "t",
+	},
+	LoginChallenge: struct {
+		Name, Alias string
+	}{
+		Name:  "loginChallenges",
+		Alias: "t",
+	},
+	OIDCState: struct {
+		Name, Alias string
+	}{
+		Name:  "oidcStates",
+		Alias: "t",
+	},
 	VfsFile: struct {
 		Name, Alias string
 	}{
@@ -163,6 +413,12 @@ var Tables = struct {
 		Name:  "news",
 		Alias: "t",
 	},
+	NewsRevision: struct {
+		Name, Alias string
+	}{
+		Name:  "newsRevisions",
+		Alias: "t",
+	},
 	Tag: struct {
 		Name, Alias string
 	}{
@@ -177,12 +433,120 @@ type User struct {
 	ID             int        `pg:"userId,pk"`
 	CreatedAt      time.Time  `pg:"createdAt,use_zero"`
 	Login          string     `pg:"login,use_zero"`
+	Email          *string    `pg:"email"`
+	OIDCSubject    *string    `pg:"oidcSubject"`
 	Password       string     `pg:"password,use_zero"`
-	AuthKey        string     `pg:"authKey,use_zero"`
 	LastActivityAt *time.Time `pg:"lastActivityAt"`
+	Role           string     `pg:"role,use_zero"`
+	TotpSecret     *string    `pg:"totpSecret"`
+	IsTotpEnabled  bool       `pg:"isTotpEnabled,use_zero"`
+	RecoveryCodes  []string   `pg:"recoveryCodes,array"`
+	TotpLastStep   *int64     `pg:"totpLastStep"`
+	Version        int        `pg:"version,use_zero"`
 	StatusID       int        `pg:"statusId,use_zero"`
 }
 
+type Session struct {
+	tableName struct{} `pg:"sessions,alias:t,discard_unknown_columns"`
+
+	ID             int       `pg:"sessionId,pk"`
+	UserID         int       `pg:"userId,use_zero"`
+	TokenHash      string    `pg:"tokenHash,use_zero"`
+	IsRemember     bool      `pg:"isRemember,use_zero"`
+	Device         *string   `pg:"device"`
+	IP             *string   `pg:"ip"`
+	UserAgent      *string   `pg:"userAgent"`
+	CreatedAt      time.Time `pg:"createdAt,use_zero"`
+	LastActivityAt time.Time `pg:"lastActivityAt,use_zero"`
+	ExpiresAt      time.Time `pg:"expiresAt,use_zero"`
+	IdleExpiresAt  time.Time `pg:"idleExpiresAt,use_zero"`
+
+	User *User `pg:"fk:userId,rel:has-one"`
+}
+
+type UserToken struct {
+	tableName struct{} `pg:"userTokens,alias:t,discard_unknown_columns"`
+
+	ID        int        `pg:"userTokenId,pk"`
+	UserID    int        `pg:"userId,use_zero"`
+	Kind      string     `pg:"kind,use_zero"`
+	TokenHash string     `pg:"tokenHash,use_zero"`
+	CreatedAt time.Time  `pg:"createdAt,use_zero"`
+	ExpiresAt time.Time  `pg:"expiresAt,use_zero"`
+	UsedAt    *time.Time `pg:"usedAt"`
+
+	User *User `pg:"fk:userId,rel:has-one"`
+}
+
+type APIKey struct {
+	tableName struct{} `pg:"apiKeys,alias:t,discard_unknown_columns"`
+
+	ID         int                 `pg:"apiKeyId,pk"`
+	UserID     int                 `pg:"userId,use_zero"`
+	Title      string              `pg:"title,use_zero"`
+	KeyPrefix  string              `pg:"keyPrefix,use_zero"`
+	KeyHash    string              `pg:"keyHash,use_zero"`
+	Scopes     map[string][]string `pg:"scopes,use_zero"`
+	IsReadOnly bool                `pg:"isReadOnly,use_zero"`
+	ExpiresAt  *time.Time          `pg:"expiresAt"`
+	LastUsedAt *time.Time          `pg:"lastUsedAt"`
+	CreatedAt  time.Time           `pg:"createdAt,use_zero"`
+	StatusID   int                 `pg:"statusId,use_zero"`
+
+	User *User `pg:"fk:userId,rel:has-one"`
+}
+
+type AuditLog struct {
+	tableName struct{} `pg:"auditLog,alias:t,discard_unknown_columns"`
+
+	ID         int                    `pg:"auditLogId,pk"`
+	UserID     *int                   `pg:"userId"`
+	Namespace  string                 `pg:"namespace,use_zero"`
+	Method     string                 `pg:"method,use_zero"`
+	ObjectID   *int                   `pg:"objectId"`
+	Params     map[string]interface{} `pg:"params"`
+	ResultCode int                    `pg:"resultCode,use_zero"`
+	IP         *string                `pg:"ip"`
+	RequestID  *string                `pg:"requestId"`
+	CreatedAt  time.Time              `pg:"createdAt,use_zero"`
+
+	User *User `pg:"fk:userId,rel:has-one"`
+}
+
+type LoginAttempt struct {
+	tableName struct{} `pg:"loginAttempts,alias:t,discard_unknown_columns"`
+
+	ID            string     `pg:"key,pk"`
+	Failures      int        `pg:"failures,use_zero"`
+	LastFailureAt time.Time  `pg:"lastFailureAt,use_zero"`
+	LockedUntil   *time.Time `pg:"lockedUntil"`
+}
+
+type LoginChallenge struct {
+	tableName struct{} `pg:"loginChallenges,alias:t,discard_unknown_columns"`
+
+	ID         int       `pg:"loginChallengeId,pk"`
+	UserID     int       `pg:"userId,use_zero"`
+	TokenHash  string    `pg:"tokenHash,use_zero"`
+	IsRemember bool      `pg:"isRemember,use_zero"`
+	Attempts   int       `pg:"attempts,use_zero"`
+	CreatedAt  time.Time `pg:"createdAt,use_zero"`
+	ExpiresAt  time.Time `pg:"expiresAt,use_zero"`
+
+	User *User `pg:"fk:userId,rel:has-one"`
+}
+
+type OIDCState struct {
+	tableName struct{} `pg:"oidcStates,alias:t,discard_unknown_columns"`
+
+	ID         string    `pg:"stateHash,pk"`
+	Nonce      string    `pg:"nonce,use_zero"`
+	Verifier   string    `pg:"verifier,use_zero"`
+	IsRemember bool      `pg:"isRemember,use_zero"`
+	CreatedAt  time.Time `pg:"createdAt,use_zero"`
+	ExpiresAt  time.Time `pg:"expiresAt,use_zero"`
+}
+
 type VfsFile struct {
 	tableName struct{} `pg:"vfsFiles,alias:t,discard_unknown_columns"`
 
@@ -208,6 +572,7 @@ type VfsFolder struct {
 	ParentFolderID *int      `pg:"parentFolderId"`
 	Title          string    `pg:"title,use_zero"`
 	IsFavorite     *bool     `pg:"isFavorite"`
+	IsPrivate      bool      `pg:"isPrivate,use_zero"`
 	CreatedAt      time.Time `pg:"createdAt,use_zero"`
 	StatusID       int       `pg:"statusId,use_zero"`
 
@@ -217,10 +582,15 @@ type VfsFolder struct {
 type Category struct {
 	tableName struct{} `pg:"categories,alias:t,discard_unknown_columns"`
 
-	ID          int    `pg:"categoryId,pk"`
-	Title       string `pg:"title,use_zero"`
-	OrderNumber int    `pg:"orderNumber,use_zero"`
-	StatusID    int    `pg:"statusId,use_zero"`
+	ID               int     `pg:"categoryId,pk"`
+	ParentCategoryID *int    `pg:"parentCategoryId"`
+	Title            string  `pg:"title,use_zero"`
+	OrderNumber      int     `pg:"orderNumber,use_zero"`
+	CoverImage       *string `pg:"coverImage"`
+	Version          int     `pg:"version,use_zero"`
+	StatusID         int     `pg:"statusId,use_zero"`
+
+	ParentCategory *Category `pg:"fk:parentCategoryId,rel:has-one"`
 }
 
 type News struct {
@@ -234,16 +604,42 @@ type News struct {
 	CreatedAt       time.Time  `pg:"createdAt,use_zero"`
 	UpdatedAt       *time.Time `pg:"updatedAt"`
 	PublicationDate time.Time  `pg:"publicationDate,use_zero"`
+	UnpublishDate   *time.Time `pg:"unpublishDate"`
+	PublishedAt     *time.Time `pg:"publishedAt"`
+	UnpublishedAt   *time.Time `pg:"unpublishedAt"`
 	TagIDs          []int      `pg:"tagIds,array"`
+	CoverImage      *string    `pg:"coverImage"`
+	GalleryImages   []string   `pg:"galleryImages,array"`
+	Version         int        `pg:"version,use_zero"`
 	StatusID        int        `pg:"statusId,use_zero"`
 
 	Category *Category `pg:"fk:categoryId,rel:has-one"`
 }
 
+type NewsRevision struct {
+	tableName struct{} `pg:"newsRevisions,alias:t,discard_unknown_columns"`
+
+	ID         int       `pg:"revisionId,pk"`
+	NewsID     int       `pg:"newsId,use_zero"`
+	Title      string    `pg:"title,use_zero"`
+	Alias      string    `pg:"alias,use_zero"`
+	Content    *string   `pg:"content"`
+	CategoryID int       `pg:"categoryId,use_zero"`
+	TagIDs     []int     `pg:"tagIds,array"`
+	StatusID   int       `pg:"statusId,use_zero"`
+	UserID     *int      `pg:"userId"`
+	CreatedAt  time.Time `pg:"createdAt,use_zero"`
+
+	News *News `pg:"fk:newsId,rel:has-one"`
+	User *User `pg:"fk:userId,rel:has-one"`
+}
+
 type Tag struct {
 	tableName struct{} `pg:"tags,alias:t,discard_unknown_columns"`
 
 	ID       int    `pg:"tagId,pk"`
 	Title    string `pg:"title,use_zero"`
+	Alias    string `pg:"alias,use_zero"`
+	Version  int    `pg:"version,use_zero"`
 	StatusID int    `pg:"statusId,use_zero"`
 }
@@ -58,15 +58,20 @@ type UserSearch struct {
 	ID                 *int
 	CreatedAt          *time.Time
 	Login              *string
+	Email              *string
+	OIDCSubject        *string
 	Password           *string
-	AuthKey            *string
 	LastActivityAt     *time.Time
+	Role               *string
+	TotpSecret         *string
+	IsTotpEnabled      *bool
+	TotpLastStep       *int64
+	Version            *int
 	StatusID           *int
 	IDs                []int
 	NotID              *int
 	LoginILike         *string
 	PasswordILike      *string
-	AuthKeyILike       *string
 	LastActivityAtFrom *time.Time
 	LastActivityAtTo   *time.Time
 }
@@ -84,15 +89,33 @@ func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
 	if us.Login != nil {
 		us.where(query, Tables.User.Alias, Columns.User.Login, us.Login)
 	}
+	if us.Email != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Email, us.Email)
+	}
+	if us.OIDCSubject != nil {
+		us.where(query, Tables.User.Alias, Columns.User.OIDCSubject, us.OIDCSubject)
+	}
 	if us.Password != nil {
 		us.where(query, Tables.User.Alias, Columns.User.Password, us.Password)
 	}
-	if us.AuthKey != nil {
-		us.where(query, Tables.User.Alias, Columns.User.AuthKey, us.AuthKey)
-	}
 	if us.LastActivityAt != nil {
 		us.where(query, Tables.User.Alias, Columns.User.LastActivityAt, us.LastActivityAt)
 	}
+	if us.Role != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Role, us.Role)
+	}
+	if us.TotpSecret != nil {
+		us.where(query, Tables.User.Alias, Columns.User.TotpSecret, us.TotpSecret)
+	}
+	if us.IsTotpEnabled != nil {
+		us.where(query, Tables.User.Alias, Columns.User.IsTotpEnabled, us.IsTotpEnabled)
+	}
+	if us.TotpLastStep != nil {
+		us.where(query, Tables.User.Alias, Columns.User.TotpLastStep, us.TotpLastStep)
+	}
+	if us.Version != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Version, us.Version)
+	}
 	if us.StatusID != nil {
 		us.where(query, Tables.User.Alias, Columns.User.StatusID, us.StatusID)
 	}
@@ -108,9 +131,6 @@ func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
 	if us.PasswordILike != nil {
 		Filter{Columns.User.Password, *us.PasswordILike, SearchTypeILike, false}.Apply(query)
 	}
-	if us.AuthKeyILike != nil {
-		Filter{Columns.User.AuthKey, *us.AuthKeyILike, SearchTypeILike, false}.Apply(query)
-	}
 	if us.LastActivityAtFrom != nil {
 		Filter{Columns.User.LastActivityAt, *us.LastActivityAtFrom, SearchTypeGE, false}.Apply(query)
 	}
@@ -227,6 +247,7 @@ type VfsFolderSearch struct {
 	ParentFolderID *int
 	Title          *string
 	IsFavorite     *bool
+	IsPrivate      *bool
 	CreatedAt      *time.Time
 	StatusID       *int
 	IDs            []int
@@ -249,6 +270,9 @@ func (vfs *VfsFolderSearch) Apply(query *orm.Query) *orm.Query {
 	if vfs.IsFavorite != nil {
 		vfs.where(query, Tables.VfsFolder.Alias, Columns.VfsFolder.IsFavorite, vfs.IsFavorite)
 	}
+	if vfs.IsPrivate != nil {
+		vfs.where(query, Tables.VfsFolder.Alias, Columns.VfsFolder.IsPrivate, vfs.IsPrivate)
+	}
 	if vfs.CreatedAt != nil {
 		vfs.where(query, Tables.VfsFolder.Alias, Columns.VfsFolder.CreatedAt, vfs.CreatedAt)
 	}
@@ -279,12 +303,15 @@ func (vfs *VfsFolderSearch) Q() applier {
 type CategorySearch struct {
 	search
 
-	ID          *int
-	Title       *string
-	OrderNumber *int
-	StatusID    *int
-	IDs         []int
-	TitleILike  *string
+	ID               *int
+	ParentCategoryID *int
+	Title            *string
+	OrderNumber      *int
+	Version          *int
+	StatusID         *int
+	IDs              []int
+	NotID            *int
+	TitleILike       *string
 }
 
 func (cs *CategorySearch) Apply(query *orm.Query) *orm.Query {
@@ -294,18 +321,27 @@ func (cs *CategorySearch) Apply(query *orm.Query) *orm.Query {
 	if cs.ID != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.ID, cs.ID)
 	}
+	if cs.ParentCategoryID != nil {
+		cs.where(query, Tables.Category.Alias, Columns.Category.ParentCategoryID, cs.ParentCategoryID)
+	}
 	if cs.Title != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.Title, cs.Title)
 	}
 	if cs.OrderNumber != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.OrderNumber, cs.OrderNumber)
 	}
+	if cs.Version != nil {
+		cs.where(query, Tables.Category.Alias, Columns.Category.Version, cs.Version)
+	}
 	if cs.StatusID != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.StatusID, cs.StatusID)
 	}
 	if len(cs.IDs) > 0 {
 		Filter{Columns.Category.ID, cs.IDs, SearchTypeArray, false}.Apply(query)
 	}
+	if cs.NotID != nil {
+		Filter{Columns.Category.ID, *cs.NotID, SearchTypeEquals, true}.Apply(query)
+	}
 	if cs.TitleILike != nil {
 		Filter{Columns.Category.Title, *cs.TitleILike, SearchTypeILike, false}.Apply(query)
 	}
@@ -335,11 +371,16 @@ type NewsSearch struct {
 	CreatedAt       *time.Time
 	UpdatedAt       *time.Time
 	PublicationDate *time.Time
+	UnpublishDate   *time.Time
+	PublishedAt     *time.Time
+	UnpublishedAt   *time.Time
+	Version         *int
 	StatusID        *int
 	IDs             []int
 	NotID           *int
 	TitleILike      *string
 	ContentILike    *string
+	TagID           *int
 }
 
 func (ns *NewsSearch) Apply(query *orm.Query) *orm.Query {
@@ -370,6 +411,18 @@ func (ns *NewsSearch) Apply(query *orm.Query) *orm.Query {
 	if ns.PublicationDate != nil {
 		ns.where(query, Tables.News.Alias, Columns.News.PublicationDate, ns.PublicationDate)
 	}
+	if ns.UnpublishDate != nil {
+		ns.where(query, Tables.News.Alias, Columns.News.UnpublishDate, ns.UnpublishDate)
+	}
+	if ns.PublishedAt != nil {
+		ns.where(query, Tables.News.Alias, Columns.News.PublishedAt, ns.PublishedAt)
+	}
+	if ns.UnpublishedAt != nil {
+		ns.where(query, Tables.News.Alias, Columns.News.UnpublishedAt, ns.UnpublishedAt)
+	}
+	if ns.Version != nil {
+		ns.where(query, Tables.News.Alias, Columns.News.Version, ns.Version)
+	}
 	if ns.StatusID != nil {
 		ns.where(query, Tables.News.Alias, Columns.News.StatusID, ns.StatusID)
 	}
@@ -385,6 +438,9 @@ func (ns *NewsSearch) Apply(query *orm.Query) *orm.Query {
 	if ns.ContentILike != nil {
 		Filter{Columns.News.Content, *ns.ContentILike, SearchTypeILike, false}.Apply(query)
 	}
+	if ns.TagID != nil {
+		Filter{Columns.News.TagIDs, *ns.TagID, SearchTypeArrayContains, false}.Apply(query)
+	}
 
 	ns.apply(query)
 
@@ -400,13 +456,428 @@ func (ns *NewsSearch) Q() applier {
 	}
 }
 
+type SessionSearch struct {
+	search
+
+	ID         *int
+	UserID     *int
+	TokenHash  *string
+	IsRemember *bool
+	IDs        []int
+	NotID      *int
+}
+
+func (ss *SessionSearch) Apply(query *orm.Query) *orm.Query {
+	if ss == nil {
+		return query
+	}
+	if ss.ID != nil {
+		ss.where(query, Tables.Session.Alias, Columns.Session.ID, ss.ID)
+	}
+	if ss.UserID != nil {
+		ss.where(query, Tables.Session.Alias, Columns.Session.UserID, ss.UserID)
+	}
+	if ss.TokenHash != nil {
+		ss.where(query, Tables.Session.Alias, Columns.Session.TokenHash, ss.TokenHash)
+	}
+	if ss.IsRemember != nil {
+		ss.where(query, Tables.Session.Alias, Columns.Session.IsRemember, ss.IsRemember)
+	}
+	if len(ss.IDs) > 0 {
+		Filter{Columns.Session.ID, ss.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if ss.NotID != nil {
+		Filter{Columns.Session.ID, *ss.NotID, SearchTypeEquals, true}.Apply(query)
+	}
+
+	ss.apply(query)
+
+	return query
+}
+
+func (ss *SessionSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if ss == nil {
+			return query, nil
+		}
+		return ss.Apply(query), nil
+	}
+}
+
+type UserTokenSearch struct {
+	search
+
+	ID        *int
+	UserID    *int
+	Kind      *string
+	TokenHash *string
+	IDs       []int
+}
+
+func (uts *UserTokenSearch) Apply(query *orm.Query) *orm.Query {
+	if uts == nil {
+		return query
+	}
+	if uts.ID != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.ID, uts.ID)
+	}
+	if uts.UserID != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.UserID, uts.UserID)
+	}
+	if uts.Kind != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.Kind, uts.Kind)
+	}
+	if uts.TokenHash != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.TokenHash, uts.TokenHash)
+	}
+	if len(uts.IDs) > 0 {
+		Filter{Columns.UserToken.ID, uts.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	uts.apply(query)
+
+	return query
+}
+
+func (uts *UserTokenSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if uts == nil {
+			return query, nil
+		}
+		return uts.Apply(query), nil
+	}
+}
+
+type APIKeySearch struct {
+	search
+
+	ID         *int
+	UserID     *int
+	Title      *string
+	KeyPrefix  *string
+	KeyHash    *string
+	IsReadOnly *bool
+	StatusID   *int
+	IDs        []int
+	TitleILike *string
+}
+
+func (aks *APIKeySearch) Apply(query *orm.Query) *orm.Query {
+	if aks == nil {
+		return query
+	}
+	if aks.ID != nil {
+		aks.where(query, Tables.APIKey.Alias, Columns.APIKey.ID, aks.ID)
+	}
+	if aks.UserID != nil {
+		aks.where(query, Tables.APIKey.Alias, Columns.APIKey.UserID, aks.UserID)
+	}
+	if aks.Title != nil {
+		aks.where(query, Tables.APIKey.Alias, Columns.APIKey.Title, aks.Title)
+	}
+	if aks.KeyPrefix != nil {
+		aks.where(query, Tables.APIKey.Alias, Columns.APIKey.KeyPrefix, aks.KeyPrefix)
+	}
+	if aks.KeyHash != nil {
+		aks.where(query, Tables.APIKey.Alias, Columns.APIKey.KeyHash, aks.KeyHash)
+	}
+	if aks.IsReadOnly != nil {
+		aks.where(query, Tables.APIKey.Alias, Columns.APIKey.IsReadOnly, aks.IsReadOnly)
+	}
+	if aks.StatusID != nil {
+		aks.where(query, Tables.APIKey.Alias, Columns.APIKey.StatusID, aks.StatusID)
+	}
+	if len(aks.IDs) > 0 {
+		Filter{Columns.APIKey.ID, aks.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if aks.TitleILike != nil {
+		Filter{Columns.APIKey.Title, *aks.TitleILike, SearchTypeILike, false}.Apply(query)
+	}
+
+	aks.apply(query)
+
+	return query
+}
+
+func (aks *APIKeySearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if aks == nil {
+			return query, nil
+		}
+		return aks.Apply(query), nil
+	}
+}
+
+type AuditLogSearch struct {
+	search
+
+	ID            *int
+	UserID        *int
+	Namespace     *string
+	Method        *string
+	ObjectID      *int
+	ResultCode    *int
+	IP            *string
+	RequestID     *string
+	IDs           []int
+	CreatedAtFrom *time.Time
+	CreatedAtTo   *time.Time
+}
+
+func (als *AuditLogSearch) Apply(query *orm.Query) *orm.Query {
+	if als == nil {
+		return query
+	}
+	if als.ID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.ID, als.ID)
+	}
+	if als.UserID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.UserID, als.UserID)
+	}
+	if als.Namespace != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.Namespace, als.Namespace)
+	}
+	if als.Method != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.Method, als.Method)
+	}
+	if als.ObjectID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.ObjectID, als.ObjectID)
+	}
+	if als.ResultCode != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.ResultCode, als.ResultCode)
+	}
+	if als.IP != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.IP, als.IP)
+	}
+	if als.RequestID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.RequestID, als.RequestID)
+	}
+	if len(als.IDs) > 0 {
+		Filter{Columns.AuditLog.ID, als.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if als.CreatedAtFrom != nil {
+		Filter{Columns.AuditLog.CreatedAt, *als.CreatedAtFrom, SearchTypeGE, false}.Apply(query)
+	}
+	if als.CreatedAtTo != nil {
+		Filter{Columns.AuditLog.CreatedAt, *als.CreatedAtTo, SearchTypeLE, false}.Apply(query)
+	}
+
+	als.apply(query)
+
+	return query
+}
+
+func (als *AuditLogSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if als == nil {
+			return query, nil
+		}
+		return als.Apply(query), nil
+	}
+}
+
+type LoginAttemptSearch struct {
+	search
+
+	ID       *string
+	Failures *int
+	IDs      []string
+}
+
+func (las *LoginAttemptSearch) Apply(query *orm.Query) *orm.Query {
+	if las == nil {
+		return query
+	}
+	if las.ID != nil {
+		las.where(query, Tables.LoginAttempt.Alias, Columns.LoginAttempt.ID, las.ID)
+	}
+	if las.Failures != nil {
+		las.where(query, Tables.LoginAttempt.Alias, Columns.LoginAttempt.Failures, las.Failures)
+	}
+	if len(las.IDs) > 0 {
+		Filter{Columns.LoginAttempt.ID, las.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	las.apply(query)
+
+	return query
+}
+
+func (las *LoginAttemptSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if las == nil {
+			return query, nil
+		}
+		return las.Apply(query), nil
+	}
+}
+
+type LoginChallengeSearch struct {
+	search
+
+	ID         *int
+	UserID     *int
+	TokenHash  *string
+	IsRemember *bool
+	Attempts   *int
+	IDs        []int
+}
+
+func (lcs *LoginChallengeSearch) Apply(query *orm.Query) *orm.Query {
+	if lcs == nil {
+		return query
+	}
+	if lcs.ID != nil {
+		lcs.where(query, Tables.LoginChallenge.Alias, Columns.LoginChallenge.ID, lcs.ID)
+	}
+	if lcs.UserID != nil {
+		lcs.where(query, Tables.LoginChallenge.Alias, Columns.LoginChallenge.UserID, lcs.UserID)
+	}
+	if lcs.TokenHash != nil {
+		lcs.where(query, Tables.LoginChallenge.Alias, Columns.LoginChallenge.TokenHash, lcs.TokenHash)
+	}
+	if lcs.IsRemember != nil {
+		lcs.where(query, Tables.LoginChallenge.Alias, Columns.LoginChallenge.IsRemember, lcs.IsRemember)
+	}
+	if lcs.Attempts != nil {
+		lcs.where(query, Tables.LoginChallenge.Alias, Columns.LoginChallenge.Attempts, lcs.Attempts)
+	}
+	if len(lcs.IDs) > 0 {
+		Filter{Columns.LoginChallenge.ID, lcs.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	lcs.apply(query)
+
+	return query
+}
+
+func (lcs *LoginChallengeSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if lcs == nil {
+			return query, nil
+		}
+		return lcs.Apply(query), nil
+	}
+}
+
+type OIDCStateSearch struct {
+	search
+
+	ID         *string
+	Nonce      *string
+	Verifier   *string
+	IsRemember *bool
+	IDs        []string
+}
+
+func (oidcss *OIDCStateSearch) Apply(query *orm.Query) *orm.Query {
+	if oidcss == nil {
+		return query
+	}
+	if oidcss.ID != nil {
+		oidcss.where(query, Tables.OIDCState.Alias, Columns.OIDCState.ID, oidcss.ID)
+	}
+	if oidcss.Nonce != nil {
+		oidcss.where(query, Tables.OIDCState.Alias, Columns.OIDCState.Nonce, oidcss.Nonce)
+	}
+	if oidcss.Verifier != nil {
+		oidcss.where(query, Tables.OIDCState.Alias, Columns.OIDCState.Verifier, oidcss.Verifier)
+	}
+	if oidcss.IsRemember != nil {
+		oidcss.where(query, Tables.OIDCState.Alias, Columns.OIDCState.IsRemember, oidcss.IsRemember)
+	}
+	if len(oidcss.IDs) > 0 {
+		Filter{Columns.OIDCState.ID, oidcss.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	oidcss.apply(query)
+
+	return query
+}
+
+func (oidcss *OIDCStateSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if oidcss == nil {
+			return query, nil
+		}
+		return oidcss.Apply(query), nil
+	}
+}
+
+type NewsRevisionSearch struct {
+	search
+
+	ID         *int
+	NewsID     *int
+	Title      *string
+	Alias      *string
+	Content    *string
+	CategoryID *int
+	StatusID   *int
+	UserID     *int
+	CreatedAt  *time.Time
+	IDs        []int
+}
+
+func (nrs *NewsRevisionSearch) Apply(query *orm.Query) *orm.Query {
+	if nrs == nil {
+		return query
+	}
+	if nrs.ID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.ID, nrs.ID)
+	}
+	if nrs.NewsID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.NewsID, nrs.NewsID)
+	}
+	if nrs.Title != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.Title, nrs.Title)
+	}
+	if nrs.Alias != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.Alias, nrs.Alias)
+	}
+	if nrs.Content != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.Content, nrs.Content)
+	}
+	if nrs.CategoryID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.CategoryID, nrs.CategoryID)
+	}
+	if nrs.StatusID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.StatusID, nrs.StatusID)
+	}
+	if nrs.UserID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.UserID, nrs.UserID)
+	}
+	if nrs.CreatedAt != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.CreatedAt, nrs.CreatedAt)
+	}
+	if len(nrs.IDs) > 0 {
+		Filter{Columns.NewsRevision.ID, nrs.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	nrs.apply(query)
+
+	return query
+}
+
+func (nrs *NewsRevisionSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if nrs == nil {
+			return query, nil
+		}
+		return nrs.Apply(query), nil
+	}
+}
+
 type TagSearch struct {
 	search
 
 	ID         *int
 	Title      *string
+	Alias      *string
+	Version    *int
 	StatusID   *int
 	IDs        []int
+	NotID      *int
 	TitleILike *string
 }
 
@@ -420,12 +891,21 @@ func (ts *TagSearch) Apply(query *orm.Query) *orm.Query {
 	if ts.Title != nil {
 		ts.where(query, Tables.Tag.Alias, Columns.Tag.Title, ts.Title)
 	}
+	if ts.Alias != nil {
+		ts.where(query, Tables.Tag.Alias, Columns.Tag.Alias, ts.Alias)
+	}
+	if ts.Version != nil {
+		ts.where(query, Tables.Tag.Alias, Columns.Tag.Version, ts.Version)
+	}
 	if ts.StatusID != nil {
 		ts.where(query, Tables.Tag.Alias, Columns.Tag.StatusID, ts.StatusID)
 	}
 	if len(ts.IDs) > 0 {
 		Filter{Columns.Tag.ID, ts.IDs, SearchTypeArray, false}.Apply(query)
 	}
+	if ts.NotID != nil {
+		Filter{Columns.Tag.ID, *ts.NotID, SearchTypeEquals, true}.Apply(query)
+	}
 	if ts.TitleILike != nil {
 		Filter{Columns.Tag.Title, *ts.TitleILike, SearchTypeILike, false}.Apply(query)
 	}
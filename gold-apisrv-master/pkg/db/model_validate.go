@@ -21,12 +21,134 @@ func (u User) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.User.Login] = ErrMaxLength
 	}
 
-	if utf8.RuneCountInString(u.Password) > 64 {
+	if u.Email != nil && utf8.RuneCountInString(*u.Email) > 255 {
+		errors[Columns.User.Email] = ErrMaxLength
+	}
+
+	if u.OIDCSubject != nil && utf8.RuneCountInString(*u.OIDCSubject) > 255 {
+		errors[Columns.User.OIDCSubject] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(u.Password) > 255 {
 		errors[Columns.User.Password] = ErrMaxLength
 	}
 
-	if utf8.RuneCountInString(u.AuthKey) > 32 {
-		errors[Columns.User.AuthKey] = ErrMaxLength
+	if utf8.RuneCountInString(u.Role) > 16 {
+		errors[Columns.User.Role] = ErrMaxLength
+	}
+
+	if u.TotpSecret != nil && utf8.RuneCountInString(*u.TotpSecret) > 64 {
+		errors[Columns.User.TotpSecret] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (s Session) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(s.TokenHash) > 64 {
+		errors[Columns.Session.TokenHash] = ErrMaxLength
+	}
+
+	if s.Device != nil && utf8.RuneCountInString(*s.Device) > 64 {
+		errors[Columns.Session.Device] = ErrMaxLength
+	}
+
+	if s.IP != nil && utf8.RuneCountInString(*s.IP) > 45 {
+		errors[Columns.Session.IP] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (ut UserToken) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(ut.Kind) > 16 {
+		errors[Columns.UserToken.Kind] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(ut.TokenHash) > 64 {
+		errors[Columns.UserToken.TokenHash] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (ak APIKey) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(ak.Title) > 128 {
+		errors[Columns.APIKey.Title] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(ak.KeyPrefix) > 16 {
+		errors[Columns.APIKey.KeyPrefix] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(ak.KeyHash) > 64 {
+		errors[Columns.APIKey.KeyHash] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (al AuditLog) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(al.Namespace) > 32 {
+		errors[Columns.AuditLog.Namespace] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(al.Method) > 64 {
+		errors[Columns.AuditLog.Method] = ErrMaxLength
+	}
+
+	if al.IP != nil && utf8.RuneCountInString(*al.IP) > 45 {
+		errors[Columns.AuditLog.IP] = ErrMaxLength
+	}
+
+	if al.RequestID != nil && utf8.RuneCountInString(*al.RequestID) > 64 {
+		errors[Columns.AuditLog.RequestID] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (la LoginAttempt) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(la.ID) > 128 {
+		errors[Columns.LoginAttempt.ID] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (lc LoginChallenge) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(lc.TokenHash) > 64 {
+		errors[Columns.LoginChallenge.TokenHash] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (oidcs OIDCState) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(oidcs.ID) > 64 {
+		errors[Columns.OIDCState.ID] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(oidcs.Nonce) > 64 {
+		errors[Columns.OIDCState.Nonce] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(oidcs.Verifier) > 128 {
+		errors[Columns.OIDCState.Verifier] = ErrMaxLength
 	}
 
 	return errors, len(errors) == 0
@@ -88,6 +210,20 @@ func (n News) Validate() (errors map[string]string, valid bool) {
 	return errors, len(errors) == 0
 }
 
+func (nr NewsRevision) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(nr.Title) > 256 {
+		errors[Columns.NewsRevision.Title] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(nr.Alias) > 256 {
+		errors[Columns.NewsRevision.Alias] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
 func (t Tag) Validate() (errors map[string]string, valid bool) {
 	errors = map[string]string{}
 
@@ -95,5 +231,9 @@ func (t Tag) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.Tag.Title] = ErrMaxLength
 	}
 
+	if utf8.RuneCountInString(t.Alias) > 256 {
+		errors[Columns.Tag.Alias] = ErrMaxLength
+	}
+
 	return errors, len(errors) == 0
 }
@@ -25,14 +25,16 @@ func NewNewsRepo(db orm.DB) NewsRepo {
 			Tables.Tag.Name:      {StatusFilter},
 		},
 		sort: map[string][]SortField{
-			Tables.Category.Name: {{Column: Columns.Category.Title, Direction: SortAsc}},
-			Tables.News.Name:     {{Column: Columns.News.CreatedAt, Direction: SortDesc}},
-			Tables.Tag.Name:      {{Column: Columns.Tag.Title, Direction: SortAsc}},
+			Tables.Category.Name:     {{Column: Columns.Category.Title, Direction: SortAsc}},
+			Tables.News.Name:         {{Column: Columns.News.CreatedAt, Direction: SortDesc}},
+			Tables.NewsRevision.Name: {{Column: Columns.NewsRevision.ID, Direction: SortDesc}},
+			Tables.Tag.Name:          {{Column: Columns.Tag.Title, Direction: SortAsc}},
 		},
 		join: map[string][]string{
-			Tables.Category.Name: {TableColumns},
-			Tables.News.Name:     {TableColumns, Columns.News.Category},
-			Tables.Tag.Name:      {TableColumns},
+			Tables.Category.Name:     {TableColumns, Columns.Category.ParentCategory},
+			Tables.News.Name:         {TableColumns, Columns.News.Category},
+			Tables.NewsRevision.Name: {TableColumns, Columns.NewsRevision.User},
+			Tables.Tag.Name:          {TableColumns},
 		},
 	}
 }
@@ -202,6 +204,60 @@ func (nr NewsRepo) DeleteNews(ctx context.Context, id int) (deleted bool, err er
 	return nr.UpdateNews(ctx, news, WithColumns(Columns.News.StatusID))
 }
 
+/*** NewsRevision ***/
+
+// FullNewsRevision returns full joins with all columns
+func (nr NewsRepo) FullNewsRevision() OpFunc {
+	return WithColumns(nr.join[Tables.NewsRevision.Name]...)
+}
+
+// DefaultNewsRevisionSort returns default sort.
+func (nr NewsRepo) DefaultNewsRevisionSort() OpFunc {
+	return WithSort(nr.sort[Tables.NewsRevision.Name]...)
+}
+
+// NewsRevisionByID is a function that returns NewsRevision by ID(s) or nil.
+func (nr NewsRepo) NewsRevisionByID(ctx context.Context, id int, ops ...OpFunc) (*NewsRevision, error) {
+	return nr.OneNewsRevision(ctx, &NewsRevisionSearch{ID: &id}, ops...)
+}
+
+// OneNewsRevision is a function that returns one NewsRevision by filters. It could return pg.ErrMultiRows.
+func (nr NewsRepo) OneNewsRevision(ctx context.Context, search *NewsRevisionSearch, ops ...OpFunc) (*NewsRevision, error) {
+	obj := &NewsRevision{}
+	err := buildQuery(ctx, nr.db, obj, search, nr.filters[Tables.NewsRevision.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// NewsRevisionsByFilters returns NewsRevision list.
+func (nr NewsRepo) NewsRevisionsByFilters(ctx context.Context, search *NewsRevisionSearch, pager Pager, ops ...OpFunc) (newsRevisions []NewsRevision, err error) {
+	err = buildQuery(ctx, nr.db, &newsRevisions, search, nr.filters[Tables.NewsRevision.Name], pager, ops...).Select()
+	return
+}
+
+// CountNewsRevisions returns count
+func (nr NewsRepo) CountNewsRevisions(ctx context.Context, search *NewsRevisionSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, nr.db, &NewsRevision{}, search, nr.filters[Tables.NewsRevision.Name], PagerOne, ops...).Count()
+}
+
+// AddNewsRevision adds NewsRevision to DB.
+func (nr NewsRepo) AddNewsRevision(ctx context.Context, newsRevision *NewsRevision, ops ...OpFunc) (*NewsRevision, error) {
+	q := nr.db.ModelContext(ctx, newsRevision)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.NewsRevision.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return newsRevision, err
+}
+
 /*** Tag ***/
 
 // FullTag returns full joins with all columns
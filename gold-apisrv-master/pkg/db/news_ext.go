@@ -0,0 +1,241 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// NewsSearchVector is a generated tsvector column built from news title and content.
+const NewsSearchVector = "searchVector"
+
+// newsHeadlineOptions are ts_headline options for news content snippets.
+const newsHeadlineOptions = "MaxWords=35, MinWords=15, MaxFragments=2"
+
+var (
+	// PublishedNewsFilter hides news with publicationDate in the future.
+	PublishedNewsFilter = Filter{Field: Columns.News.PublicationDate, Value: pg.Safe("now()"), SearchType: SearchTypeLE}
+	// NotExpiredNewsFilter hides news with unpublishDate in the past.
+	NotExpiredNewsFilter = Filter{Field: Columns.News.UnpublishDate, Value: pg.Safe("now()"), SearchType: SearchTypeNullOrGreater}
+)
+
+// WithPublishedOnly is a function that adds "statusId"=1 and publication window as base news filters.
+func (nr NewsRepo) WithPublishedOnly() NewsRepo {
+	nr = nr.WithEnabledOnly()
+	nr.filters[Tables.News.Name] = append(nr.filters[Tables.News.Name], PublishedNewsFilter, NotExpiredNewsFilter)
+
+	return nr
+}
+
+// PublishScheduledNews sets publishedAt for enabled news which publication date has come.
+func (nr NewsRepo) PublishScheduledNews(ctx context.Context) (int, error) {
+	res, err := nr.db.ModelContext(ctx, (*News)(nil)).
+		Set("? = now()", pg.Ident(Columns.News.PublishedAt)).
+		Where("? = ?", pg.Ident(Columns.News.StatusID), StatusEnabled).
+		Where("? is null", pg.Ident(Columns.News.PublishedAt)).
+		Where("? <= now()", pg.Ident(Columns.News.PublicationDate)).
+		Where("? is null or ? > now()", pg.Ident(Columns.News.UnpublishDate), pg.Ident(Columns.News.UnpublishDate)).
+		Update()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// UnpublishExpiredNews disables enabled news which unpublish date has passed and sets unpublishedAt.
+func (nr NewsRepo) UnpublishExpiredNews(ctx context.Context) (int, error) {
+	res, err := nr.db.ModelContext(ctx, (*News)(nil)).
+		Set("? = ?", pg.Ident(Columns.News.StatusID), StatusDisabled).
+		Set("? = now()", pg.Ident(Columns.News.UnpublishedAt)).
+		Where("? = ?", pg.Ident(Columns.News.StatusID), StatusEnabled).
+		Where("? <= now()", pg.Ident(Columns.News.UnpublishDate)).
+		Update()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// WithScheduled adds condition for enabled news waiting for publication date.
+func (ns *NewsSearch) WithScheduled() {
+	ns.With("?.? = ? and ?.? > now()", pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.StatusID), StatusEnabled, pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.PublicationDate))
+}
+
+// WithExpired adds condition for news which unpublish date has passed.
+func (ns *NewsSearch) WithExpired() {
+	ns.With("?.? <= now()", pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.UnpublishDate))
+}
+
+// WithQuery adds full-text search condition by title and content.
+func (ns *NewsSearch) WithQuery(query string) {
+	ns.WithApply(func(q *orm.Query) (*orm.Query, error) {
+		return Filter{Field: NewsSearchVector, Value: query, SearchType: SearchTypeFullText}.Apply(q), nil
+	})
+}
+
+// NewsHeadlines returns ts_headline snippets of news content matched by full-text query.
+func (nr NewsRepo) NewsHeadlines(ctx context.Context, ids []int, query string) (map[int]string, error) {
+	if len(ids) == 0 {
+		return map[int]string{}, nil
+	}
+
+	var list []struct {
+		ID       int    `pg:"newsId"`
+		Headline string `pg:"headline"`
+	}
+
+	err := nr.db.ModelContext(ctx, (*News)(nil)).
+		Column(Columns.News.ID).
+		ColumnExpr("ts_headline(?, coalesce(?, ?), websearch_to_tsquery(?, ?), ?) AS ?",
+			FullTextConfig, pg.Ident(Columns.News.Content), pg.Ident(Columns.News.Title), FullTextConfig, query, newsHeadlineOptions, pg.Ident("headline")).
+		Where("? in (?)", pg.Ident(Columns.News.ID), pg.In(ids)).
+		Select(&list)
+	if err != nil {
+		return nil, err
+	}
+
+	headlines := make(map[int]string, len(list))
+	for _, h := range list {
+		headlines[h.ID] = h.Headline
+	}
+
+	return headlines, nil
+}
+
+// WithPublishedSince adds condition for news published after t.
+func (ns *NewsSearch) WithPublishedSince(t time.Time) {
+	ns.With("?.? >= ?", pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.PublicationDate), t)
+}
+
+// TagNewsCounts returns count of news by tag id, base news filters are applied. Counts are limited to tagIDs if passed.
+func (nr NewsRepo) TagNewsCounts(ctx context.Context, tagIDs []int) (map[int]int, error) {
+	tags := nr.db.ModelContext(ctx, (*News)(nil)).
+		ColumnExpr("unnest(?.?) AS ?", pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.TagIDs), pg.Ident(Columns.Tag.ID))
+	for _, f := range nr.filters[Tables.News.Name] {
+		f.Apply(tags)
+	}
+
+	q := nr.db.ModelContext(ctx).
+		TableExpr("(?) AS ?", tags, pg.Ident("nt")).
+		ColumnExpr("?, count(*) AS ?", pg.Ident(Columns.Tag.ID), pg.Ident("count")).
+		Group(Columns.Tag.ID)
+	if len(tagIDs) != 0 {
+		q.Where("? in (?)", pg.Ident(Columns.Tag.ID), pg.In(tagIDs))
+	}
+
+	var list []struct {
+		TagID int `pg:"tagId"`
+		Count int `pg:"count"`
+	}
+	if err := q.Select(&list); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(list))
+	for _, c := range list {
+		counts[c.TagID] = c.Count
+	}
+
+	return counts, nil
+}
+
+// categoryTree returns subquery of category id with ids of all its descendants.
+func categoryTree(id int) *orm.SafeQueryAppender {
+	return pg.SafeQuery(`WITH RECURSIVE "tree" AS (SELECT ?0 FROM ?1 WHERE ?0 = ?2 UNION SELECT "c".?0 FROM ?1 AS "c" JOIN "tree" ON "c".?3 = "tree".?0) SELECT ?0 FROM "tree"`,
+		pg.Ident(Columns.Category.ID), pg.Ident(Tables.Category.Name), id, pg.Ident(Columns.Category.ParentCategoryID))
+}
+
+// CategoryTreeIDs returns id of category with ids of all its descendants.
+func (nr NewsRepo) CategoryTreeIDs(ctx context.Context, id int) ([]int, error) {
+	var ids []int
+	_, err := nr.db.QueryContext(ctx, &ids, "?", categoryTree(id))
+
+	return ids, err
+}
+
+// UpdateCategoryPosition updates parent and order number of category and increments its version.
+// Version is not checked: positions are only changed under category order lock, last change wins.
+func (nr NewsRepo) UpdateCategoryPosition(ctx context.Context, id int, parentID *int, orderNumber int) (bool, error) {
+	category := &Category{ID: id, ParentCategoryID: parentID, OrderNumber: orderNumber}
+	q := nr.db.ModelContext(ctx, category).WherePK().Column(Columns.Category.ParentCategoryID, Columns.Category.OrderNumber)
+
+	res, err := incrementVersion(q, Columns.Category.Version, true).Update()
+	if errors.Is(err, pg.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// WithCategoryTree adds condition for news from category or any of its descendants.
+func (ns *NewsSearch) WithCategoryTree(categoryID int) {
+	ns.With("?.? IN (?)", pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.CategoryID), categoryTree(categoryID))
+}
+
+// CategoryChildren returns child categories of parent ordered by orderNumber, root categories are returned for nil parent.
+func (nr NewsRepo) CategoryChildren(ctx context.Context, parentID *int) ([]Category, error) {
+	search := &CategorySearch{ParentCategoryID: parentID}
+	if parentID == nil {
+		search.With("?.? is null", pg.Ident(Tables.Category.Alias), pg.Ident(Columns.Category.ParentCategoryID))
+	}
+
+	return nr.CategoriesByFilters(ctx, search, PagerNoLimit, WithSort(
+		SortField{Column: Columns.Category.OrderNumber, Direction: SortAsc},
+		SortField{Column: Columns.Category.ID, Direction: SortAsc},
+	))
+}
+
+// AddVersionedCategory adds Category to DB with the first version.
+func (nr NewsRepo) AddVersionedCategory(ctx context.Context, category *Category, ops ...OpFunc) (*Category, error) {
+	category.Version = firstVersion
+	return nr.AddCategory(ctx, category, ops...)
+}
+
+// UpdateVersionedCategory updates Category in DB and increments its version.
+// Update is made only if Category has the same version in DB, ErrVersionConflict is returned otherwise.
+func (nr NewsRepo) UpdateVersionedCategory(ctx context.Context, category *Category, ops ...OpFunc) (bool, error) {
+	q := nr.db.ModelContext(ctx, category).WherePK()
+	applyOps(q, ops...)
+
+	return updateVersioned(q, Columns.Category.Version, category.Version, len(ops) > 0)
+}
+
+// AddVersionedNews adds News to DB with the first version.
+func (nr NewsRepo) AddVersionedNews(ctx context.Context, news *News, ops ...OpFunc) (*News, error) {
+	news.Version = firstVersion
+	return nr.AddNews(ctx, news, ops...)
+}
+
+// UpdateVersionedNews updates News in DB and increments its version.
+// Update is made only if News has the same version in DB, ErrVersionConflict is returned otherwise.
+func (nr NewsRepo) UpdateVersionedNews(ctx context.Context, news *News, ops ...OpFunc) (bool, error) {
+	q := nr.db.ModelContext(ctx, news).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.News.CreatedAt)
+	}
+	applyOps(q, ops...)
+
+	return updateVersioned(q, Columns.News.Version, news.Version, len(ops) > 0)
+}
+
+// AddVersionedTag adds Tag to DB with the first version.
+func (nr NewsRepo) AddVersionedTag(ctx context.Context, tag *Tag, ops ...OpFunc) (*Tag, error) {
+	tag.Version = firstVersion
+	return nr.AddTag(ctx, tag, ops...)
+}
+
+// UpdateVersionedTag updates Tag in DB and increments its version.
+// Update is made only if Tag has the same version in DB, ErrVersionConflict is returned otherwise.
+func (nr NewsRepo) UpdateVersionedTag(ctx context.Context, tag *Tag, ops ...OpFunc) (bool, error) {
+	q := nr.db.ModelContext(ctx, tag).WherePK()
+	applyOps(q, ops...)
+
+	return updateVersioned(q, Columns.Tag.Version, tag.Version, len(ops) > 0)
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"unicode"
@@ -60,6 +61,13 @@ func WithSort(fields ...SortField) OpFunc {
 	}
 }
 
+// WithFullTextRank is a function that sorts query by ts_rank of tsvector column against search query.
+func WithFullTextRank(column, search string) OpFunc {
+	return func(query *orm.Query) {
+		query.OrderExpr("ts_rank(?.?, websearch_to_tsquery(?, ?)) desc", types.Ident(TablePrefix), types.Ident(column), FullTextConfig, search)
+	}
+}
+
 // WithColumns is a function that adds user specific columns to query.
 func WithColumns(cols ...string) OpFunc {
 	return func(query *orm.Query) {
@@ -134,6 +142,7 @@ var (
 type Pager struct {
 	Page     int
 	PageSize int
+	Keyset   *Keyset // keyset pagination, page is used only if keyset has no cursor
 }
 
 // NewPager create new Pager. If page and pageSize is zero return PagerDefault
@@ -182,12 +191,22 @@ func (p Pager) String() (opts string) {
 	return
 }
 
-// Apply applies options to go-pg orm
+// Apply applies options to go-pg orm. Keyset pager selects one extra row to detect next page, see Next.
 func (p Pager) Apply(query *orm.Query) *orm.Query {
 	pager := p.Pager()
 	limit := pager.GetLimit()
 	offset := pager.GetOffset()
 
+	if p.Keyset != nil {
+		p.Keyset.apply(query)
+		if limit != 0 {
+			limit++
+		}
+		if p.Keyset.After != nil {
+			offset = 0
+		}
+	}
+
 	if limit != 0 {
 		query = query.Limit(limit)
 	}
@@ -196,3 +215,16 @@ func (p Pager) Apply(query *orm.Query) *orm.Query {
 	}
 	return query
 }
+
+// Next cuts extra row from list selected by keyset pager and returns cursor of next page or nil if it is the last page.
+// List must be a pointer to slice of structs or pointers.
+func (p Pager) Next(list interface{}) (*Cursor, error) {
+	v := reflect.ValueOf(list).Elem()
+	limit := p.Pager().GetLimit()
+	if p.Keyset == nil || limit == 0 || v.Len() <= limit {
+		return nil, nil
+	}
+
+	v.Set(v.Slice(0, limit))
+	return p.Keyset.cursor(v.Index(limit - 1))
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+const (
+	// DefaultReplicaMaxLag is a max replication lag of replica used for reads.
+	DefaultReplicaMaxLag = 5 * time.Second
+	// DefaultReplicaCheckInterval is an interval of replication lag checks.
+	DefaultReplicaCheckInterval = 5 * time.Second
+)
+
+// replicaLagSQL returns replication lag in seconds, it is zero if replica has replayed all received wal.
+const replicaLagSQL = `SELECT CASE
+	WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+type primaryCtx struct{}
+
+// WithPrimary returns context, which routes all reads to primary. It is used for reads before writes and for
+// reads, which must see just committed changes.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryCtx{}, true)
+}
+
+// isPrimaryContext checks that reads of context must go to primary.
+func isPrimaryContext(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryCtx{}).(bool)
+	return v
+}
+
+// replica is a read replica connection with last known state.
+type replica struct {
+	db        *pg.DB
+	isHealthy atomic.Bool
+}
+
+// replicaSet routes reads to healthy replicas by round robin.
+type replicaSet struct {
+	list   []*replica
+	maxLag time.Duration
+	next   atomic.Uint32
+}
+
+// reader returns healthy replica or nil.
+func (rs *replicaSet) reader() *pg.DB {
+	n := uint32(len(rs.list))
+	start := rs.next.Add(1)
+	for i := uint32(0); i < n; i++ {
+		if r := rs.list[(start+i)%n]; r.isHealthy.Load() {
+			return r.db
+		}
+	}
+
+	return nil
+}
+
+// WithReplicas returns DB, which reads from replicas by repo read methods. Replica is used for reads only if its
+// replication lag is not greater than maxLag, otherwise reads fall back to primary. Replicas are not used
+// until CheckReplicas is called. Writes and transactions always use primary.
+func (db DB) WithReplicas(replicas []*pg.DB, maxLag time.Duration) DB {
+	if maxLag <= 0 {
+		maxLag = DefaultReplicaMaxLag
+	}
+
+	rs := &replicaSet{maxLag: maxLag}
+	for _, r := range replicas {
+		rs.list = append(rs.list, &replica{db: r})
+	}
+	db.replicas = rs
+
+	return db
+}
+
+// Replicas returns replica connections.
+func (db DB) Replicas() []*pg.DB {
+	if db.replicas == nil {
+		return nil
+	}
+
+	list := make([]*pg.DB, 0, len(db.replicas.list))
+	for _, r := range db.replicas.list {
+		list = append(list, r.db)
+	}
+
+	return list
+}
+
+// CheckReplicas updates replicas health by their replication lag.
+func (db DB) CheckReplicas(ctx context.Context) {
+	if db.replicas == nil {
+		return
+	}
+
+	for _, r := range db.replicas.list {
+		var lag float64
+		_, err := r.db.QueryOneContext(ctx, pg.Scan(&lag), replicaLagSQL)
+		isHealthy := err == nil && time.Duration(lag*float64(time.Second)) <= db.replicas.maxLag
+
+		if r.isHealthy.Swap(isHealthy) != isHealthy {
+			opts := r.db.Options()
+			if isHealthy {
+				db.Printf("replica %s/%s is used for reads", opts.Addr, opts.Database)
+			} else {
+				db.Errorf("replica %s/%s is not used for reads lag=%.1fs err=%v", opts.Addr, opts.Database, lag, err)
+			}
+		}
+	}
+}
+
+// WatchReplicas checks replicas every interval until ctx is done.
+func (db DB) WatchReplicas(ctx context.Context, interval time.Duration) {
+	if db.replicas == nil {
+		return
+	}
+
+	if interval <= 0 {
+		interval = DefaultReplicaCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		db.CheckReplicas(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reader returns connection for reads: healthy replica if conn is DB with replicas or conn itself.
+func reader(ctx context.Context, conn orm.DB) orm.DB {
+	var d DB
+	switch v := conn.(type) {
+	case DB:
+		d = v
+	case *DB:
+		d = *v
+	default:
+		return conn
+	}
+
+	if d.replicas == nil || isPrimaryContext(ctx) {
+		return conn
+	}
+
+	if r := d.replicas.reader(); r != nil {
+		return r
+	}
+
+	return conn
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReplicas(t *testing.T) {
+	Convey("Test read replicas routing", t, func() {
+		ctx := context.Background()
+		primary := New(pg.Connect(&pg.Options{Addr: "primary:5432"}))
+		r1, r2 := pg.Connect(&pg.Options{Addr: "replica1:5432"}), pg.Connect(&pg.Options{Addr: "replica2:5432"})
+		dbc := primary.WithReplicas([]*pg.DB{r1, r2}, 0)
+
+		So(primary.Replicas(), ShouldBeNil)
+		So(dbc.Replicas(), ShouldResemble, []*pg.DB{r1, r2})
+		So(dbc.replicas.maxLag, ShouldEqual, DefaultReplicaMaxLag)
+
+		Convey("Reads use primary until replicas are healthy", func() {
+			So(reader(ctx, dbc), ShouldResemble, dbc)
+			So(reader(ctx, primary), ShouldResemble, primary)
+		})
+
+		Convey("Reads are balanced between healthy replicas", func() {
+			dbc.replicas.list[0].isHealthy.Store(true)
+			dbc.replicas.list[1].isHealthy.Store(true)
+			first, second := reader(ctx, dbc), reader(ctx, &dbc)
+			So(first, ShouldNotEqual, second)
+			So([]interface{}{first, second}, ShouldContain, r1)
+			So([]interface{}{first, second}, ShouldContain, r2)
+
+			dbc.replicas.list[0].isHealthy.Store(false)
+			So(reader(ctx, dbc), ShouldEqual, r2)
+			So(reader(ctx, dbc), ShouldEqual, r2)
+
+			Convey("Primary context and transactions use primary", func() {
+				So(reader(WithPrimary(ctx), dbc), ShouldResemble, dbc)
+
+				tx := &pg.Tx{}
+				So(reader(ctx, tx), ShouldEqual, tx)
+			})
+		})
+	})
+}
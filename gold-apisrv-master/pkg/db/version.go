@@ -0,0 +1,44 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10/types"
+)
+
+// ErrVersionConflict is returned by update if row was changed after it was read.
+var ErrVersionConflict = errors.New("version conflict")
+
+// firstVersion is a version of added row.
+const firstVersion = 1
+
+// withVersion adds optimistic lock to update query: update is made only if row still has the version,
+// version column is incremented and returned to model. Partial update of selected columns is checked too.
+func withVersion(query *orm.Query, column string, version int, isPartial bool) *orm.Query {
+	return incrementVersion(query, column, isPartial).Where("?.? = ?", types.Ident(TablePrefix), types.Ident(column), version)
+}
+
+// incrementVersion adds increment of version column returned to model to update query without version check.
+func incrementVersion(query *orm.Query, column string, isPartial bool) *orm.Query {
+	t, col := types.Ident(TablePrefix), types.Ident(column)
+	if isPartial {
+		query.Column(column)
+	}
+
+	return query.Value(column, "?.? + 1", t, col).Returning("?", col)
+}
+
+// updateVersioned updates row by query with version lock, see withVersion.
+// ErrVersionConflict is returned if row with the version is not found.
+func updateVersioned(query *orm.Query, column string, version int, isPartial bool) (bool, error) {
+	res, err := withVersion(query, column, version, isPartial).Update()
+	if errors.Is(err, pg.ErrNoRows) || (err == nil && res.RowsAffected() == 0) {
+		return false, ErrVersionConflict
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/go-pg/pg/v10/orm"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// updateSQL returns update query of tag with version lock.
+func updateSQL(tag *Tag, isPartial bool, ops ...OpFunc) string {
+	q := orm.NewQuery(nil, tag).WherePK()
+	applyOps(q, ops...)
+	b, err := orm.NewUpdateQuery(withVersion(q, Columns.Tag.Version, tag.Version, isPartial), false).AppendQuery(orm.NewFormatter(), nil)
+	So(err, ShouldBeNil)
+	return string(b)
+}
+
+func TestVersion(t *testing.T) {
+	Convey("Test optimistic lock of updates", t, func() {
+		tag := &Tag{ID: 1, Title: "a", Alias: "a", Version: 3, StatusID: StatusEnabled}
+
+		Convey("Full update checks version", func() {
+			So(updateSQL(tag, false), ShouldEqual, `UPDATE "tags" AS "t" SET "title" = 'a', "alias" = 'a', "version" = "t"."version" + 1, "statusId" = 1 `+
+				`WHERE "t"."tagId" = 1 AND ("t"."version" = 3) RETURNING "version"`)
+
+			tag.Version = 0
+			So(updateSQL(tag, false), ShouldContainSubstring, `("t"."version" = 0)`)
+		})
+
+		Convey("Partial update checks version", func() {
+			So(updateSQL(tag, true, WithColumns(Columns.Tag.StatusID)), ShouldEqual, `UPDATE "tags" AS "t" SET "statusId" = 1, "version" = "t"."version" + 1 `+
+				`WHERE "t"."tagId" = 1 AND ("t"."version" = 3) RETURNING "version"`)
+		})
+	})
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// vfsHashesTable is a table with indexed vfs files, maintained by vfs.HashIndexer.
+const vfsHashesTable = "vfsHashes"
+
+// MissingVfsHashes returns hashes which are not present in vfsHashes table.
+func (vr VfsRepo) MissingVfsHashes(ctx context.Context, hashes []string) ([]string, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	var existing []string
+	_, err := vr.db.QueryContext(ctx, &existing, "SELECT DISTINCT ? FROM ? WHERE ? IN (?)",
+		pg.Ident("hash"), pg.Ident(vfsHashesTable), pg.Ident("hash"), pg.In(hashes))
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]struct{}, len(existing))
+	for _, h := range existing {
+		found[h] = struct{}{}
+	}
+
+	var missing []string
+	for _, h := range hashes {
+		if _, ok := found[h]; !ok {
+			missing = append(missing, h)
+		}
+	}
+
+	return missing, nil
+}
+
+// VfsFilesWithFolderByPaths returns VfsFiles of any status with their folders by paths.
+func (vr VfsRepo) VfsFilesWithFolderByPaths(ctx context.Context, paths []string) ([]VfsFile, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	search := &VfsFileSearch{}
+	search.With("?.? IN (?)", pg.Ident(Tables.VfsFile.Alias), pg.Ident(Columns.VfsFile.Path), pg.In(paths))
+
+	var list []VfsFile
+	err := buildQuery(ctx, vr.db, &list, search, nil, PagerNoLimit, vr.FullVfsFile()).Select()
+	return list, err
+}
@@ -0,0 +1,305 @@
+// Package feed renders RSS 2.0, Atom 1.0 and JSON Feed 1.1 documents.
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
+const (
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+	AtomContentType = "application/atom+xml; charset=utf-8"
+	JSONContentType = "application/feed+json; charset=utf-8"
+
+	atomNS          = "http://www.w3.org/2005/Atom"
+	jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+)
+
+// Feed is a format independent feed.
+type Feed struct {
+	Title       string
+	Description string
+	// Link is a public page of the feed.
+	Link string
+	// SelfLink is an url of the feed document.
+	SelfLink string
+	Updated  time.Time
+	Items    []Item
+}
+
+// Item is a format independent feed entry.
+type Item struct {
+	ID          string
+	Title       string
+	Link        string
+	Content     string
+	Image       string
+	Category    string
+	Tags        []string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+// LastModified returns the latest update time of feed items or Feed.Updated if it is set.
+func (f Feed) LastModified() time.Time {
+	if !f.Updated.IsZero() {
+		return f.Updated
+	}
+
+	var t time.Time
+	for _, it := range f.Items {
+		if it.updated().After(t) {
+			t = it.updated()
+		}
+	}
+
+	return t
+}
+
+func (it Item) updated() time.Time {
+	if it.UpdatedAt.After(it.PublishedAt) {
+		return it.UpdatedAt
+	}
+
+	return it.PublishedAt
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      *atomLink `xml:"atom:link,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link,omitempty"`
+	GUID        rssGUID  `xml:"guid"`
+	Description string   `xml:"description,omitempty"`
+	Categories  []string `xml:"category,omitempty"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS renders feed as RSS 2.0 document.
+func (f Feed) RSS() ([]byte, error) {
+	doc := rss{
+		Version: "2.0",
+		AtomNS:  atomNS,
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+			Items:       make([]rssItem, 0, len(f.Items)),
+		},
+	}
+
+	if t := f.LastModified(); !t.IsZero() {
+		doc.Channel.LastBuildDate = t.Format(time.RFC1123Z)
+	}
+
+	if f.SelfLink != "" {
+		doc.Channel.AtomLink = &atomLink{Href: f.SelfLink, Rel: "self", Type: "application/rss+xml"}
+	}
+
+	for _, it := range f.Items {
+		item := rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			GUID:        rssGUID{IsPermaLink: it.ID == it.Link, Value: it.ID},
+			Description: it.Content,
+			PubDate:     it.PublishedAt.Format(time.RFC1123Z),
+		}
+
+		if it.Category != "" {
+			item.Categories = append(item.Categories, it.Category)
+		}
+		item.Categories = append(item.Categories, it.Tags...)
+
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+
+	return marshalXML(doc)
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	NS       string      `xml:"xmlns,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Author   atomAuthor  `xml:"author"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+	Content    *atomContent   `xml:"content,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Atom renders feed as Atom 1.0 document.
+func (f Feed) Atom() ([]byte, error) {
+	doc := atomFeed{
+		NS:       atomNS,
+		ID:       f.id(),
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  f.atomUpdated(),
+		Author:   atomAuthor{Name: f.Title},
+		Entries:  make([]atomEntry, 0, len(f.Items)),
+	}
+
+	if f.Link != "" {
+		doc.Links = append(doc.Links, atomLink{Href: f.Link, Rel: "alternate", Type: "text/html"})
+	}
+	if f.SelfLink != "" {
+		doc.Links = append(doc.Links, atomLink{Href: f.SelfLink, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	for _, it := range f.Items {
+		entry := atomEntry{
+			ID:        it.ID,
+			Title:     it.Title,
+			Published: it.PublishedAt.UTC().Format(time.RFC3339),
+			Updated:   it.updated().UTC().Format(time.RFC3339),
+		}
+
+		if it.Link != "" {
+			entry.Links = append(entry.Links, atomLink{Href: it.Link, Rel: "alternate", Type: "text/html"})
+		}
+		if it.Content != "" {
+			entry.Content = &atomContent{Type: "html", Value: it.Content}
+		}
+		if it.Category != "" {
+			entry.Categories = append(entry.Categories, atomCategory{Term: it.Category})
+		}
+		for _, tag := range it.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return marshalXML(doc)
+}
+
+// atomUpdated returns required Atom updated field, current time is used for empty feeds.
+func (f Feed) atomUpdated() string {
+	t := f.LastModified()
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// id returns feed id for Atom document.
+func (f Feed) id() string {
+	if f.SelfLink != "" {
+		return f.SelfLink
+	}
+
+	return f.Link
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string     `json:"id"`
+	URL           string     `json:"url,omitempty"`
+	Title         string     `json:"title"`
+	ContentHTML   string     `json:"content_html"`
+	Image         string     `json:"image,omitempty"`
+	DatePublished time.Time  `json:"date_published"`
+	DateModified  *time.Time `json:"date_modified,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+}
+
+// JSON renders feed as JSON Feed 1.1 document.
+func (f Feed) JSON() ([]byte, error) {
+	doc := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.SelfLink,
+		Description: f.Description,
+		Items:       make([]jsonFeedItem, 0, len(f.Items)),
+	}
+
+	for _, it := range f.Items {
+		item := jsonFeedItem{
+			ID:            it.ID,
+			URL:           it.Link,
+			Title:         it.Title,
+			ContentHTML:   it.Content,
+			Image:         it.Image,
+			DatePublished: it.PublishedAt,
+			Tags:          it.Tags,
+		}
+
+		if it.UpdatedAt.After(it.PublishedAt) {
+			updatedAt := it.UpdatedAt
+			item.DateModified = &updatedAt
+		}
+
+		doc.Items = append(doc.Items, item)
+	}
+
+	return json.Marshal(doc)
+}
+
+func marshalXML(v interface{}) ([]byte, error) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
@@ -0,0 +1,70 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testFeed() Feed {
+	published := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	return Feed{
+		Title:    "News",
+		Link:     "https://example.com",
+		SelfLink: "https://example.com/v1/feed/rss",
+		Items: []Item{
+			{ID: "https://example.com/news/a", Link: "https://example.com/news/a", Title: "A & B", Content: "<p>text</p>", Category: "World", Tags: []string{"go"}, PublishedAt: published},
+			{ID: "https://example.com/news/b", Link: "https://example.com/news/b", Title: "B", PublishedAt: published.Add(-time.Hour), UpdatedAt: published.Add(time.Hour)},
+		},
+	}
+}
+
+func TestFeed(t *testing.T) {
+	Convey("Test feed rendering", t, func() {
+		f := testFeed()
+
+		Convey("LastModified is the latest item update", func() {
+			So(f.LastModified(), ShouldEqual, time.Date(2023, 5, 1, 11, 0, 0, 0, time.UTC))
+			So(Feed{}.LastModified().IsZero(), ShouldBeTrue)
+		})
+
+		Convey("RSS is valid xml with escaped content", func() {
+			b, err := f.RSS()
+			So(err, ShouldBeNil)
+			So(xml.Unmarshal(b, new(interface{})), ShouldBeNil)
+
+			s := string(b)
+			So(s, ShouldStartWith, xml.Header)
+			So(s, ShouldContainSubstring, `<rss version="2.0"`)
+			So(s, ShouldContainSubstring, "<title>A &amp; B</title>")
+			So(s, ShouldContainSubstring, "<category>World</category><category>go</category>")
+			So(s, ShouldContainSubstring, "<pubDate>Mon, 01 May 2023 10:00:00 +0000</pubDate>")
+		})
+
+		Convey("Atom has required fields", func() {
+			b, err := f.Atom()
+			So(err, ShouldBeNil)
+
+			s := string(b)
+			So(s, ShouldContainSubstring, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+			So(s, ShouldContainSubstring, "<updated>2023-05-01T11:00:00Z</updated>")
+			So(s, ShouldContainSubstring, `<content type="html">&lt;p&gt;text&lt;/p&gt;</content>`)
+			So(strings.Count(s, "<entry>"), ShouldEqual, 2)
+		})
+
+		Convey("JSON Feed has version and items", func() {
+			b, err := f.JSON()
+			So(err, ShouldBeNil)
+
+			var doc map[string]interface{}
+			So(json.Unmarshal(b, &doc), ShouldBeNil)
+			So(doc["version"], ShouldEqual, jsonFeedVersion)
+			So(doc["items"], ShouldHaveLength, 2)
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"apisrv/pkg/embedlog"
+)
+
+const (
+	TransportSMTP = "smtp"
+	TransportFile = "file"
+	TransportLog  = "log"
+)
+
+type Config struct {
+	Transport string // smtp, file or log, log is used by default
+	From      string // sender address
+	Host      string // smtp host
+	Port      int    // smtp port
+	Username  string // smtp username, auth is disabled if empty
+	Password  string // smtp password
+	Dir       string // directory for file transport
+}
+
+// Message is a plain text email.
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New returns Mailer by cfg.Transport.
+func New(cfg Config, logger embedlog.Logger) Mailer {
+	switch cfg.Transport {
+	case TransportSMTP:
+		return NewSMTPMailer(cfg)
+	case TransportFile:
+		return NewFileMailer(cfg.From, cfg.Dir)
+	default:
+		return NewLogMailer(cfg.From, logger)
+	}
+}
+
+// SMTPMailer sends emails via SMTP server.
+type SMTPMailer struct {
+	cfg Config
+}
+
+func NewSMTPMailer(cfg Config) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send sends message via SMTP server, PLAIN auth is used if username is set.
+func (m *SMTPMailer) Send(_ context.Context, msg Message) error {
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	return smtp.SendMail(addr, auth, m.cfg.From, msg.To, Build(m.cfg.From, msg, time.Now()))
+}
+
+// FileMailer writes emails as .eml files to directory.
+type FileMailer struct {
+	from, dir string
+}
+
+func NewFileMailer(from, dir string) *FileMailer {
+	return &FileMailer{from: from, dir: dir}
+}
+
+// Send writes message to new file in directory.
+func (m *FileMailer) Send(_ context.Context, msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := filepath.Join(m.dir, strconv.FormatInt(now.UnixNano(), 10)+".eml")
+	return os.WriteFile(name, Build(m.from, msg, now), 0o600)
+}
+
+// LogMailer writes emails to debug log.
+type LogMailer struct {
+	embedlog.Logger
+	from string
+}
+
+func NewLogMailer(from string, logger embedlog.Logger) *LogMailer {
+	return &LogMailer{Logger: logger, from: from}
+}
+
+// Send prints message to log.
+func (m *LogMailer) Send(_ context.Context, msg Message) error {
+	m.Printf("mail from=%s to=%s subject=%q\n%s", m.from, strings.Join(msg.To, ","), msg.Subject, msg.Body)
+	return nil
+}
+
+// Build returns RFC 5322 message with UTF-8 plain text body.
+func Build(from string, msg Message, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return b.Bytes()
+}
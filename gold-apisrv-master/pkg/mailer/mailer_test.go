@@ -0,0 +1,51 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"apisrv/pkg/embedlog"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMailer(t *testing.T) {
+	Convey("Test mailer", t, func() {
+		msg := Message{To: []string{"user@example.com"}, Subject: "Сброс пароля", Body: "line1\nline2"}
+
+		Convey("Build returns RFC 5322 message", func() {
+			b := string(Build("noreply@example.com", msg, time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)))
+
+			So(b, ShouldStartWith, "From: noreply@example.com\r\nTo: user@example.com\r\n")
+			So(b, ShouldContainSubstring, "Subject: =?utf-8?q?")
+			So(b, ShouldContainSubstring, "Date: Mon, 01 May 2023 10:00:00 +0000\r\n")
+			So(b, ShouldContainSubstring, "Content-Type: text/plain; charset=utf-8\r\n")
+			So(b, ShouldEndWith, "\r\n\r\nline1\r\nline2")
+		})
+
+		Convey("File mailer writes eml files", func() {
+			dir := t.TempDir()
+			m := New(Config{Transport: TransportFile, From: "noreply@example.com", Dir: dir}, embedlog.Logger{})
+			So(m, ShouldHaveSameTypeAs, &FileMailer{})
+			So(m.Send(context.Background(), msg), ShouldBeNil)
+
+			files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+			So(err, ShouldBeNil)
+			So(files, ShouldHaveLength, 1)
+
+			b, err := os.ReadFile(files[0])
+			So(err, ShouldBeNil)
+			So(strings.HasSuffix(string(b), "line2"), ShouldBeTrue)
+		})
+
+		Convey("Log mailer is default", func() {
+			m := New(Config{}, embedlog.Logger{})
+			So(m, ShouldHaveSameTypeAs, &LogMailer{})
+			So(m.Send(context.Background(), msg), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,53 @@
+// Package media builds public web paths of vfs files and images.
+package media
+
+import "path"
+
+const (
+	NormalImage = "normal"
+	BigImage    = "big"
+	MediumImage = "medium"
+	SmallImage  = "small"
+	Image128    = "128"
+	Image256    = "256"
+	Image512    = "512"
+	Image768    = "768"
+	Image1024   = "1024"
+	Image2048   = "2048"
+
+	// DefaultImage is a preset size of image web path.
+	DefaultImage = Image256
+)
+
+// ImageSizes are preset sizes of vfs images.
+var ImageSizes = []string{
+	NormalImage, BigImage, MediumImage, SmallImage,
+	Image128, Image256, Image512, Image768, Image1024, Image2048,
+}
+
+// WebPath is a public path prefix of vfs files.
+var WebPath string
+
+// ImagePath returns full path of vfs image by hash and preset size, it is empty for invalid hash.
+func ImagePath(hash, size string) string {
+	if len(hash) != 32 {
+		return ""
+	}
+
+	return WebPath + path.Join(
+		size,
+		hash[:1],
+		hash[1:3],
+		hash+".jpg",
+	)
+}
+
+// ImageSizePaths returns full paths of vfs image by preset size.
+func ImageSizePaths(hash string) map[string]string {
+	sizes := make(map[string]string, len(ImageSizes))
+	for _, size := range ImageSizes {
+		sizes[size] = ImagePath(hash, size)
+	}
+
+	return sizes
+}
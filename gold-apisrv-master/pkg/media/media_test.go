@@ -0,0 +1,25 @@
+package media
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestImagePath(t *testing.T) {
+	Convey("Test image paths", t, func() {
+		WebPath = "/media/"
+		hash := "0123456789abcdef0123456789abcdef"
+
+		So(ImagePath(hash, BigImage), ShouldEqual, "/media/big/0/12/"+hash+".jpg")
+		So(ImagePath("invalid", BigImage), ShouldBeEmpty)
+
+		sizes := ImageSizePaths(hash)
+		So(sizes, ShouldHaveLength, len(ImageSizes))
+		So(sizes[DefaultImage], ShouldEqual, "/media/256/0/12/"+hash+".jpg")
+
+		Reset(func() {
+			WebPath = ""
+		})
+	})
+}
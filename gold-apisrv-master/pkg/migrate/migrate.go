@@ -0,0 +1,417 @@
+// Package migrate applies versioned sql migrations, which are embedded into binary.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"apisrv/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+const (
+	// DefaultDir is a directory of embedded migrations relative to repository root.
+	DefaultDir = "pkg/migrate/migrations"
+
+	lockName        = "apisrv.migrate"
+	versionWidth    = 5
+	migrationsTable = "schemaMigrations"
+)
+
+const createTableSQL = `CREATE TABLE IF NOT EXISTS "schemaMigrations" (
+	"version" int8 NOT NULL,
+	"name" varchar(255) NOT NULL,
+	"checksum" varchar(64) NOT NULL,
+	"appliedAt" timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT "schemaMigrations_pkey" PRIMARY KEY ("version")
+)`
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration was changed")
+	ErrNoDown           = errors.New("migration has no down sql")
+	ErrUnknown          = errors.New("applied migration is not found")
+	ErrNotFound         = errors.New("migration is not found")
+)
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+// fileNameRe matches migration file names: {version}_{name}.{up|down}.sql.
+var fileNameRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var nameRe = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Migration is a schema change with sql to apply and to roll it back.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum returns hex sha256 of up sql.
+func (m Migration) Checksum() string {
+	h := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(h[:])
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%0*d_%s", versionWidth, m.Version, m.Name)
+}
+
+// Load reads migrations from dir, they are sorted by version. Every migration must have up sql, down sql is optional.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		m := fileNameRe.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+
+		b, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mg, ok := byVersion[version]
+		if !ok {
+			mg = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mg
+		} else if mg.Name != m[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, mg.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			mg.Up = string(b)
+		} else {
+			mg.Down = string(b)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, mg := range byVersion {
+		if mg.Up == "" {
+			return nil, fmt.Errorf("migration %s has no up sql", mg)
+		}
+		list = append(list, *mg)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+
+	return list, nil
+}
+
+// Embedded returns migrations embedded into binary.
+func Embedded() ([]Migration, error) {
+	return Load(embedded, "migrations")
+}
+
+// Create adds empty up and down migration files to dir with next version and returns their paths.
+func Create(dir, name string) ([]string, error) {
+	if !nameRe.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q, use lowercase letters, digits and underscores", name)
+	}
+
+	list, err := Load(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, fmt.Errorf("load migrations from %s: %w", dir, err)
+	}
+
+	mg := Migration{Version: 1, Name: name}
+	if len(list) > 0 {
+		mg.Version = list[len(list)-1].Version + 1
+	}
+
+	var files []string
+	for _, kind := range []string{"up", "down"} {
+		fn := filepath.Join(dir, mg.String()+"."+kind+".sql")
+		f, err := os.OpenFile(fn, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return files, err
+		}
+
+		_, err = fmt.Fprintf(f, "-- %s %s\n", mg, kind)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return files, err
+		}
+
+		files = append(files, fn)
+	}
+
+	return files, nil
+}
+
+// Status is a state of migration in database.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	IsChanged bool // applied migration has other checksum
+	IsUnknown bool // applied migration is not found in migrations list
+}
+
+// applied is a row of migrations table.
+type applied struct {
+	tableName struct{} `pg:"schemaMigrations"`
+
+	Version   int64     `pg:"version,pk"`
+	Name      string    `pg:"name"`
+	Checksum  string    `pg:"checksum"`
+	AppliedAt time.Time `pg:"appliedAt"`
+}
+
+// Migrator applies migrations in transaction with advisory lock, so only one instance migrates database at once.
+// Migrations can't contain statements, which are not allowed in transaction, like CREATE INDEX CONCURRENTLY.
+type Migrator struct {
+	db         db.DB
+	migrations []Migration
+}
+
+func New(dbo db.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: dbo, migrations: migrations}
+}
+
+// Up applies all pending migrations by version and returns them.
+// Nothing is applied if any applied migration was changed or is not found in migrations list.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var list []Migration
+	err := m.db.RunInLock(ctx, lockName, func(tx *pg.Tx) error {
+		done, err := m.applied(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if unknown := unknownVersions(m.migrations, done); len(unknown) > 0 {
+			return fmt.Errorf("versions %v: %w", unknown, ErrUnknown)
+		}
+
+		for _, mg := range m.migrations {
+			if a, ok := done[mg.Version]; ok {
+				if a.Checksum != mg.Checksum() {
+					return fmt.Errorf("%s: %w", mg, ErrChecksumMismatch)
+				}
+				continue
+			}
+			list = append(list, mg)
+		}
+
+		for _, mg := range list {
+			if _, err := tx.ExecContext(ctx, mg.Up); err != nil {
+				return fmt.Errorf("%s: %w", mg, err)
+			}
+
+			a := &applied{Version: mg.Version, Name: mg.Name, Checksum: mg.Checksum()}
+			if _, err := tx.ModelContext(ctx, a).ExcludeColumn("appliedAt").Insert(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Down rolls back n last applied migrations and returns them.
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, mg := range m.migrations {
+		byVersion[mg.Version] = mg
+	}
+
+	var list []Migration
+	err := m.db.RunInLock(ctx, lockName, func(tx *pg.Tx) error {
+		var rows []applied
+		if err := m.createTable(ctx, tx); err != nil {
+			return err
+		} else if err := tx.ModelContext(ctx, &rows).Order("version DESC").Limit(n).Select(); err != nil {
+			return err
+		}
+
+		for _, a := range rows {
+			mg, ok := byVersion[a.Version]
+			switch {
+			case !ok:
+				return fmt.Errorf("%0*d_%s: %w", versionWidth, a.Version, a.Name, ErrUnknown)
+			case mg.Down == "":
+				return fmt.Errorf("%s: %w", mg, ErrNoDown)
+			}
+
+			if _, err := tx.ExecContext(ctx, mg.Down); err != nil {
+				return fmt.Errorf("%s: %w", mg, err)
+			}
+
+			if _, err := tx.ModelContext(ctx, &a).WherePK().Delete(); err != nil {
+				return err
+			}
+			list = append(list, mg)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Baseline marks migrations up to version as applied without running them and returns them.
+// It is used for existing databases, which schema was created without migrations.
+func (m *Migrator) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	found := false
+	for _, mg := range m.migrations {
+		found = found || mg.Version == version
+	}
+	if !found {
+		return nil, fmt.Errorf("version %d: %w", version, ErrNotFound)
+	}
+
+	var list []Migration
+	err := m.db.RunInLock(ctx, lockName, func(tx *pg.Tx) error {
+		done, err := m.applied(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, mg := range m.migrations {
+			if _, ok := done[mg.Version]; ok || mg.Version > version {
+				continue
+			}
+
+			a := &applied{Version: mg.Version, Name: mg.Name, Checksum: mg.Checksum()}
+			if _, err := tx.ModelContext(ctx, a).ExcludeColumn("appliedAt").Insert(); err != nil {
+				return err
+			}
+			list = append(list, mg)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Status returns states of known and applied migrations by version.
+// It only reads migrations table without lock, so it is not blocked by running migrations.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var exists bool
+	if _, err := m.db.QueryOneContext(ctx, pg.Scan(&exists), `SELECT to_regclass(?) IS NOT NULL`, `"`+migrationsTable+`"`); err != nil {
+		return nil, err
+	} else if !exists {
+		return statuses(m.migrations, nil), nil
+	}
+
+	done, err := m.selectApplied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses(m.migrations, done), nil
+}
+
+// unknownVersions returns sorted versions of applied migrations, which are not found in migrations list.
+func unknownVersions(migrations []Migration, done map[int64]applied) []int64 {
+	known := make(map[int64]bool, len(migrations))
+	for _, mg := range migrations {
+		known[mg.Version] = true
+	}
+
+	var list []int64
+	for v := range done {
+		if !known[v] {
+			list = append(list, v)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+
+	return list
+}
+
+// statuses merges migrations with applied rows.
+func statuses(migrations []Migration, done map[int64]applied) []Status {
+	list := make([]Status, 0, len(migrations))
+	known := make(map[int64]bool, len(migrations))
+	for _, mg := range migrations {
+		known[mg.Version] = true
+		s := Status{Version: mg.Version, Name: mg.Name}
+		if a, ok := done[mg.Version]; ok {
+			appliedAt := a.AppliedAt
+			s.AppliedAt = &appliedAt
+			s.IsChanged = a.Checksum != mg.Checksum()
+		}
+		list = append(list, s)
+	}
+
+	for v, a := range done {
+		if !known[v] {
+			appliedAt := a.AppliedAt
+			list = append(list, Status{Version: v, Name: a.Name, AppliedAt: &appliedAt, IsUnknown: true})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+
+	return list
+}
+
+// createTable creates migrations table if it does not exist.
+func (m *Migrator) createTable(ctx context.Context, tx *pg.Tx) error {
+	_, err := tx.ExecContext(ctx, createTableSQL)
+	return err
+}
+
+// applied creates migrations table if it does not exist and returns applied migrations by version.
+func (m *Migrator) applied(ctx context.Context, tx *pg.Tx) (map[int64]applied, error) {
+	if err := m.createTable(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return m.selectApplied(ctx, tx)
+}
+
+// selectApplied returns applied migrations by version from existing migrations table.
+func (m *Migrator) selectApplied(ctx context.Context, dbo orm.DB) (map[int64]applied, error) {
+	var rows []applied
+	if err := dbo.ModelContext(ctx, &rows).Select(); err != nil {
+		return nil, err
+	}
+
+	done := make(map[int64]applied, len(rows))
+	for _, a := range rows {
+		done[a.Version] = a
+	}
+
+	return done, nil
+}
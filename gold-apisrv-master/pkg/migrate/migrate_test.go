@@ -0,0 +1,173 @@
+package migrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"apisrv/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var dbConn = env("DB_CONN", "postgresql://localhost:5432/apisrv?sslmode=disable")
+
+func env(v, def string) string {
+	if r := os.Getenv(v); r != "" {
+		return r
+	}
+
+	return def
+}
+
+func TestLoad(t *testing.T) {
+	Convey("Test loading migrations", t, func() {
+		Convey("Migrations are sorted by version", func() {
+			list, err := Load(fstest.MapFS{
+				"m/00002_add_b.up.sql":    {Data: []byte("b")},
+				"m/00001_init.up.sql":     {Data: []byte("a")},
+				"m/00001_init.down.sql":   {Data: []byte("drop a")},
+				"m/README.md":             {Data: []byte("readme")},
+				"m/00003_Bad-Name.up.sql": {Data: []byte("c")},
+			}, "m")
+			So(err, ShouldBeNil)
+			So(list, ShouldResemble, []Migration{
+				{Version: 1, Name: "init", Up: "a", Down: "drop a"},
+				{Version: 2, Name: "add_b", Up: "b"},
+			})
+			So(list[0].String(), ShouldEqual, "00001_init")
+			So(list[0].Checksum(), ShouldHaveLength, 64)
+			So(list[0].Checksum(), ShouldNotEqual, list[1].Checksum())
+		})
+
+		Convey("Duplicate versions and migrations without up are rejected", func() {
+			_, err := Load(fstest.MapFS{"00001_a.up.sql": {Data: []byte("a")}, "00001_b.up.sql": {Data: []byte("b")}}, ".")
+			So(err, ShouldNotBeNil)
+
+			_, err = Load(fstest.MapFS{"00001_a.down.sql": {Data: []byte("a")}}, ".")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Embedded migrations are valid", func() {
+			list, err := Embedded()
+			So(err, ShouldBeNil)
+			So(len(list), ShouldBeGreaterThan, 0)
+			So(list[0].Name, ShouldEqual, "init")
+			So(list[0].Up, ShouldNotContainSubstring, `INSERT INTO "users"`)
+			for _, mg := range list {
+				So(mg.Down, ShouldNotBeEmpty)
+			}
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	Convey("Test creating migrations", t, func() {
+		dir := t.TempDir()
+
+		files, err := Create(dir, "init")
+		So(err, ShouldBeNil)
+		So(files, ShouldResemble, []string{filepath.Join(dir, "00001_init.up.sql"), filepath.Join(dir, "00001_init.down.sql")})
+
+		files, err = Create(dir, "add_users")
+		So(err, ShouldBeNil)
+		So(files[0], ShouldEqual, filepath.Join(dir, "00002_add_users.up.sql"))
+
+		list, err := Load(os.DirFS(dir), ".")
+		So(err, ShouldBeNil)
+		So(list, ShouldHaveLength, 2)
+
+		_, err = Create(dir, "Add users")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestStatuses(t *testing.T) {
+	Convey("Test migration statuses", t, func() {
+		now := time.Now()
+		migrations := []Migration{{Version: 1, Name: "a", Up: "a"}, {Version: 2, Name: "b", Up: "b"}, {Version: 4, Name: "d", Up: "d"}}
+		done := map[int64]applied{
+			1: {Version: 1, Name: "a", Checksum: migrations[0].Checksum(), AppliedAt: now},
+			2: {Version: 2, Name: "b", Checksum: "old", AppliedAt: now},
+			3: {Version: 3, Name: "c", Checksum: "c", AppliedAt: now},
+		}
+
+		So(statuses(migrations, done), ShouldResemble, []Status{
+			{Version: 1, Name: "a", AppliedAt: &now},
+			{Version: 2, Name: "b", AppliedAt: &now, IsChanged: true},
+			{Version: 3, Name: "c", AppliedAt: &now, IsUnknown: true},
+			{Version: 4, Name: "d"},
+		})
+		So(statuses(migrations, nil), ShouldHaveLength, len(migrations))
+		So(unknownVersions(migrations, done), ShouldResemble, []int64{3})
+		So(unknownVersions(migrations[:1], done), ShouldResemble, []int64{2, 3})
+	})
+}
+
+func TestDB_Migrator(t *testing.T) {
+	Convey("Test applying migrations", t, func() {
+		cfg, err := pg.ParseURL(dbConn)
+		So(err, ShouldBeNil)
+		dbc := db.New(pg.Connect(cfg))
+		ctx := context.Background()
+
+		// test database schema is created from docs/apisrv.sql, so embedded migrations are marked as applied
+		embedded, err := Embedded()
+		So(err, ShouldBeNil)
+		_, err = New(dbc, embedded).Baseline(ctx, embedded[len(embedded)-1].Version)
+		So(err, ShouldBeNil)
+
+		// versions are far from real ones to keep test database schema untouched
+		migrations := []Migration{
+			{Version: 900001, Name: "test_a", Up: `CREATE TABLE "migrateTestA" ("id" int)`, Down: `DROP TABLE "migrateTestA"`},
+			{Version: 900002, Name: "test_b", Up: `CREATE TABLE "migrateTestB" ("id" int)`, Down: `DROP TABLE "migrateTestB"`},
+		}
+
+		m := New(dbc, append(embedded, migrations[:1]...))
+		list, err := m.Up(ctx)
+		So(err, ShouldBeNil)
+		So(list, ShouldHaveLength, 1)
+
+		m = New(dbc, append(embedded, migrations...))
+		list, err = m.Up(ctx)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, migrations[1:])
+
+		list, err = m.Up(ctx)
+		So(err, ShouldBeNil)
+		So(list, ShouldBeEmpty)
+
+		Convey("Migrations missing in list are reported", func() {
+			_, err := New(dbc, append(embedded, migrations[:1]...)).Up(ctx)
+			So(err, ShouldWrap, ErrUnknown)
+
+			_, err = New(dbc, embedded).Baseline(ctx, 900003)
+			So(err, ShouldWrap, ErrNotFound)
+		})
+
+		Convey("Changed migration is not applied", func() {
+			changed := append(append([]Migration{}, embedded...), migrations...)
+			changed[len(embedded)].Up += ";"
+			_, err := New(dbc, changed).Up(ctx)
+			So(err, ShouldWrap, ErrChecksumMismatch)
+
+			st, err := New(dbc, changed).Status(ctx)
+			So(err, ShouldBeNil)
+			for _, s := range st {
+				if s.Version == migrations[0].Version {
+					So(s.IsChanged, ShouldBeTrue)
+				}
+			}
+		})
+
+		Reset(func() {
+			list, err := m.Down(ctx, 2)
+			So(err, ShouldBeNil)
+			So(list, ShouldResemble, []Migration{migrations[1], migrations[0]})
+		})
+	})
+}
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "categories" CASCADE;
+DROP TABLE IF EXISTS "news" CASCADE;
+DROP TABLE IF EXISTS "tags" CASCADE;
+DROP TABLE IF EXISTS "vfsHashes" CASCADE;
+DROP TABLE IF EXISTS "vfsFolders" CASCADE;
+DROP TABLE IF EXISTS "vfsFiles" CASCADE;
+DROP TABLE IF EXISTS "users" CASCADE;
+DROP TABLE IF EXISTS "statuses" CASCADE;
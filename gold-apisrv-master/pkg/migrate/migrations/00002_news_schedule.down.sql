@@ -0,0 +1,3 @@
+ALTER TABLE "news" DROP COLUMN "unpublishedAt";
+ALTER TABLE "news" DROP COLUMN "publishedAt";
+ALTER TABLE "news" DROP COLUMN "unpublishDate";
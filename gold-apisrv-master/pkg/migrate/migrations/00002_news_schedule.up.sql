@@ -0,0 +1,3 @@
+ALTER TABLE "news" ADD COLUMN "unpublishDate" timestamp with time zone;
+ALTER TABLE "news" ADD COLUMN "publishedAt" timestamp with time zone;
+ALTER TABLE "news" ADD COLUMN "unpublishedAt" timestamp with time zone;
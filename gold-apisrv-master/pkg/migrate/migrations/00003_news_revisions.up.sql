@@ -0,0 +1,31 @@
+CREATE TABLE "newsRevisions" (
+    "revisionId" SERIAL NOT NULL,
+    "newsId" int4 NOT NULL,
+    "title" varchar(256) NOT NULL,
+    "alias" varchar(256) NOT NULL,
+    "content" text,
+    "categoryId" int4 NOT NULL,
+    "tagIds" int4[],
+    "statusId" int4 NOT NULL,
+    "userId" int4,
+    "createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY("revisionId")
+);
+
+CREATE INDEX "IX_FK_newsRevisions_newsId_newsRevisions" ON "newsRevisions" USING BTREE (
+    "newsId"
+);
+
+ALTER TABLE "newsRevisions" ADD CONSTRAINT "Ref_newsRevisions_to_news" FOREIGN KEY ("newsId")
+    REFERENCES "news"("newsId")
+        MATCH SIMPLE
+    ON DELETE CASCADE
+    ON UPDATE NO ACTION
+    NOT DEFERRABLE;
+
+ALTER TABLE "newsRevisions" ADD CONSTRAINT "Ref_newsRevisions_to_users" FOREIGN KEY ("userId")
+    REFERENCES "users"("userId")
+        MATCH SIMPLE
+    ON DELETE SET NULL
+    ON UPDATE NO ACTION
+    NOT DEFERRABLE;
@@ -0,0 +1,8 @@
+ALTER TABLE "news" ADD COLUMN "searchVector" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce("title", '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce("content", '')), 'B')
+) STORED;
+
+CREATE INDEX "IX_news_searchVector" ON "news" USING GIN (
+    "searchVector"
+);
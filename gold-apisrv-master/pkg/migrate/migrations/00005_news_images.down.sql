@@ -0,0 +1,3 @@
+ALTER TABLE "categories" DROP COLUMN "coverImage";
+ALTER TABLE "news" DROP COLUMN "galleryImages";
+ALTER TABLE "news" DROP COLUMN "coverImage";
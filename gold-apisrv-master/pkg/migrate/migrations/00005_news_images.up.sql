@@ -0,0 +1,3 @@
+ALTER TABLE "news" ADD COLUMN "coverImage" varchar(40);
+ALTER TABLE "news" ADD COLUMN "galleryImages" varchar(40)[];
+ALTER TABLE "categories" ADD COLUMN "coverImage" varchar(40);
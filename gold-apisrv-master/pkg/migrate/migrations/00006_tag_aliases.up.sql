@@ -0,0 +1,10 @@
+ALTER TABLE "tags" ADD COLUMN "alias" varchar(256);
+
+-- Titles of existing tags can't be used as aliases, which are latin, so aliases are made from ids.
+UPDATE "tags" SET "alias" = 'tag-' || "tagId";
+
+ALTER TABLE "tags" ALTER COLUMN "alias" SET NOT NULL;
+
+CREATE UNIQUE INDEX "IX_tags_alias" ON "tags" USING BTREE (
+    "alias"
+);
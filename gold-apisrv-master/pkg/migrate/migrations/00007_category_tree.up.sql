@@ -0,0 +1,12 @@
+ALTER TABLE "categories" ADD COLUMN "parentCategoryId" int4;
+
+ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_categories" FOREIGN KEY ("parentCategoryId")
+    REFERENCES "categories"("categoryId")
+        MATCH SIMPLE
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION
+    NOT DEFERRABLE;
+
+CREATE INDEX "IX_FK_categories_parentCategoryId_categories" ON "categories" USING BTREE (
+    "parentCategoryId"
+);
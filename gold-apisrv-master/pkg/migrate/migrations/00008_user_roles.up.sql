@@ -0,0 +1,5 @@
+ALTER TABLE "users" ADD COLUMN "role" varchar(16) NOT NULL DEFAULT 'viewer';
+
+-- All users had full access before roles, so enabled users existing at this point keep it.
+-- Users added later get the viewer role by default, restrict backfilled admins with role.assign.
+UPDATE "users" SET "role" = 'admin' WHERE "statusId" = 1;
@@ -0,0 +1,29 @@
+CREATE TABLE "sessions" (
+	"sessionId" SERIAL NOT NULL,
+	"userId" int4 NOT NULL,
+	"tokenHash" varchar(64) NOT NULL,
+	"isRemember" bool NOT NULL DEFAULT false,
+	"device" varchar(64),
+	"ip" varchar(45),
+	"userAgent" text,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"lastActivityAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	"idleExpiresAt" timestamp with time zone NOT NULL,
+	CONSTRAINT "sessions_pkey" PRIMARY KEY("sessionId")
+);
+
+CREATE UNIQUE INDEX "IX_sessions_tokenHash" ON "sessions" USING BTREE (
+	"tokenHash"
+);
+
+CREATE INDEX "IX_FK_sessions_userId_sessions" ON "sessions" USING BTREE (
+	"userId"
+);
+
+ALTER TABLE "sessions" ADD CONSTRAINT "FK_sessions_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
@@ -0,0 +1 @@
+ALTER TABLE "users" ADD COLUMN "authKey" varchar(32);
@@ -0,0 +1,2 @@
+-- Auth keys are replaced by sessions, users sign in again after upgrade.
+ALTER TABLE "users" DROP COLUMN "authKey";
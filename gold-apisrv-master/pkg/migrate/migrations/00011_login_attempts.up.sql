@@ -0,0 +1,7 @@
+CREATE TABLE "loginAttempts" (
+	"key" varchar(128) NOT NULL,
+	"failures" int4 NOT NULL DEFAULT 0,
+	"lastFailureAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"lockedUntil" timestamp with time zone,
+	CONSTRAINT "loginAttempts_pkey" PRIMARY KEY("key")
+);
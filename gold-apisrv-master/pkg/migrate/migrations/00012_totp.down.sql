@@ -0,0 +1,5 @@
+DROP TABLE "loginChallenges";
+ALTER TABLE "users" DROP COLUMN "totpLastStep";
+ALTER TABLE "users" DROP COLUMN "recoveryCodes";
+ALTER TABLE "users" DROP COLUMN "isTotpEnabled";
+ALTER TABLE "users" DROP COLUMN "totpSecret";
@@ -0,0 +1,30 @@
+ALTER TABLE "users" ADD COLUMN "totpSecret" varchar(64);
+ALTER TABLE "users" ADD COLUMN "isTotpEnabled" bool NOT NULL DEFAULT false;
+ALTER TABLE "users" ADD COLUMN "recoveryCodes" varchar(64)[];
+ALTER TABLE "users" ADD COLUMN "totpLastStep" int8;
+
+CREATE TABLE "loginChallenges" (
+	"loginChallengeId" SERIAL NOT NULL,
+	"userId" int4 NOT NULL,
+	"tokenHash" varchar(64) NOT NULL,
+	"isRemember" bool NOT NULL DEFAULT false,
+	"attempts" int4 NOT NULL DEFAULT 0,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	CONSTRAINT "loginChallenges_pkey" PRIMARY KEY("loginChallengeId")
+);
+
+CREATE UNIQUE INDEX "IX_loginChallenges_tokenHash" ON "loginChallenges" USING BTREE (
+	"tokenHash"
+);
+
+CREATE INDEX "IX_FK_loginChallenges_userId_loginChallenges" ON "loginChallenges" USING BTREE (
+	"userId"
+);
+
+ALTER TABLE "loginChallenges" ADD CONSTRAINT "FK_loginChallenges_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
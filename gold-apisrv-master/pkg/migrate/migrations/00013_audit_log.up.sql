@@ -0,0 +1,33 @@
+CREATE TABLE "auditLog" (
+	"auditLogId" SERIAL NOT NULL,
+	"userId" int4,
+	"namespace" varchar(32) NOT NULL,
+	"method" varchar(64) NOT NULL,
+	"objectId" int4,
+	"params" jsonb,
+	"resultCode" int4 NOT NULL DEFAULT 0,
+	"ip" varchar(45),
+	"requestId" varchar(64),
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "auditLog_pkey" PRIMARY KEY("auditLogId")
+);
+
+CREATE INDEX "IX_FK_auditLog_userId_auditLog" ON "auditLog" USING BTREE (
+	"userId"
+);
+
+CREATE INDEX "IX_auditLog_namespace_objectId" ON "auditLog" USING BTREE (
+	"namespace",
+	"objectId"
+);
+
+CREATE INDEX "IX_auditLog_createdAt" ON "auditLog" USING BTREE (
+	"createdAt"
+);
+
+ALTER TABLE "auditLog" ADD CONSTRAINT "FK_auditLog_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
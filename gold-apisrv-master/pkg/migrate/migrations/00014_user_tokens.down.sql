@@ -0,0 +1,2 @@
+DROP TABLE "userTokens";
+ALTER TABLE "users" DROP COLUMN "email";
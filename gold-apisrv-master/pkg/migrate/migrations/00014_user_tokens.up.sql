@@ -0,0 +1,31 @@
+ALTER TABLE "users" ADD COLUMN "email" varchar(255);
+
+CREATE INDEX "IX_users_email" ON "users" USING BTREE (
+	"email"
+);
+
+CREATE TABLE "userTokens" (
+	"userTokenId" SERIAL NOT NULL,
+	"userId" int4 NOT NULL,
+	"kind" varchar(16) NOT NULL,
+	"tokenHash" varchar(64) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	"usedAt" timestamp with time zone,
+	CONSTRAINT "userTokens_pkey" PRIMARY KEY("userTokenId")
+);
+
+CREATE UNIQUE INDEX "IX_userTokens_tokenHash" ON "userTokens" USING BTREE (
+	"tokenHash"
+);
+
+CREATE INDEX "IX_FK_userTokens_userId_userTokens" ON "userTokens" USING BTREE (
+	"userId"
+);
+
+ALTER TABLE "userTokens" ADD CONSTRAINT "FK_userTokens_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
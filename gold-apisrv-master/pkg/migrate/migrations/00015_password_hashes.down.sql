@@ -0,0 +1,2 @@
+-- Column is not narrowed back: argon2id hashes don't fit into varchar(64).
+SELECT 1;
@@ -0,0 +1,2 @@
+-- argon2id hashes in PHC format are longer than bcrypt ones.
+ALTER TABLE "users" ALTER COLUMN "password" TYPE varchar(255);
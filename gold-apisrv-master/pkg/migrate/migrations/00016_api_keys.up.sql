@@ -0,0 +1,40 @@
+CREATE TABLE "apiKeys" (
+	"apiKeyId" SERIAL NOT NULL,
+	"userId" int4 NOT NULL,
+	"title" varchar(128) NOT NULL,
+	"keyPrefix" varchar(16) NOT NULL,
+	"keyHash" varchar(64) NOT NULL,
+	"scopes" jsonb NOT NULL,
+	"isReadOnly" bool NOT NULL DEFAULT false,
+	"expiresAt" timestamp with time zone,
+	"lastUsedAt" timestamp with time zone,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"statusId" int4 NOT NULL,
+	CONSTRAINT "apiKeys_pkey" PRIMARY KEY("apiKeyId")
+);
+
+CREATE UNIQUE INDEX "IX_apiKeys_keyHash" ON "apiKeys" USING BTREE (
+	"keyHash"
+);
+
+CREATE INDEX "IX_FK_apiKeys_userId_apiKeys" ON "apiKeys" USING BTREE (
+	"userId"
+);
+
+CREATE INDEX "IX_FK_apiKeys_statusId_apiKeys" ON "apiKeys" USING BTREE (
+	"statusId"
+);
+
+ALTER TABLE "apiKeys" ADD CONSTRAINT "FK_apiKeys_userId" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
+
+ALTER TABLE "apiKeys" ADD CONSTRAINT "FK_apiKeys_statusId" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
@@ -0,0 +1,2 @@
+DROP TABLE "oidcStates";
+ALTER TABLE "users" DROP COLUMN "oidcSubject";
@@ -0,0 +1,15 @@
+ALTER TABLE "users" ADD COLUMN "oidcSubject" varchar(255);
+
+CREATE INDEX "IX_users_oidcSubject" ON "users" USING BTREE (
+	"oidcSubject"
+);
+
+CREATE TABLE "oidcStates" (
+	"stateHash" varchar(64) NOT NULL,
+	"nonce" varchar(64) NOT NULL,
+	"verifier" varchar(128) NOT NULL,
+	"isRemember" bool NOT NULL DEFAULT false,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	CONSTRAINT "oidcStates_pkey" PRIMARY KEY("stateHash")
+);
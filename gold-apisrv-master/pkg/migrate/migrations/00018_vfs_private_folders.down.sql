@@ -0,0 +1,2 @@
+DROP INDEX "IX_vfsFiles_path";
+ALTER TABLE "vfsFolders" DROP COLUMN "isPrivate";
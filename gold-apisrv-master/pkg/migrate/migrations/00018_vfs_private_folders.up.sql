@@ -0,0 +1,5 @@
+ALTER TABLE "vfsFolders" ADD COLUMN "isPrivate" bool NOT NULL DEFAULT false;
+
+CREATE INDEX "IX_vfsFiles_path" ON "vfsFiles" USING BTREE (
+	"path"
+);
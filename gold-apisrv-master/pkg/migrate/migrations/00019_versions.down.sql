@@ -0,0 +1,4 @@
+ALTER TABLE "tags" DROP COLUMN "version";
+ALTER TABLE "news" DROP COLUMN "version";
+ALTER TABLE "categories" DROP COLUMN "version";
+ALTER TABLE "users" DROP COLUMN "version";
@@ -0,0 +1,4 @@
+ALTER TABLE "users" ADD COLUMN "version" int4 NOT NULL DEFAULT 1;
+ALTER TABLE "categories" ADD COLUMN "version" int4 NOT NULL DEFAULT 1;
+ALTER TABLE "news" ADD COLUMN "version" int4 NOT NULL DEFAULT 1;
+ALTER TABLE "tags" ADD COLUMN "version" int4 NOT NULL DEFAULT 1;
@@ -0,0 +1,428 @@
+// Package oidc implements OpenID Connect authorization code flow with PKCE (RFC 7636) for confidential clients.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512" // RS384 and RS512 hashes
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ScopeOpenID = "openid"
+
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// Leeway is a clock skew allowed for id token expiration.
+	Leeway = time.Minute
+
+	verifierBytes = 32
+
+	// maxResponseSize limits identity provider responses.
+	maxResponseSize = 1 << 20
+)
+
+var (
+	ErrInvalidToken   = errors.New("invalid id token")
+	ErrUnsupportedAlg = errors.New("unsupported id token signing algorithm")
+	ErrUnknownKey     = errors.New("unknown id token signing key")
+	ErrNoIDToken      = errors.New("token response has no id token")
+)
+
+// algorithms are supported RSA signing algorithms of id tokens.
+var algorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+type Config struct {
+	Issuer       string   // issuer url, endpoints are discovered from {Issuer}/.well-known/openid-configuration
+	ClientID     string   // client id registered in identity provider
+	ClientSecret string   // client secret, sent with basic auth
+	RedirectURL  string   // callback url registered in identity provider
+	Scopes       []string // additional scopes, openid is always requested
+}
+
+// Error is an error response of identity provider.
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return "oidc: " + e.Code
+	}
+	return fmt.Sprintf("oidc: %s: %s", e.Code, e.Description)
+}
+
+// Token is a token endpoint response.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Audience is an aud claim, which is a string or an array of strings.
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*a = list
+
+	return nil
+}
+
+// Contains checks that clientID is in audience.
+func (a Audience) Contains(clientID string) bool {
+	for _, s := range a {
+		if s == clientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Claims are verified id token claims, all claims are in Raw.
+type Claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          Audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp"`
+	Expiry            int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"email_verified"`
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Strings returns claim name as list of strings, single string claim is returned as list with one element.
+func (c Claims) Strings(name string) []string {
+	switch v := c.Raw[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, i := range v {
+			if s, ok := i.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+
+	return nil
+}
+
+// NewVerifier returns random PKCE code verifier.
+func NewVerifier() (string, error) {
+	b := make([]byte, verifierBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Challenge returns S256 PKCE code challenge for verifier.
+func Challenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// endpoints are discovered provider metadata.
+type endpoints struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an OpenID Connect identity provider client.
+type Provider struct {
+	cfg       Config
+	client    *http.Client
+	endpoints endpoints
+	now       func() time.Time
+
+	mu   sync.RWMutex
+	keys map[string]*rsa.PublicKey
+}
+
+// New discovers provider endpoints of cfg.Issuer, http.DefaultClient is used if client is nil.
+func New(ctx context.Context, cfg Config, client *http.Client) (*Provider, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	p := &Provider{cfg: cfg, client: client, now: time.Now}
+	if err := p.getJSON(ctx, strings.TrimSuffix(cfg.Issuer, "/")+discoveryPath, &p.endpoints); err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+
+	if p.endpoints.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf("issuer mismatch: expected %q, got %q", cfg.Issuer, p.endpoints.Issuer)
+	} else if p.endpoints.AuthorizationEndpoint == "" || p.endpoints.TokenEndpoint == "" || p.endpoints.JWKSURI == "" {
+		return nil, errors.New("discovery document has no required endpoints")
+	}
+
+	return p, nil
+}
+
+// AuthCodeURL returns authorization endpoint url with S256 code challenge of verifier.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	v := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(append([]string{ScopeOpenID}, p.cfg.Scopes...), " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {Challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(p.endpoints.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return p.endpoints.AuthorizationEndpoint + sep + v.Encode()
+}
+
+// Exchange exchanges authorization code and PKCE code verifier for tokens.
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {verifier},
+		"client_id":     {p.cfg.ClientID},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoints.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	var token Token
+	if err := p.do(req, &token); err != nil {
+		return nil, err
+	} else if token.IDToken == "" {
+		return nil, ErrNoIDToken
+	}
+
+	return &token, nil
+}
+
+// Verify checks id token signature, issuer, audience, expiration and nonce and returns its claims.
+func (p *Provider) Verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	hash, ok := algorithms[header.Alg]
+	if !ok {
+		return nil, ErrUnsupportedAlg
+	}
+
+	key, err := p.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	h := hash.New()
+	h.Write([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, hash, h.Sum(nil), sig); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if err := decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	switch {
+	case claims.Issuer != p.endpoints.Issuer:
+		return nil, fmt.Errorf("%w: issuer mismatch", ErrInvalidToken)
+	case !claims.Audience.Contains(p.cfg.ClientID):
+		return nil, fmt.Errorf("%w: audience mismatch", ErrInvalidToken)
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID:
+		return nil, fmt.Errorf("%w: authorized party mismatch", ErrInvalidToken)
+	case !p.now().Before(time.Unix(claims.Expiry, 0).Add(Leeway)):
+		return nil, fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: empty subject", ErrInvalidToken)
+	}
+
+	return &claims, nil
+}
+
+// key returns signing key by id, keys are refetched once if key is not found.
+func (p *Provider) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.RLock()
+	key := findKey(p.keys, kid)
+	p.mu.RUnlock()
+	if key != nil {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	if key = findKey(keys, kid); key == nil {
+		return nil, ErrUnknownKey
+	}
+
+	return key, nil
+}
+
+// findKey returns key by id, empty kid matches the only key.
+func findKey(keys map[string]*rsa.PublicKey, kid string) *rsa.PublicKey {
+	if kid == "" && len(keys) == 1 {
+		for _, k := range keys {
+			return k
+		}
+	}
+
+	return keys[kid]
+}
+
+// fetchKeys returns RSA signing keys from jwks uri.
+func (p *Provider) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.endpoints.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("jwks fetch failed: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	return keys, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	return p.do(req, v)
+}
+
+// do sends request and decodes json response into v or returns *Error.
+func (p *Provider) do(req *http.Request, v interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		e := &Error{}
+		if json.Unmarshal(body, e) != nil || e.Code == "" {
+			return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Path)
+		}
+		return e
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// decodeSegment decodes base64url json segment of jwt.
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
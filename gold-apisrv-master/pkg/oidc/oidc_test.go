@@ -0,0 +1,132 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"apisrv/pkg/oidc/oidctest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// authorize follows authorization url and returns code and state from redirect.
+func authorize(authURL string) (code, state string, err error) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+
+	return loc.Query().Get("code"), loc.Query().Get("state"), nil
+}
+
+func TestProvider(t *testing.T) {
+	Convey("Test OpenID Connect provider", t, func() {
+		idp := oidctest.NewServer("vt", "secret")
+		defer idp.Close()
+		idp.SetUser("user1", map[string]interface{}{"email": "user1@example.com", "email_verified": true, "groups": []string{"editors", "staff"}})
+
+		ctx := context.Background()
+		cfg := Config{Issuer: idp.URL, ClientID: "vt", ClientSecret: "secret", RedirectURL: "http://localhost/callback", Scopes: []string{"email"}}
+		p, err := New(ctx, cfg, nil)
+		So(err, ShouldBeNil)
+
+		verifier, err := NewVerifier()
+		So(err, ShouldBeNil)
+
+		Convey("Authorization code flow", func() {
+			authURL := p.AuthCodeURL("state1", "nonce1", verifier)
+			So(authURL, ShouldStartWith, idp.URL+oidctest.AuthorizePath)
+			So(authURL, ShouldContainSubstring, "scope=openid+email")
+			So(authURL, ShouldContainSubstring, "code_challenge="+Challenge(verifier))
+
+			code, state, err := authorize(authURL)
+			So(err, ShouldBeNil)
+			So(state, ShouldEqual, "state1")
+
+			token, err := p.Exchange(ctx, code, verifier)
+			So(err, ShouldBeNil)
+
+			claims, err := p.Verify(ctx, token.IDToken, "nonce1")
+			So(err, ShouldBeNil)
+			So(claims.Subject, ShouldEqual, "user1")
+			So(claims.Email, ShouldEqual, "user1@example.com")
+			So(claims.EmailVerified, ShouldBeTrue)
+			So(claims.Strings("groups"), ShouldResemble, []string{"editors", "staff"})
+			So(claims.Strings("email"), ShouldResemble, []string{"user1@example.com"})
+			So(claims.Strings("unknown"), ShouldBeNil)
+
+			Convey("Code is single use", func() {
+				_, err := p.Exchange(ctx, code, verifier)
+				So(err, ShouldHaveSameTypeAs, &Error{})
+				So(err.(*Error).Code, ShouldEqual, "invalid_grant")
+			})
+		})
+
+		Convey("Wrong code verifier is rejected", func() {
+			code, _, err := authorize(p.AuthCodeURL("state", "nonce", verifier))
+			So(err, ShouldBeNil)
+
+			other, _ := NewVerifier()
+			_, err = p.Exchange(ctx, code, other)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "code_verifier mismatch")
+		})
+
+		Convey("Wrong client secret is rejected", func() {
+			code, _, err := authorize(p.AuthCodeURL("state", "nonce", verifier))
+			So(err, ShouldBeNil)
+
+			cfg.ClientSecret = "wrong"
+			p2, err := New(ctx, cfg, nil)
+			So(err, ShouldBeNil)
+			_, err = p2.Exchange(ctx, code, verifier)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "invalid_client")
+		})
+
+		Convey("Invalid id tokens are rejected", func() {
+			_, err := p.Verify(ctx, idp.IDToken("nonce", nil), "nonce")
+			So(err, ShouldBeNil)
+
+			_, err = p.Verify(ctx, idp.IDToken("nonce", nil), "other")
+			So(err, ShouldWrap, ErrInvalidToken)
+
+			_, err = p.Verify(ctx, idp.IDToken("nonce", map[string]interface{}{"exp": time.Now().Add(-2 * Leeway).Unix()}), "nonce")
+			So(err, ShouldWrap, ErrInvalidToken)
+
+			_, err = p.Verify(ctx, idp.IDToken("nonce", map[string]interface{}{"aud": []string{"other"}}), "nonce")
+			So(err, ShouldWrap, ErrInvalidToken)
+
+			_, err = p.Verify(ctx, idp.IDToken("nonce", map[string]interface{}{"aud": []string{"vt", "other"}, "azp": "other"}), "nonce")
+			So(err, ShouldWrap, ErrInvalidToken)
+
+			_, err = p.Verify(ctx, idp.IDToken("nonce", map[string]interface{}{"iss": "http://evil"}), "nonce")
+			So(err, ShouldWrap, ErrInvalidToken)
+
+			parts := strings.Split(idp.IDToken("nonce", nil), ".")
+			tampered := parts[0] + "." + strings.Split(idp.IDToken("nonce", map[string]interface{}{"sub": "admin"}), ".")[1] + "." + parts[2]
+			_, err = p.Verify(ctx, tampered, "nonce")
+			So(err, ShouldEqual, ErrInvalidToken)
+
+			_, err = p.Verify(ctx, "eyJhbGciOiJub25lIn0.e30.", "nonce")
+			So(err, ShouldEqual, ErrUnsupportedAlg)
+		})
+
+		Convey("Issuer mismatch fails discovery", func() {
+			cfg.Issuer = idp.URL + "/"
+			_, err := New(ctx, cfg, nil)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,192 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var (
+	ErrUnknownHash = errors.New("unknown password hash format")
+	ErrInvalidHash = errors.New("invalid password hash")
+)
+
+// Argon2idParams are argon2id parameters, memory is in KiB.
+type Argon2idParams struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// DefaultArgon2idParams are parameters recommended by RFC 9106.
+var DefaultArgon2idParams = Argon2idParams{Time: 3, Memory: 64 * 1024, Threads: 4, SaltLen: 16, KeyLen: 32}
+
+const DefaultBcryptCost = 14
+
+type Config struct {
+	Algorithm  string // argon2id or bcrypt, argon2id is used by default
+	Argon2id   Argon2idParams
+	BcryptCost int
+	Policy     Policy
+}
+
+// Hasher hashes passwords to self-describing hashes and verifies them.
+type Hasher interface {
+	// Hash returns hash of password.
+	Hash(password string) (string, error)
+
+	// Verify checks password against hash of any supported algorithm.
+	Verify(password, hash string) (bool, error)
+
+	// NeedsRehash checks that hash is made by other algorithm or with other parameters.
+	NeedsRehash(hash string) bool
+}
+
+// NewHasher returns Hasher for cfg.Algorithm, zero parameters are replaced with defaults.
+func NewHasher(cfg Config) Hasher {
+	if cfg.Algorithm == AlgorithmBcrypt {
+		if cfg.BcryptCost == 0 {
+			cfg.BcryptCost = DefaultBcryptCost
+		}
+		return bcryptHasher{cost: cfg.BcryptCost}
+	}
+
+	p, d := cfg.Argon2id, DefaultArgon2idParams
+	if p.Time == 0 {
+		p.Time = d.Time
+	}
+	if p.Memory == 0 {
+		p.Memory = d.Memory
+	}
+	if p.Threads == 0 {
+		p.Threads = d.Threads
+	}
+	if p.SaltLen == 0 {
+		p.SaltLen = d.SaltLen
+	}
+	if p.KeyLen == 0 {
+		p.KeyLen = d.KeyLen
+	}
+
+	return argon2idHasher{params: p}
+}
+
+// verify checks password against hash of any supported algorithm.
+func verify(password, hash string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		h, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+		key := argon2.IDKey([]byte(password), h.salt, h.params.Time, h.params.Memory, h.params.Threads, h.params.KeyLen)
+		return subtle.ConstantTimeCompare(key, h.key) == 1, nil
+	case isBcrypt(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	default:
+		return false, ErrUnknownHash
+	}
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+func (b bcryptHasher) Hash(password string) (string, error) {
+	h, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	return string(h), err
+}
+
+func (b bcryptHasher) Verify(password, hash string) (bool, error) {
+	return verify(password, hash)
+}
+
+func (b bcryptHasher) NeedsRehash(hash string) bool {
+	if !isBcrypt(hash) {
+		return true
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != b.cost
+}
+
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+// argon2idHash is a decoded argon2id hash.
+type argon2idHash struct {
+	params    Argon2idParams
+	salt, key []byte
+}
+
+// Hash returns argon2id hash in PHC string format: $argon2id$v=19$m=65536,t=3,p=4$salt$key.
+func (a argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Time, a.params.Memory, a.params.Threads, a.params.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, a.params.Memory, a.params.Time, a.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (a argon2idHasher) Verify(password, hash string) (bool, error) {
+	return verify(password, hash)
+}
+
+func (a argon2idHasher) NeedsRehash(hash string) bool {
+	h, err := decodeArgon2id(hash)
+	return err != nil || h.params != a.params
+}
+
+// decodeArgon2id parses argon2id hash in PHC string format.
+func decodeArgon2id(hash string) (*argon2idHash, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrInvalidHash
+	}
+
+	h := &argon2idHash{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.params.Memory, &h.params.Time, &h.params.Threads); err != nil {
+		return nil, ErrInvalidHash
+	}
+
+	var err error
+	if h.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrInvalidHash
+	}
+	if h.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(h.key) == 0 {
+		return nil, ErrInvalidHash
+	}
+	h.params.SaltLen, h.params.KeyLen = uint32(len(h.salt)), uint32(len(h.key))
+
+	return h, nil
+}
@@ -0,0 +1,96 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testParams are cheap argon2id parameters for tests.
+var testParams = Argon2idParams{Time: 1, Memory: 1024, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+func TestHasher(t *testing.T) {
+	Convey("Test password hasher", t, func() {
+		h := NewHasher(Config{Argon2id: testParams})
+
+		Convey("Argon2id is default and hash is self-describing", func() {
+			hash, err := h.Hash("secret")
+			So(err, ShouldBeNil)
+			So(hash, ShouldStartWith, "$argon2id$v=19$m=1024,t=1,p=1$")
+			So(len(hash), ShouldBeLessThanOrEqualTo, 255)
+
+			ok, err := h.Verify("secret", hash)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			ok, err = h.Verify("wrong", hash)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+
+			So(h.NeedsRehash(hash), ShouldBeFalse)
+		})
+
+		Convey("Zero params are replaced with defaults", func() {
+			So(NewHasher(Config{}).(argon2idHasher).params, ShouldResemble, DefaultArgon2idParams)
+			So(NewHasher(Config{Algorithm: AlgorithmBcrypt}).(bcryptHasher).cost, ShouldEqual, DefaultBcryptCost)
+		})
+
+		Convey("Bcrypt hashes are verified and need rehash", func() {
+			b := NewHasher(Config{Algorithm: AlgorithmBcrypt, BcryptCost: 4})
+			hash, err := b.Hash("secret")
+			So(err, ShouldBeNil)
+			So(b.NeedsRehash(hash), ShouldBeFalse)
+
+			ok, err := h.Verify("secret", hash)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(h.NeedsRehash(hash), ShouldBeTrue)
+
+			// legacy $2y$ hash from seed.sql, password is 12345
+			ok, err = h.Verify("12345", "$2y$14$4IpqlaJ2Rvfgs.wb8f6lPODVLb/Ygl6zw1ZCUKz5CuT6WB6CV44AG")
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Outdated argon2id params need rehash", func() {
+			old := NewHasher(Config{Argon2id: Argon2idParams{Time: 1, Memory: 512, Threads: 1}})
+			hash, err := old.Hash("secret")
+			So(err, ShouldBeNil)
+			So(h.NeedsRehash(hash), ShouldBeTrue)
+
+			ok, err := h.Verify("secret", hash)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Unknown and broken hashes are rejected", func() {
+			_, err := h.Verify("secret", "plain")
+			So(err, ShouldEqual, ErrUnknownHash)
+
+			_, err = h.Verify("secret", "$argon2id$v=19$m=1024$salt$key")
+			So(err, ShouldEqual, ErrInvalidHash)
+			So(h.NeedsRehash("plain"), ShouldBeTrue)
+		})
+	})
+}
+
+func TestPolicy(t *testing.T) {
+	Convey("Test password policy", t, func() {
+		Convey("Default policy checks length and banned list", func() {
+			var p Policy
+			So(p.Check("Xk3#fa9!", "admin"), ShouldBeEmpty)
+			So(p.Check("short", ""), ShouldResemble, []string{RuleMinLength})
+			So(p.Check(strings.Repeat("a", 65), ""), ShouldResemble, []string{RuleMaxLength})
+			So(p.Check("Password", ""), ShouldResemble, []string{RuleBanned})
+			So(p.Check("longlogin", "LongLogin"), ShouldResemble, []string{RuleBanned})
+		})
+
+		Convey("Character classes are checked", func() {
+			p := Policy{MinLength: 4, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true, Banned: []string{}}
+			So(p.Check("abcd", ""), ShouldResemble, []string{RuleUpper, RuleDigit, RuleSymbol})
+			So(p.Check("Ab1!", ""), ShouldBeEmpty)
+			So(p.Check("password", ""), ShouldResemble, []string{RuleUpper, RuleDigit, RuleSymbol})
+		})
+	})
+}
@@ -0,0 +1,115 @@
+package password
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	DefaultMinLength = 8
+	DefaultMaxLength = 64
+)
+
+// policy rules
+const (
+	RuleMinLength = "min"
+	RuleMaxLength = "max"
+	RuleUpper     = "upper"
+	RuleLower     = "lower"
+	RuleDigit     = "digit"
+	RuleSymbol    = "symbol"
+	RuleBanned    = "banned"
+)
+
+// DefaultBanned is a list of the most common passwords.
+var DefaultBanned = []string{"password", "password1", "12345678", "123456789", "1234567890", "qwerty123", "qwertyuiop",
+	"11111111", "00000000", "iloveyou", "admin123", "letmein1", "welcome1"}
+
+// Policy is a password policy, zero lengths and nil banned list are replaced with defaults.
+type Policy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	Banned        []string // case-insensitive list of banned passwords
+}
+
+// Limits returns min and max password length.
+func (p Policy) Limits() (min, max int) {
+	min, max = p.MinLength, p.MaxLength
+	if min == 0 {
+		min = DefaultMinLength
+	}
+	if max == 0 {
+		max = DefaultMaxLength
+	}
+
+	return min, max
+}
+
+// Check returns broken rules for password, login is banned as password too.
+func (p Policy) Check(password, login string) []string {
+	var rules []string
+
+	min, max := p.Limits()
+	if n := len([]rune(password)); n < min {
+		rules = append(rules, RuleMinLength)
+	} else if n > max {
+		rules = append(rules, RuleMaxLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	for _, c := range []struct {
+		required, ok bool
+		rule         string
+	}{
+		{p.RequireUpper, upper, RuleUpper},
+		{p.RequireLower, lower, RuleLower},
+		{p.RequireDigit, digit, RuleDigit},
+		{p.RequireSymbol, symbol, RuleSymbol},
+	} {
+		if c.required && !c.ok {
+			rules = append(rules, c.rule)
+		}
+	}
+
+	if p.isBanned(password, login) {
+		rules = append(rules, RuleBanned)
+	}
+
+	return rules
+}
+
+func (p Policy) isBanned(password, login string) bool {
+	if login != "" && strings.EqualFold(password, login) {
+		return true
+	}
+
+	banned := p.Banned
+	if banned == nil {
+		banned = DefaultBanned
+	}
+
+	for _, b := range banned {
+		if strings.EqualFold(password, b) {
+			return true
+		}
+	}
+
+	return false
+}
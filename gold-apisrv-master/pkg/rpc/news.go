@@ -0,0 +1,238 @@
+package rpc
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	maxPageSize     = 100
+	maxTagCloudSize = 200
+	maxTagWeight    = 5
+)
+
+type NewsService struct {
+	zenrpc.Service
+	embedlog.Logger
+	newsRepo db.NewsRepo
+}
+
+func NewNewsService(dbo db.DB, logger embedlog.Logger) *NewsService {
+	return &NewsService{
+		Logger:   logger,
+		newsRepo: db.NewNewsRepo(dbo).WithPublishedOnly(),
+	}
+}
+
+// GetByAlias returns a published News by its alias.
+//
+//zenrpc:alias News alias
+//zenrpc:return News
+//zenrpc:404 Not Found
+//zenrpc:500 Internal Error
+func (s NewsService) GetByAlias(ctx context.Context, alias string) (*News, error) {
+	news, err := s.newsRepo.OneNews(ctx, &db.NewsSearch{Alias: &alias}, s.newsRepo.FullNews())
+	if err != nil {
+		return nil, internalError(err)
+	} else if news == nil {
+		return nil, ErrNotFound
+	}
+
+	var tags []db.Tag
+	if len(news.TagIDs) != 0 {
+		tags, err = s.newsRepo.TagsByFilters(ctx, &db.TagSearch{IDs: news.TagIDs}, db.PagerNoLimit, s.newsRepo.DefaultTagSort())
+		if err != nil {
+			return nil, internalError(err)
+		}
+	}
+
+	return NewNews(news, tags), nil
+}
+
+// Get returns a feed of published News ordered by publication date.
+//
+//zenrpc:page=1 Page number
+//zenrpc:pageSize=20 Items count per page, max - 100
+//zenrpc:return []NewsSummary
+//zenrpc:500 Internal Error
+func (s NewsService) Get(ctx context.Context, page, pageSize *int) ([]NewsSummary, error) {
+	return s.newsList(ctx, nil, page, pageSize)
+}
+
+// GetByCategory returns a feed of published News from the given Category.
+//
+//zenrpc:categoryId Category id
+//zenrpc:page=1 Page number
+//zenrpc:pageSize=20 Items count per page, max - 100
+//zenrpc:withSubcategories=false Include News from descendant Categories
+//zenrpc:return []NewsSummary
+//zenrpc:404 Not Found
+//zenrpc:500 Internal Error
+func (s NewsService) GetByCategory(ctx context.Context, categoryId int, page, pageSize *int, withSubcategories *bool) ([]NewsSummary, error) {
+	category, err := s.newsRepo.CategoryByID(ctx, categoryId)
+	if err != nil {
+		return nil, internalError(err)
+	} else if category == nil {
+		return nil, ErrNotFound
+	}
+
+	search := &db.NewsSearch{CategoryID: &categoryId}
+	if withSubcategories != nil && *withSubcategories {
+		search.CategoryID = nil
+		search.WithCategoryTree(categoryId)
+	}
+
+	return s.newsList(ctx, search, page, pageSize)
+}
+
+// GetByTag returns a feed of published News marked with the given Tag.
+//
+//zenrpc:tagId Tag id
+//zenrpc:page=1 Page number
+//zenrpc:pageSize=20 Items count per page, max - 100
+//zenrpc:return []NewsSummary
+//zenrpc:404 Not Found
+//zenrpc:500 Internal Error
+func (s NewsService) GetByTag(ctx context.Context, tagId int, page, pageSize *int) ([]NewsSummary, error) {
+	tag, err := s.newsRepo.TagByID(ctx, tagId)
+	if err != nil {
+		return nil, internalError(err)
+	} else if tag == nil {
+		return nil, ErrNotFound
+	}
+
+	return s.newsList(ctx, &db.NewsSearch{TagID: &tagId}, page, pageSize)
+}
+
+// Categories returns all enabled Categories, parentCategoryId can be used to build the tree.
+//
+//zenrpc:return []Category
+//zenrpc:500 Internal Error
+func (s NewsService) Categories(ctx context.Context) ([]Category, error) {
+	list, err := s.newsRepo.CategoriesByFilters(ctx, nil, db.PagerNoLimit, db.WithSort(db.SortField{Column: db.Columns.Category.OrderNumber, Direction: db.SortAsc}))
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	categories := make([]Category, 0, len(list))
+	for i := range list {
+		categories = append(categories, *NewCategory(&list[i]))
+	}
+
+	return categories, nil
+}
+
+// TagCloud returns the most used Tags of published News with weights.
+//
+//zenrpc:limit=50 Max tags count, max - 200
+//zenrpc:return []TagCloudItem
+//zenrpc:500 Internal Error
+func (s NewsService) TagCloud(ctx context.Context, limit *int) ([]TagCloudItem, error) {
+	counts, err := s.newsRepo.TagNewsCounts(ctx, nil)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	ids := make([]int, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+
+	var tags []db.Tag
+	if len(ids) != 0 {
+		tags, err = s.newsRepo.TagsByFilters(ctx, &db.TagSearch{IDs: ids}, db.PagerNoLimit)
+		if err != nil {
+			return nil, internalError(err)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if ci, cj := counts[tags[i].ID], counts[tags[j].ID]; ci != cj {
+			return ci > cj
+		}
+		return tags[i].Title < tags[j].Title
+	})
+
+	size := maxTagCloudSize
+	if limit != nil && *limit > 0 && *limit < size {
+		size = *limit
+	}
+	if len(tags) > size {
+		tags = tags[:size]
+	}
+
+	cloud := make([]TagCloudItem, 0, len(tags))
+	if len(tags) == 0 {
+		return cloud, nil
+	}
+
+	maxCount, minCount := counts[tags[0].ID], counts[tags[len(tags)-1].ID]
+	for i := range tags {
+		count := counts[tags[i].ID]
+		cloud = append(cloud, TagCloudItem{
+			Tag:    *NewTag(&tags[i]),
+			Count:  count,
+			Weight: tagWeight(count, minCount, maxCount),
+		})
+	}
+
+	return cloud, nil
+}
+
+// tagWeight returns logarithmic weight of count between minCount and maxCount from 1 to maxTagWeight.
+func tagWeight(count, minCount, maxCount int) int {
+	if maxCount <= minCount {
+		return 1
+	}
+
+	scale := (math.Log(float64(count)) - math.Log(float64(minCount))) / (math.Log(float64(maxCount)) - math.Log(float64(minCount)))
+
+	return 1 + int(math.Round(scale*(maxTagWeight-1)))
+}
+
+func (s NewsService) newsList(ctx context.Context, search *db.NewsSearch, page, pageSize *int) ([]NewsSummary, error) {
+	list, err := s.newsRepo.NewsByFilters(ctx, search, newPager(page, pageSize), s.publicSort(), s.newsRepo.FullNews())
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	newsList := make([]NewsSummary, 0, len(list))
+	for i := range list {
+		newsList = append(newsList, *NewNewsSummary(&list[i]))
+	}
+
+	return newsList, nil
+}
+
+// publicSort orders news by publication date, newest first.
+func (s NewsService) publicSort() db.OpFunc {
+	return db.WithSort(
+		db.SortField{Column: db.Columns.News.PublicationDate, Direction: db.SortDesc},
+		db.SortField{Column: db.Columns.News.ID, Direction: db.SortDesc},
+	)
+}
+
+// newPager returns db.Pager with page size limited by maxPageSize.
+func newPager(page, pageSize *int) db.Pager {
+	p := db.PagerDefault
+	if page != nil {
+		p.Page = *page
+	}
+	if pageSize != nil {
+		p.PageSize = *pageSize
+	}
+
+	if p.PageSize > maxPageSize {
+		p.PageSize = maxPageSize
+	} else if p.PageSize < 1 {
+		p.PageSize = 1
+	}
+
+	return p
+}
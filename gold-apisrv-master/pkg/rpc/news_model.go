@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"time"
+
+	"apisrv/pkg/db"
+)
+
+type Category struct {
+	ID               int           `json:"id"`
+	ParentCategoryID *int          `json:"parentCategoryId"`
+	Title            string        `json:"title"`
+	Cover            *VfsHashImage `json:"cover"`
+}
+
+type Tag struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Alias string `json:"alias"`
+}
+
+type TagCloudItem struct {
+	Tag
+	// count of published news with the tag
+	Count int `json:"count"`
+	// relative weight from 1 to 5
+	Weight int `json:"weight"`
+}
+
+type News struct {
+	ID              int       `json:"id"`
+	Title           string    `json:"title"`
+	Alias           string    `json:"alias"`
+	Content         *string   `json:"content"`
+	PublicationDate time.Time `json:"publicationDate"`
+
+	Category *Category      `json:"category"`
+	Tags     []Tag          `json:"tags"`
+	Cover    *VfsHashImage  `json:"cover"`
+	Gallery  []VfsHashImage `json:"gallery"`
+}
+
+type NewsSummary struct {
+	ID              int       `json:"id"`
+	Title           string    `json:"title"`
+	Alias           string    `json:"alias"`
+	PublicationDate time.Time `json:"publicationDate"`
+
+	Category *Category     `json:"category"`
+	Cover    *VfsHashImage `json:"cover"`
+}
+
+func NewCategory(in *db.Category) *Category {
+	if in == nil {
+		return nil
+	}
+
+	return &Category{
+		ID:               in.ID,
+		ParentCategoryID: in.ParentCategoryID,
+		Title:            in.Title,
+		Cover:            NewVfsHashImage(in.CoverImage),
+	}
+}
+
+func NewTag(in *db.Tag) *Tag {
+	if in == nil {
+		return nil
+	}
+
+	return &Tag{
+		ID:    in.ID,
+		Title: in.Title,
+		Alias: in.Alias,
+	}
+}
+
+func NewNews(in *db.News, tags []db.Tag) *News {
+	if in == nil {
+		return nil
+	}
+
+	news := &News{
+		ID:              in.ID,
+		Title:           in.Title,
+		Alias:           in.Alias,
+		Content:         in.Content,
+		PublicationDate: in.PublicationDate,
+
+		Category: NewCategory(in.Category),
+		Tags:     make([]Tag, 0, len(tags)),
+		Cover:    NewVfsHashImage(in.CoverImage),
+		Gallery:  NewVfsHashImages(in.GalleryImages),
+	}
+
+	for i := range tags {
+		news.Tags = append(news.Tags, *NewTag(&tags[i]))
+	}
+
+	return news
+}
+
+func NewNewsSummary(in *db.News) *NewsSummary {
+	if in == nil {
+		return nil
+	}
+
+	return &NewsSummary{
+		ID:              in.ID,
+		Title:           in.Title,
+		Alias:           in.Alias,
+		PublicationDate: in.PublicationDate,
+
+		Category: NewCategory(in.Category),
+		Cover:    NewVfsHashImage(in.CoverImage),
+	}
+}
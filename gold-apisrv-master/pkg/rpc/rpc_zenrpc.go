@@ -0,0 +1,789 @@
+// Code generated by zenrpc v2.2.9; DO NOT EDIT.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vmkteam/zenrpc/v2"
+	"github.com/vmkteam/zenrpc/v2/smd"
+)
+
+var RPC = struct {
+	NewsService struct{ GetByAlias, Get, GetByCategory, GetByTag, Categories, TagCloud string }
+}{
+	NewsService: struct{ GetByAlias, Get, GetByCategory, GetByTag, Categories, TagCloud string }{
+		GetByAlias:    "getbyalias",
+		Get:           "get",
+		GetByCategory: "getbycategory",
+		GetByTag:      "getbytag",
+		Categories:    "categories",
+		TagCloud:      "tagcloud",
+	},
+}
+
+func (NewsService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"GetByAlias": {
+				Description: `GetByAlias returns a published News by its alias.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "alias",
+						Description: `News alias`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `News`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "News",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name:     "content",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "publicationDate",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+						{
+							Name:     "category",
+							Optional: true,
+							Ref:      "#/definitions/Category",
+							Type:     smd.Object,
+						},
+						{
+							Name: "tags",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/Tag",
+							},
+						},
+						{
+							Name:     "cover",
+							Optional: true,
+							Ref:      "#/definitions/VfsHashImage",
+							Type:     smd.Object,
+						},
+						{
+							Name: "gallery",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/VfsHashImage",
+							},
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"Category": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+						"Tag": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					404: "Not Found",
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get returns a feed of published News ordered by publication date.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "page",
+						Optional:    true,
+						Description: `Page number`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "pageSize",
+						Optional:    true,
+						Description: `Items count per page, max - 100`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "publicationDate",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name:     "category",
+									Optional: true,
+									Ref:      "#/definitions/Category",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"Category": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"GetByCategory": {
+				Description: `GetByCategory returns a feed of published News from the given Category.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "categoryId",
+						Description: `Category id`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "page",
+						Optional:    true,
+						Description: `Page number`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "pageSize",
+						Optional:    true,
+						Description: `Items count per page, max - 100`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "withSubcategories",
+						Optional:    true,
+						Description: `Include News from descendant Categories`,
+						Type:        smd.Boolean,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "publicationDate",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name:     "category",
+									Optional: true,
+									Ref:      "#/definitions/Category",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"Category": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					404: "Not Found",
+					500: "Internal Error",
+				},
+			},
+			"GetByTag": {
+				Description: `GetByTag returns a feed of published News marked with the given Tag.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "tagId",
+						Description: `Tag id`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "page",
+						Optional:    true,
+						Description: `Page number`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "pageSize",
+						Optional:    true,
+						Description: `Items count per page, max - 100`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "publicationDate",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name:     "category",
+									Optional: true,
+									Ref:      "#/definitions/Category",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"Category": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					404: "Not Found",
+					500: "Internal Error",
+				},
+			},
+			"Categories": {
+				Description: `Categories returns all enabled Categories, parentCategoryId can be used to build the tree.`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `[]Category`,
+					Type:        smd.Array,
+					TypeName:    "[]Category",
+					Items: map[string]string{
+						"$ref": "#/definitions/Category",
+					},
+					Definitions: map[string]smd.Definition{
+						"Category": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"TagCloud": {
+				Description: `TagCloud returns the most used Tags of published News with weights.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "limit",
+						Optional:    true,
+						Description: `Max tags count, max - 200`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]TagCloudItem`,
+					Type:        smd.Array,
+					TypeName:    "[]TagCloudItem",
+					Items: map[string]string{
+						"$ref": "#/definitions/TagCloudItem",
+					},
+					Definitions: map[string]smd.Definition{
+						"TagCloudItem": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:        "count",
+									Description: `count of published news with the tag`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "weight",
+									Description: `relative weight from 1 to 5`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s NewsService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.NewsService.GetByAlias:
+		var args = struct {
+			Alias string `json:"alias"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"alias"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByAlias(ctx, args.Alias))
+
+	case RPC.NewsService.Get:
+		var args = struct {
+			Page     *int `json:"page"`
+			PageSize *int `json:"pageSize"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"page", "pageSize"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		//zenrpc:page=1 Page number
+		if args.Page == nil {
+			var v int = 1
+			args.Page = &v
+		}
+
+		//zenrpc:pageSize=20 Items count per page, max - 100
+		if args.PageSize == nil {
+			var v int = 20
+			args.PageSize = &v
+		}
+
+		resp.Set(s.Get(ctx, args.Page, args.PageSize))
+
+	case RPC.NewsService.GetByCategory:
+		var args = struct {
+			CategoryId        int   `json:"categoryId"`
+			Page              *int  `json:"page"`
+			PageSize          *int  `json:"pageSize"`
+			WithSubcategories *bool `json:"withSubcategories"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"categoryId", "page", "pageSize", "withSubcategories"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		//zenrpc:page=1 Page number
+		if args.Page == nil {
+			var v int = 1
+			args.Page = &v
+		}
+
+		//zenrpc:pageSize=20 Items count per page, max - 100
+		if args.PageSize == nil {
+			var v int = 20
+			args.PageSize = &v
+		}
+
+		//zenrpc:withSubcategories=false Include News from descendant Categories
+		if args.WithSubcategories == nil {
+			var v bool = false
+			args.WithSubcategories = &v
+		}
+
+		resp.Set(s.GetByCategory(ctx, args.CategoryId, args.Page, args.PageSize, args.WithSubcategories))
+
+	case RPC.NewsService.GetByTag:
+		var args = struct {
+			TagId    int  `json:"tagId"`
+			Page     *int `json:"page"`
+			PageSize *int `json:"pageSize"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tagId", "page", "pageSize"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		//zenrpc:page=1 Page number
+		if args.Page == nil {
+			var v int = 1
+			args.Page = &v
+		}
+
+		//zenrpc:pageSize=20 Items count per page, max - 100
+		if args.PageSize == nil {
+			var v int = 20
+			args.PageSize = &v
+		}
+
+		resp.Set(s.GetByTag(ctx, args.TagId, args.Page, args.PageSize))
+
+	case RPC.NewsService.Categories:
+		resp.Set(s.Categories(ctx))
+
+	case RPC.NewsService.TagCloud:
+		var args = struct {
+			Limit *int `json:"limit"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"limit"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		//zenrpc:limit=50 Max tags count, max - 200
+		if args.Limit == nil {
+			var v int = 50
+			args.Limit = &v
+		}
+
+		resp.Set(s.TagCloud(ctx, args.Limit))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
@@ -3,8 +3,6 @@ package rpc
 import (
 	"net/http"
 
-	"apisrv/pkg/vt"
-
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
 
@@ -12,9 +10,14 @@ import (
 	"github.com/vmkteam/zenrpc/v2"
 )
 
+const (
+	NSNews = "news"
+)
+
 var (
 	ErrNotImplemented = zenrpc.NewStringError(http.StatusInternalServerError, "Not implemented")
 	ErrInternal       = zenrpc.NewStringError(http.StatusInternalServerError, "Internal error")
+	ErrNotFound       = zenrpc.NewStringError(http.StatusNotFound, "Not found")
 )
 
 var allowDebugFn = func() zm.AllowDebugFunc {
@@ -51,17 +54,12 @@ func New(dbo db.DB, logger embedlog.Logger, isDevel bool) zenrpc.Server {
 
 	// services
 	rpc.RegisterAll(map[string]zenrpc.Invoker{
-		"auth":     vt.NewAuthService(dbo, logger),
-		"users":    vt.NewUserService(dbo, logger),
-		"news":     vt.NewNewsService(dbo, logger),
-		"category": vt.NewCategoryService(dbo, logger),
-		"tags":     vt.NewTagService(dbo, logger),
+		NSNews: NewNewsService(dbo, logger),
 	})
 
 	return rpc
 }
 
-//nolint:unused
 func internalError(err error) *zenrpc.Error {
 	return zenrpc.NewError(http.StatusInternalServerError, err)
 }
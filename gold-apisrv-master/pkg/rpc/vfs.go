@@ -0,0 +1,35 @@
+package rpc
+
+import "apisrv/pkg/media"
+
+type VfsHashImage struct {
+	Hash    string `json:"hash"`
+	WebPath string `json:"webPath"`
+	// web paths by preset size
+	Sizes map[string]string `json:"sizes"`
+}
+
+// NewVfsHashImage converts vfs hash to VfsHashImage.
+func NewVfsHashImage(in *string) *VfsHashImage {
+	if in == nil || *in == "" {
+		return nil
+	}
+
+	return &VfsHashImage{
+		Hash:    *in,
+		WebPath: media.ImagePath(*in, media.DefaultImage),
+		Sizes:   media.ImageSizePaths(*in),
+	}
+}
+
+// NewVfsHashImages converts vfs hashes to []VfsHashImage, empty hashes are skipped.
+func NewVfsHashImages(in []string) []VfsHashImage {
+	out := make([]VfsHashImage, 0, len(in))
+	for i := range in {
+		if img := NewVfsHashImage(&in[i]); img != nil {
+			out = append(out, *img)
+		}
+	}
+
+	return out
+}
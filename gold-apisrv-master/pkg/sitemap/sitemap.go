@@ -0,0 +1,134 @@
+// Package sitemap renders sitemap index, url set and Google News sitemap documents.
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const (
+	ContentType = "application/xml; charset=utf-8"
+
+	// MaxURLs is a maximum number of urls in one sitemap by sitemaps.org protocol.
+	MaxURLs = 50000
+	// MaxNewsURLs is a maximum number of urls in one Google News sitemap.
+	MaxNewsURLs = 1000
+
+	sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	newsNS    = "http://www.google.com/schemas/sitemap-news/0.9"
+	dateFmt   = time.RFC3339
+)
+
+// Sitemap is a link to a sitemap in the index.
+type Sitemap struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// URL is a page in the sitemap.
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// NewsURL is a news page in the Google News sitemap.
+type NewsURL struct {
+	Loc             string
+	Title           string
+	PublicationDate time.Time
+}
+
+// Publication describes news publisher for Google News sitemap.
+type Publication struct {
+	Name     string
+	Language string
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	NS       string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type urlSet struct {
+	XMLName xml.Name  `xml:"urlset"`
+	NS      string    `xml:"xmlns,attr"`
+	NewsNS  string    `xml:"xmlns:news,attr,omitempty"`
+	URLs    []urlItem `xml:"url"`
+}
+
+type urlItem struct {
+	Loc     string    `xml:"loc"`
+	LastMod string    `xml:"lastmod,omitempty"`
+	News    *newsItem `xml:"news:news,omitempty"`
+}
+
+type newsItem struct {
+	Publication     newsPublication `xml:"news:publication"`
+	PublicationDate string          `xml:"news:publication_date"`
+	Title           string          `xml:"news:title"`
+}
+
+type newsPublication struct {
+	Name     string `xml:"news:name"`
+	Language string `xml:"news:language"`
+}
+
+// Index renders sitemap index document.
+func Index(sitemaps []Sitemap) ([]byte, error) {
+	doc := sitemapIndex{NS: sitemapNS, Sitemaps: make([]sitemapLoc, 0, len(sitemaps))}
+	for _, s := range sitemaps {
+		doc.Sitemaps = append(doc.Sitemaps, sitemapLoc{Loc: s.Loc, LastMod: formatDate(s.LastMod)})
+	}
+
+	return marshal(doc)
+}
+
+// URLSet renders sitemap document.
+func URLSet(urls []URL) ([]byte, error) {
+	doc := urlSet{NS: sitemapNS, URLs: make([]urlItem, 0, len(urls))}
+	for _, u := range urls {
+		doc.URLs = append(doc.URLs, urlItem{Loc: u.Loc, LastMod: formatDate(u.LastMod)})
+	}
+
+	return marshal(doc)
+}
+
+// News renders Google News sitemap document.
+func News(pub Publication, urls []NewsURL) ([]byte, error) {
+	doc := urlSet{NS: sitemapNS, NewsNS: newsNS, URLs: make([]urlItem, 0, len(urls))}
+	for _, u := range urls {
+		doc.URLs = append(doc.URLs, urlItem{
+			Loc: u.Loc,
+			News: &newsItem{
+				Publication:     newsPublication{Name: pub.Name, Language: pub.Language},
+				PublicationDate: formatDate(u.PublicationDate),
+				Title:           u.Title,
+			},
+		})
+	}
+
+	return marshal(doc)
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(dateFmt)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
@@ -0,0 +1,38 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSitemap(t *testing.T) {
+	Convey("Test sitemap rendering", t, func() {
+		date := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		Convey("Index contains sitemap links", func() {
+			b, err := Index([]Sitemap{{Loc: "https://example.com/sitemap/news/1.xml", LastMod: date}, {Loc: "https://example.com/sitemap/tags/1.xml"}})
+			So(err, ShouldBeNil)
+			So(xml.Unmarshal(b, new(interface{})), ShouldBeNil)
+			So(string(b), ShouldContainSubstring, `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+			So(string(b), ShouldContainSubstring, "<sitemap><loc>https://example.com/sitemap/news/1.xml</loc><lastmod>2023-05-01T10:00:00Z</lastmod></sitemap>")
+			So(string(b), ShouldContainSubstring, "<sitemap><loc>https://example.com/sitemap/tags/1.xml</loc></sitemap>")
+		})
+
+		Convey("URL set escapes locations", func() {
+			b, err := URLSet([]URL{{Loc: "https://example.com/news/a?b=1&c=2"}})
+			So(err, ShouldBeNil)
+			So(string(b), ShouldContainSubstring, "<url><loc>https://example.com/news/a?b=1&amp;c=2</loc></url>")
+		})
+
+		Convey("Google News sitemap has news namespace and publication", func() {
+			b, err := News(Publication{Name: "Example", Language: "ru"}, []NewsURL{{Loc: "https://example.com/news/a", Title: "A", PublicationDate: date}})
+			So(err, ShouldBeNil)
+			So(string(b), ShouldContainSubstring, `xmlns:news="http://www.google.com/schemas/sitemap-news/0.9"`)
+			So(string(b), ShouldContainSubstring, "<news:publication><news:name>Example</news:name><news:language>ru</news:language></news:publication>")
+			So(string(b), ShouldContainSubstring, "<news:publication_date>2023-05-01T10:00:00Z</news:publication_date><news:title>A</news:title>")
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTOTP(t *testing.T) {
+	Convey("Test TOTP", t, func() {
+		// RFC 6238 Appendix B test vectors for SHA1
+		key := []byte("12345678901234567890")
+		secret := encoding.EncodeToString(key)
+
+		Convey("RFC 6238 vectors", func() {
+			vectors := map[int64]string{
+				59:          "94287082",
+				1111111109:  "07081804",
+				1111111111:  "14050471",
+				1234567890:  "89005924",
+				2000000000:  "69279037",
+				20000000000: "65353130",
+			}
+			for ts, code := range vectors {
+				So(hotp(key, counter(time.Unix(ts, 0)), 8), ShouldEqual, code)
+			}
+		})
+
+		Convey("Validate accepts adjacent periods only", func() {
+			now := time.Unix(1234567890, 0)
+			code, err := Code(secret, now)
+			So(err, ShouldBeNil)
+			So(code, ShouldHaveLength, Digits)
+
+			So(Validate(secret, code, now), ShouldBeTrue)
+			So(Validate(secret, code, now.Add(Period)), ShouldBeTrue)
+			So(Validate(secret, code, now.Add(-Period)), ShouldBeTrue)
+			So(Validate(secret, code, now.Add(3*Period)), ShouldBeFalse)
+			So(Validate(secret, "12345", now), ShouldBeFalse)
+			So(Validate("!", code, now), ShouldBeFalse)
+
+			step, ok := ValidateStep(secret, code, now.Add(Period))
+			So(ok, ShouldBeTrue)
+			So(step, ShouldEqual, now.Unix()/int64(Period.Seconds()))
+		})
+
+		Convey("New secret and provisioning uri", func() {
+			s, err := NewSecret()
+			So(err, ShouldBeNil)
+			So(s, ShouldHaveLength, 32)
+
+			uri := ProvisioningURI("apisrv", "admin", s)
+			So(uri, ShouldStartWith, "otpauth://totp/apisrv:admin?")
+			So(uri, ShouldContainSubstring, "secret="+s)
+			So(strings.Contains(uri, "issuer=apisrv"), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,262 @@
+package vt
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	// APIKeyHeader is a header with api key of machine clients.
+	APIKeyHeader = "X-Api-Key"
+
+	apiKeyPrefix = "ak_"
+
+	// apiKeyPrefixLen is a length of key prefix stored in DB to identify key in lists.
+	apiKeyPrefixLen = len(apiKeyPrefix) + 8
+)
+
+// apiKeyNamespaces are namespaces which could be used in api key scopes.
+var apiKeyNamespaces = []string{anyPermission, NSAuth, NSUser, NSNews, NSTag, NSCategory, NSRole, NSVFS, NSAudit}
+
+// newAPIKey returns new random api key.
+func newAPIKey() (string, error) {
+	token, err := newSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	return apiKeyPrefix + token, nil
+}
+
+// apiKeyByToken returns active api key with enabled user by raw key or nil.
+func apiKeyByToken(ctx context.Context, commonRepo *db.CommonRepo, token string) (*db.APIKey, error) {
+	apiKey, err := commonRepo.ActiveAPIKeyByHash(ctx, hashSessionToken(token))
+	if err != nil || apiKey == nil {
+		return nil, err
+	} else if apiKey.User == nil || apiKey.User.StatusID != db.StatusEnabled {
+		return nil, nil
+	}
+
+	return apiKey, nil
+}
+
+// touchAPIKey updates api key last usage not often than sessionActivityInterval.
+func touchAPIKey(ctx context.Context, commonRepo *db.CommonRepo, apiKey *db.APIKey) error {
+	if apiKey.LastUsedAt != nil && time.Since(*apiKey.LastUsedAt) <= sessionActivityInterval {
+		return nil
+	}
+
+	_, err := commonRepo.TouchAPIKey(ctx, apiKey)
+	return err
+}
+
+// apiKeyAllows checks that api key scopes allow method of namespace ns.
+// Api keys can't manage api keys and use auth methods except auth.profile,
+// read-only keys can't call mutating methods.
+func apiKeyAllows(apiKey *db.APIKey, ns, method string) bool {
+	switch {
+	case ns == NSAPIKey, ns == NSAuth && method != RPC.AuthService.Profile:
+		return false
+	case apiKey.IsReadOnly && (auditedMethods.Has(ns, method) || ns == NSVFS && method == VfsUploadMethod):
+		return false
+	}
+
+	return Permissions(apiKey.Scopes).Has(ns, method)
+}
+
+// APIKeyFromContext returns api key of current request.
+func APIKeyFromContext(ctx context.Context) *db.APIKey {
+	if apiKey, ok := ctx.Value(apiKeyKey).(*db.APIKey); ok {
+		return apiKey
+	}
+	return nil
+}
+
+type APIKeyService struct {
+	zenrpc.Service
+	embedlog.Logger
+	commonRepo db.CommonRepo
+}
+
+func NewAPIKeyService(dbo db.DB, logger embedlog.Logger) *APIKeyService {
+	return &APIKeyService{
+		Logger:     logger,
+		commonRepo: db.NewCommonRepo(dbo),
+	}
+}
+
+func (s APIKeyService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.commonRepo.DefaultAPIKeySort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.APIKey.ID, db.Columns.APIKey.Title, db.Columns.APIKey.CreatedAt, db.Columns.APIKey.ExpiresAt, db.Columns.APIKey.LastUsedAt:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count APIKeys according to conditions in search params
+//
+//zenrpc:search APIKeySearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s APIKeyService) Count(ctx context.Context, search *APIKeySearch) (int, error) {
+	count, err := s.commonRepo.CountAPIKeys(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get а list of APIKeys according to conditions in search params
+//
+//zenrpc:search APIKeySearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []APIKey
+//zenrpc:500 Internal Error
+func (s APIKeyService) Get(ctx context.Context, search *APIKeySearch, viewOps *ViewOps) ([]APIKey, error) {
+	list, err := s.commonRepo.APIKeysByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.commonRepo.FullAPIKey())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	apiKeys := make([]APIKey, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if apiKey := NewAPIKey(&list[i]); apiKey != nil {
+			apiKeys = append(apiKeys, *apiKey)
+		}
+	}
+	return apiKeys, nil
+}
+
+// GetByID returns an APIKey by its ID.
+//
+//zenrpc:id int
+//zenrpc:return APIKey
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s APIKeyService) GetByID(ctx context.Context, id int) (*APIKey, error) {
+	apiKey, err := s.commonRepo.APIKeyByID(ctx, id, s.commonRepo.FullAPIKey())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if apiKey == nil {
+		return nil, ErrNotFound
+	}
+	return NewAPIKey(apiKey), nil
+}
+
+// Add creates an APIKey for the user, current user is used by default.
+// Raw key is returned only once in key field.
+//
+//zenrpc:apiKey APIKey
+//zenrpc:return APIKey
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s APIKeyService) Add(ctx context.Context, apiKey APIKey) (*APIKey, error) {
+	if apiKey.UserID == 0 {
+		if user := UserFromContext(ctx); user != nil {
+			apiKey.UserID = user.ID
+		}
+	}
+
+	if ve := s.isValid(ctx, &apiKey); ve.HasErrors() {
+		return nil, ve.Error()
+	}
+
+	key, err := newAPIKey()
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	in := apiKey.ToDB()
+	in.KeyPrefix = key[:apiKeyPrefixLen]
+	in.KeyHash = hashSessionToken(key)
+	in.StatusID = db.StatusEnabled
+
+	dbk, err := s.commonRepo.AddAPIKey(ctx, in)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	result := NewAPIKey(dbk)
+	result.Key = &key
+	return result, nil
+}
+
+// Revoke revokes the APIKey by its ID.
+//
+//zenrpc:id int
+//zenrpc:return isRevoked
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s APIKeyService) Revoke(ctx context.Context, id int) (bool, error) {
+	apiKey, err := s.commonRepo.APIKeyByID(ctx, id)
+	if err != nil {
+		return false, InternalError(err)
+	} else if apiKey == nil {
+		return false, ErrNotFound
+	}
+
+	ok, err := s.commonRepo.DeleteAPIKey(ctx, apiKey.ID)
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, nil
+}
+
+// isValid checks api key, scopes are normalized to lowercase method names.
+func (s APIKeyService) isValid(ctx context.Context, apiKey *APIKey) Validator {
+	var v Validator
+
+	if v.CheckBasic(ctx, *apiKey); v.HasInternalError() {
+		return v
+	}
+
+	// check user
+	user, err := s.commonRepo.UserByID(ctx, apiKey.UserID)
+	if err != nil {
+		v.SetInternalError(err)
+		return v
+	} else if user == nil || user.StatusID != db.StatusEnabled {
+		v.Append("userId", FieldErrorIncorrect)
+	}
+
+	// check scopes
+	scopes := Permissions{}
+	for ns, methods := range apiKey.Scopes {
+		if !isAPIKeyNamespace(ns) || len(methods) == 0 {
+			v.Append("scopes", FieldErrorIncorrect)
+			break
+		}
+		for _, m := range methods {
+			scopes[ns] = append(scopes[ns], strings.ToLower(m))
+		}
+	}
+	apiKey.Scopes = scopes
+
+	// check expiration
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(time.Now()) {
+		v.Append("expiresAt", FieldErrorIncorrect)
+	}
+
+	return v
+}
+
+func isAPIKeyNamespace(ns string) bool {
+	for _, n := range apiKeyNamespaces {
+		if n == ns {
+			return true
+		}
+	}
+
+	return false
+}
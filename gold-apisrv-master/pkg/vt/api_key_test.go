@@ -0,0 +1,50 @@
+package vt
+
+import (
+	"strings"
+	"testing"
+
+	"apisrv/pkg/db"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAPIKey(t *testing.T) {
+	Convey("Test api key scopes", t, func() {
+		Convey("New key has prefix", func() {
+			key, err := newAPIKey()
+			So(err, ShouldBeNil)
+			So(strings.HasPrefix(key, apiKeyPrefix), ShouldBeTrue)
+			So(key, ShouldHaveLength, len(apiKeyPrefix)+sessionTokenBytes*2)
+		})
+
+		Convey("Scopes restrict namespaces and methods", func() {
+			apiKey := &db.APIKey{Scopes: map[string][]string{NSNews: {anyPermission}, NSTag: {RPC.TagService.Get}}}
+			So(apiKeyAllows(apiKey, NSNews, RPC.NewsService.Add), ShouldBeTrue)
+			So(apiKeyAllows(apiKey, NSTag, RPC.TagService.Get), ShouldBeTrue)
+			So(apiKeyAllows(apiKey, NSTag, RPC.TagService.Delete), ShouldBeFalse)
+			So(apiKeyAllows(apiKey, NSUser, RPC.UserService.Get), ShouldBeFalse)
+		})
+
+		Convey("Read-only keys can't call mutating methods", func() {
+			apiKey := &db.APIKey{Scopes: map[string][]string{anyPermission: {anyPermission}}, IsReadOnly: true}
+			So(apiKeyAllows(apiKey, NSNews, RPC.NewsService.Get), ShouldBeTrue)
+			So(apiKeyAllows(apiKey, NSNews, RPC.NewsService.Update), ShouldBeFalse)
+			So(apiKeyAllows(apiKey, NSVFS, VfsUploadMethod), ShouldBeFalse)
+		})
+
+		Convey("Keys can't manage keys and sessions", func() {
+			apiKey := &db.APIKey{Scopes: map[string][]string{anyPermission: {anyPermission}}}
+			So(apiKeyAllows(apiKey, NSAPIKey, RPC.APIKeyService.Add), ShouldBeFalse)
+			So(apiKeyAllows(apiKey, NSAuth, RPC.AuthService.ChangePassword), ShouldBeFalse)
+			So(apiKeyAllows(apiKey, NSAuth, RPC.AuthService.Profile), ShouldBeTrue)
+		})
+
+		Convey("Only known namespaces are allowed in scopes", func() {
+			So(isAPIKeyNamespace(NSNews), ShouldBeTrue)
+			So(isAPIKeyNamespace(anyPermission), ShouldBeTrue)
+			So(isAPIKeyNamespace(NSAPIKey), ShouldBeFalse)
+			So(isAPIKeyNamespace("unknown"), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,248 @@
+package vt
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+
+	zm "github.com/vmkteam/zenrpc-middleware"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	// redactedValue replaces values of sensitive params in audit log.
+	redactedValue = "***"
+
+	auditRequestIDLen = 64
+)
+
+var (
+	// crudWriteMethods are common mutating methods of CRUD services.
+	crudWriteMethods = []string{"add", "update", "delete", "updatestatus"}
+
+	// auditedMethods is a list of mutating methods which are stored in audit log.
+	auditedMethods = Permissions{
+		NSAuth: {RPC.AuthService.Login, RPC.AuthService.LoginSecondFactor, RPC.AuthService.Logout, RPC.AuthService.ChangePassword,
+			RPC.AuthService.RevokeSession, RPC.AuthService.TotpEnroll, RPC.AuthService.TotpEnable, RPC.AuthService.TotpDisable,
+			RPC.AuthService.TotpRecoveryCodes, RPC.AuthService.RequestPasswordReset, RPC.AuthService.ResetPassword,
+			RPC.AuthService.AcceptInvite},
+		NSUser:     append([]string{RPC.UserService.Invite}, crudWriteMethods...),
+		NSNews:     append([]string{RPC.NewsService.RestoreRevision}, crudWriteMethods...),
+		NSCategory: append([]string{RPC.CategoryService.Move, RPC.CategoryService.Reorder}, crudWriteMethods...),
+		NSTag:      crudWriteMethods,
+		NSRole:     {RPC.RoleService.Assign},
+		NSAPIKey:   {RPC.APIKeyService.Add, RPC.APIKeyService.Revoke},
+		NSVFS: {"movefiles", "deletefiles", "setfilephysicalname", "managefavorites", "createfolder", "deletefolder",
+			"movefolder", "renamefolder", "deletehash", RPC.VfsService.SetFolderPrivate},
+	}
+
+	// sensitiveParams are param names which values are never stored in audit log.
+	sensitiveParams = []string{"password", "token", "secret", "code"}
+)
+
+// auditMiddleware stores mutating calls to audit log.
+// It must be used after authMiddleware, zm.WithHeaders and zm.WithNoCancelContext.
+func auditMiddleware(commonRepo *db.CommonRepo, logger embedlog.Logger) zenrpc.MiddlewareFunc {
+	return func(h zenrpc.InvokeFunc) zenrpc.InvokeFunc {
+		return func(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+			ns := zenrpc.NamespaceFromContext(ctx)
+			if !auditedMethods.Has(ns, method) {
+				return h(ctx, method, params)
+			}
+
+			resp := h(ctx, method, params)
+
+			al := newAuditLog(ctx, ns, method, params, resp)
+			if _, err := commonRepo.AddAuditLog(ctx, al); err != nil {
+				logger.Errorf("add audit log ns=%s method=%s error=%s", ns, method, err)
+			}
+
+			return resp
+		}
+	}
+}
+
+// newAuditLog returns audit log record for the call.
+func newAuditLog(ctx context.Context, ns, method string, params json.RawMessage, resp zenrpc.Response) *db.AuditLog {
+	al := &db.AuditLog{
+		Namespace: ns,
+		Method:    method,
+		Params:    redactParams(params, ns == NSAuth),
+		IP:        cutStringPtr(zm.IPFromContext(ctx), sessionIPLen),
+		RequestID: cutStringPtr(zm.XRequestIDFromContext(ctx), auditRequestIDLen),
+	}
+
+	if user := UserFromContext(ctx); user != nil {
+		al.UserID = &user.ID
+	}
+
+	if resp.Error != nil {
+		al.ResultCode = resp.Error.Code
+		al.ObjectID = auditObjectID(params, nil)
+	} else {
+		al.ObjectID = auditObjectID(params, resp.Result)
+	}
+
+	return al
+}
+
+// redactParams returns params as map with sensitive values replaced by redactedValue.
+// Positional params are stored under "params" key, their names are unknown so with redactScalars
+// all top-level scalar values are redacted.
+func redactParams(params json.RawMessage, redactScalars bool) map[string]interface{} {
+	var v interface{}
+	if len(params) == 0 || json.Unmarshal(params, &v) != nil {
+		return nil
+	}
+
+	switch p := redactValue(v).(type) {
+	case map[string]interface{}:
+		return p
+	case []interface{}:
+		for i := range p {
+			switch p[i].(type) {
+			case map[string]interface{}, []interface{}:
+			default:
+				if redactScalars {
+					p[i] = redactedValue
+				}
+			}
+		}
+		return map[string]interface{}{"params": p}
+	default:
+		return nil
+	}
+}
+
+// redactValue recursively replaces values of sensitive keys in objects.
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if isSensitiveParam(k) {
+				val[k] = redactedValue
+			} else {
+				val[k] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i := range val {
+			val[i] = redactValue(val[i])
+		}
+	}
+
+	return v
+}
+
+// isSensitiveParam checks that param name contains one of sensitiveParams.
+func isSensitiveParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveParams {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// auditObjectID returns object id from "id" param, "id" field of object param or "id" field of result.
+func auditObjectID(params json.RawMessage, result *json.RawMessage) *int {
+	var named map[string]json.RawMessage
+	if json.Unmarshal(params, &named) == nil {
+		if id := jsonID(named["id"]); id != nil {
+			return id
+		}
+
+		for _, v := range named {
+			var obj map[string]json.RawMessage
+			if json.Unmarshal(v, &obj) == nil {
+				if id := jsonID(obj["id"]); id != nil {
+					return id
+				}
+			}
+		}
+	}
+
+	if result != nil {
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(*result, &obj) == nil {
+			return jsonID(obj["id"])
+		}
+	}
+
+	return nil
+}
+
+// jsonID returns positive int from json value or nil.
+func jsonID(v json.RawMessage) *int {
+	var id int
+	if len(v) == 0 || json.Unmarshal(v, &id) != nil || id <= 0 {
+		return nil
+	}
+
+	return &id
+}
+
+type AuditService struct {
+	zenrpc.Service
+	embedlog.Logger
+	commonRepo db.CommonRepo
+}
+
+func NewAuditService(dbo db.DB, logger embedlog.Logger) *AuditService {
+	return &AuditService{
+		Logger:     logger,
+		commonRepo: db.NewCommonRepo(dbo),
+	}
+}
+
+func (s AuditService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.commonRepo.DefaultAuditLogSort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.AuditLog.ID, db.Columns.AuditLog.CreatedAt, db.Columns.AuditLog.UserID, db.Columns.AuditLog.Namespace:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count AuditLogs according to conditions in search params
+//
+//zenrpc:search AuditLogSearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s AuditService) Count(ctx context.Context, search *AuditLogSearch) (int, error) {
+	count, err := s.commonRepo.CountAuditLogs(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get а list of AuditLogs according to conditions in search params
+//
+//zenrpc:search AuditLogSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []AuditLog
+//zenrpc:500 Internal Error
+func (s AuditService) Get(ctx context.Context, search *AuditLogSearch, viewOps *ViewOps) ([]AuditLog, error) {
+	list, err := s.commonRepo.AuditLogsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.commonRepo.FullAuditLog())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	logs := make([]AuditLog, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if al := NewAuditLog(&list[i]); al != nil {
+			logs = append(logs, *al)
+		}
+	}
+	return logs, nil
+}
@@ -0,0 +1,54 @@
+package vt
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAuditLog(t *testing.T) {
+	Convey("Test audit log helpers", t, func() {
+		Convey("Only mutating methods are audited", func() {
+			So(auditedMethods.Has(NSNews, RPC.NewsService.Update), ShouldBeTrue)
+			So(auditedMethods.Has(NSUser, RPC.UserService.UpdateStatus), ShouldBeTrue)
+			So(auditedMethods.Has(NSAuth, RPC.AuthService.ChangePassword), ShouldBeTrue)
+			So(auditedMethods.Has(NSNews, RPC.NewsService.Get), ShouldBeFalse)
+			So(auditedMethods.Has(NSAuth, RPC.AuthService.Profile), ShouldBeFalse)
+			So(auditedMethods.Has(NSAudit, RPC.AuditService.Get), ShouldBeFalse)
+		})
+
+		Convey("Sensitive params are redacted", func() {
+			p := redactParams(json.RawMessage(`{"login":"admin","password":"secret","user":{"id":1,"password":"new","tags":[{"token":"x"}]}}`), false)
+			So(p["login"], ShouldEqual, "admin")
+			So(p["password"], ShouldEqual, redactedValue)
+
+			user := p["user"].(map[string]interface{})
+			So(user["password"], ShouldEqual, redactedValue)
+			So(user["tags"].([]interface{})[0].(map[string]interface{})["token"], ShouldEqual, redactedValue)
+
+			p = redactParams(json.RawMessage(`[1, {"password":"new"}]`), false)
+			So(p["params"], ShouldResemble, []interface{}{float64(1), map[string]interface{}{"password": redactedValue}})
+
+			p = redactParams(json.RawMessage(`["admin", "secret", true]`), true)
+			So(p["params"], ShouldResemble, []interface{}{redactedValue, redactedValue, redactedValue})
+
+			p = redactParams(json.RawMessage(`{"challengeToken":"t","code":"123456"}`), true)
+			So(p["challengeToken"], ShouldEqual, redactedValue)
+			So(p["code"], ShouldEqual, redactedValue)
+
+			So(redactParams(nil, false), ShouldBeNil)
+		})
+
+		Convey("Object id is taken from params or result", func() {
+			So(*auditObjectID(json.RawMessage(`{"id":5}`), nil), ShouldEqual, 5)
+			So(*auditObjectID(json.RawMessage(`{"news":{"id":7,"title":"t"}}`), nil), ShouldEqual, 7)
+
+			result := json.RawMessage(`{"id":9,"title":"t"}`)
+			So(*auditObjectID(json.RawMessage(`{"news":{"title":"t"}}`), &result), ShouldEqual, 9)
+
+			result = json.RawMessage(`true`)
+			So(auditObjectID(json.RawMessage(`{"statusUpdate":{"ids":[1,2]}}`), &result), ShouldBeNil)
+		})
+	})
+}
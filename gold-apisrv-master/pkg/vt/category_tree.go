@@ -0,0 +1,116 @@
+package vt
+
+import (
+	"apisrv/pkg/db"
+)
+
+// categoryOrderLock serializes concurrent changes of categories order.
+const categoryOrderLock = "category-order"
+
+type CategoryTree struct {
+	ID               int    `json:"id"`
+	ParentCategoryID *int   `json:"parentCategoryId"`
+	Title            string `json:"title"`
+	OrderNumber      int    `json:"orderNumber"`
+
+	Status   *Status        `json:"status"`
+	Children []CategoryTree `json:"children"`
+}
+
+// newCategoryTree builds nested categories from flat list sorted by orderNumber.
+// Categories with unknown parent are returned as roots.
+func newCategoryTree(list []db.Category) []CategoryTree {
+	known := make(map[int]bool, len(list))
+	for i := range list {
+		known[list[i].ID] = true
+	}
+
+	children := make(map[int][]db.Category)
+	var roots []db.Category
+	for _, c := range list {
+		if c.ParentCategoryID == nil || !known[*c.ParentCategoryID] {
+			roots = append(roots, c)
+		} else {
+			children[*c.ParentCategoryID] = append(children[*c.ParentCategoryID], c)
+		}
+	}
+
+	var build func([]db.Category) []CategoryTree
+	build = func(in []db.Category) []CategoryTree {
+		out := make([]CategoryTree, 0, len(in))
+		for _, c := range in {
+			out = append(out, CategoryTree{
+				ID:               c.ID,
+				ParentCategoryID: c.ParentCategoryID,
+				Title:            c.Title,
+				OrderNumber:      c.OrderNumber,
+
+				Status:   NewStatus(c.StatusID),
+				Children: build(children[c.ID]),
+			})
+		}
+		return out
+	}
+
+	return build(roots)
+}
+
+// moveCategory inserts category id into siblings at 1-based position, out of range positions are clamped.
+func moveCategory(siblings []db.Category, id, position int) []int {
+	ids := make([]int, 0, len(siblings)+1)
+	for _, c := range siblings {
+		if c.ID != id {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	idx := position - 1
+	if idx < 0 {
+		idx = 0
+	} else if idx > len(ids) {
+		idx = len(ids)
+	}
+
+	ids = append(ids[:idx], append([]int{id}, ids[idx:]...)...)
+
+	return ids
+}
+
+// sameIDs checks that siblings and ids contain the same categories.
+func sameIDs(siblings []db.Category, ids []int) bool {
+	if len(siblings) != len(ids) {
+		return false
+	}
+
+	set := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+
+	for _, c := range siblings {
+		if !set[c.ID] {
+			return false
+		}
+	}
+
+	return len(set) == len(ids)
+}
+
+// categoryIDs returns ids of categories.
+func categoryIDs(list []db.Category) []int {
+	ids := make([]int, len(list))
+	for i := range list {
+		ids[i] = list[i].ID
+	}
+
+	return ids
+}
+
+// sameParent checks that parent ids are equal.
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
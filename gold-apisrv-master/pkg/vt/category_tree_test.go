@@ -0,0 +1,46 @@
+package vt
+
+import (
+	"testing"
+
+	"apisrv/pkg/db"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCategoryTree(t *testing.T) {
+	Convey("Test category tree helpers", t, func() {
+		root, deleted := 1, 100
+		list := []db.Category{
+			{ID: 1, Title: "root", OrderNumber: 1, StatusID: db.StatusEnabled},
+			{ID: 2, ParentCategoryID: &root, Title: "child 1", OrderNumber: 1, StatusID: db.StatusEnabled},
+			{ID: 3, Title: "root 2", OrderNumber: 2, StatusID: db.StatusEnabled},
+			{ID: 4, ParentCategoryID: &root, Title: "child 2", OrderNumber: 2, StatusID: db.StatusEnabled},
+			{ID: 5, ParentCategoryID: &deleted, Title: "orphan", OrderNumber: 3, StatusID: db.StatusEnabled},
+		}
+
+		Convey("Tree is nested and keeps order", func() {
+			tree := newCategoryTree(list)
+			So(tree, ShouldHaveLength, 3)
+			So(tree[0].ID, ShouldEqual, 1)
+			So(tree[0].Children, ShouldHaveLength, 2)
+			So(tree[0].Children[0].ID, ShouldEqual, 2)
+			So(tree[0].Children[1].ID, ShouldEqual, 4)
+			So(tree[1].Children, ShouldBeEmpty)
+			So(tree[2].ID, ShouldEqual, 5)
+		})
+
+		Convey("Move inserts category at position", func() {
+			siblings := list[:3]
+			So(moveCategory(siblings, 3, 1), ShouldResemble, []int{3, 1, 2})
+			So(moveCategory(siblings, 1, 10), ShouldResemble, []int{2, 3, 1})
+			So(moveCategory(siblings, 7, 0), ShouldResemble, []int{7, 1, 2, 3})
+		})
+
+		Convey("Reorder ids must match siblings", func() {
+			So(sameIDs(list[:2], []int{2, 1}), ShouldBeTrue)
+			So(sameIDs(list[:2], []int{1, 1}), ShouldBeFalse)
+			So(sameIDs(list[:2], []int{1}), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package vt
+
+import (
+	"errors"
+
+	"apisrv/pkg/db"
+)
+
+const (
+	maxFilterDepth      = 4
+	maxFilterConditions = 50
+
+	filterField = "filter"
+)
+
+var (
+	idSearchTypes       = []int{db.SearchTypeEquals, db.SearchTypeArray}
+	textSearchTypes     = []int{db.SearchTypeEquals, db.SearchTypeLike, db.SearchTypeILike}
+	timeSearchTypes     = []int{db.SearchTypeGE, db.SearchTypeLE, db.SearchTypeGreater, db.SearchTypeLess}
+	nullTimeSearchTypes = append([]int{db.SearchTypeNull, db.SearchTypeNullOrGreater}, timeSearchTypes...)
+)
+
+// Filter is a condition of advanced filter.
+type Filter struct {
+	// field from service filter fields
+	Field string `json:"field"`
+	// search type, see db.SearchType* constants
+	SearchType int `json:"type"`
+	// value for search type: array for array types, null for null type
+	Value interface{} `json:"value"`
+	// negate condition
+	Exclude bool `json:"exclude"`
+}
+
+// FilterGroup is an advanced filter: conditions and nested groups joined by AND or OR.
+type FilterGroup struct {
+	// join conditions by OR, by AND otherwise
+	Or bool `json:"or"`
+	// negate the whole group
+	Not     bool          `json:"not"`
+	Filters []Filter      `json:"filters"`
+	Groups  []FilterGroup `json:"groups"`
+}
+
+// ToDB converts advanced filter to db filter group.
+func (fg *FilterGroup) ToDB() *db.FilterGroup {
+	if fg == nil {
+		return nil
+	}
+
+	group := &db.FilterGroup{Or: fg.Or, Not: fg.Not}
+
+	for _, f := range fg.Filters {
+		group.Filters = append(group.Filters, db.Filter{Field: f.Field, Value: f.Value, SearchType: f.SearchType, Exclude: f.Exclude})
+	}
+
+	for i := range fg.Groups {
+		group.Groups = append(group.Groups, *fg.Groups[i].ToDB())
+	}
+
+	return group
+}
+
+// filterFields are allowed search types by field of advanced filter.
+type filterFields db.FilterFields
+
+// validate checks size of advanced filter, its fields and values.
+func (ff filterFields) validate(fg *FilterGroup) error {
+	if fg == nil {
+		return nil
+	}
+
+	var v Validator
+	for _, e := range fg.ToDB().Validate(db.FilterFields(ff), maxFilterDepth, maxFilterConditions) {
+		field, fieldErr := filterField, FieldErrorIncorrect
+		if e.Path != "" {
+			field += fieldPathSeparator + e.Path
+		}
+		if errors.Is(e.Err, db.ErrFilterTooLarge) {
+			fieldErr = FieldErrorMax
+		}
+		v.Append(field, fieldErr)
+	}
+
+	return v.Error()
+}
@@ -0,0 +1,107 @@
+package vt
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"apisrv/pkg/db"
+
+	"github.com/go-pg/pg/v10/orm"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+// filterSQL returns select query of news with filter group.
+func filterSQL(fg *FilterGroup) string {
+	q := fg.ToDB().Apply(orm.NewQuery(nil, &db.News{}))
+	b, err := orm.NewSelectQuery(q).AppendQuery(orm.NewFormatter(), nil)
+	So(err, ShouldBeNil)
+	return string(b)
+}
+
+// filterErrors returns invalid fields of filter validation error.
+func filterErrors(err error) []string {
+	So(err, ShouldNotBeNil)
+	zerr := err.(*zenrpc.Error)
+	So(zerr.Code, ShouldEqual, http.StatusBadRequest)
+
+	var fields []string
+	for _, f := range zerr.Data.([]FieldError) {
+		fields = append(fields, f.Field)
+	}
+	return fields
+}
+
+func TestFilterGroup(t *testing.T) {
+	Convey("Test advanced filter", t, func() {
+		var fg FilterGroup
+		err := json.Unmarshal([]byte(`{
+			"groups": [
+				{"or": true, "filters": [{"field": "categoryId", "value": 1}, {"field": "tagIds", "type": 9, "value": 2}]},
+				{"not": true, "filters": [{"field": "statusId", "value": 2}]}
+			]
+		}`), &fg)
+		So(err, ShouldBeNil)
+
+		Convey("Groups are joined with negation", func() {
+			So(newsFilterFields.validate(&fg), ShouldBeNil)
+			So(filterSQL(&fg), ShouldContainSubstring, `WHERE ((("t"."categoryId" = 1) OR (2 = any ("t"."tagIds"))) AND NOT (("t"."statusId" = 2)))`)
+		})
+
+		Convey("Filter json is compatible with db filter group", func() {
+			b, err := json.Marshal(fg)
+			So(err, ShouldBeNil)
+
+			var dg db.FilterGroup
+			So(json.Unmarshal(b, &dg), ShouldBeNil)
+			So(dg, ShouldResemble, *fg.ToDB())
+		})
+
+		Convey("Empty groups are skipped", func() {
+			So(filterSQL(&FilterGroup{Not: true, Groups: []FilterGroup{{Or: true}}}), ShouldNotContainSubstring, "WHERE")
+		})
+
+		Convey("Only allowed fields and types are accepted", func() {
+			fg.Groups[0].Filters = append(fg.Groups[0].Filters,
+				Filter{Field: db.Columns.User.Password, SearchType: db.SearchTypeILike, Value: "a"},
+				Filter{Field: db.Columns.News.CategoryID, SearchType: db.SearchTypeILike, Value: "a"},
+				Filter{Field: db.Columns.News.Title, SearchType: db.SearchTypeILike, Value: 1},
+				Filter{Field: db.Columns.News.CategoryID, SearchType: db.SearchTypeArray, Value: []interface{}{}},
+				Filter{Field: db.Columns.News.TagIDs, SearchType: db.SearchTypeArrayIntersect, Value: []interface{}{1.0}, Exclude: true},
+				Filter{Field: db.Columns.News.UpdatedAt, SearchType: db.SearchTypeNull, Value: "a"},
+			)
+			So(filterErrors(newsFilterFields.validate(&fg)), ShouldResemble, []string{
+				"filter.groups[0].filters[2].field",
+				"filter.groups[0].filters[3].field",
+				"filter.groups[0].filters[4].value",
+				"filter.groups[0].filters[5].value",
+				"filter.groups[0].filters[6].value",
+				"filter.groups[0].filters[7].value",
+			})
+		})
+
+		Convey("Filter size is limited", func() {
+			deep := FilterGroup{}
+			for i := 0; i < maxFilterDepth; i++ {
+				deep = FilterGroup{Groups: []FilterGroup{deep}}
+			}
+			So(filterErrors(newsFilterFields.validate(&deep)), ShouldResemble, []string{"filter.groups[0].groups[0].groups[0].groups[0]"})
+
+			big := FilterGroup{Or: true}
+			for i := 0; i <= maxFilterConditions; i++ {
+				big.Filters = append(big.Filters, Filter{Field: db.Columns.News.ID, Value: i})
+			}
+			So(filterErrors(newsFilterFields.validate(&big)), ShouldResemble, []string{"filter"})
+		})
+
+		Convey("Search is validated with filter", func() {
+			var search *NewsSearch
+			So(search.validate(), ShouldBeNil)
+
+			search = &NewsSearch{Filter: &FilterGroup{Filters: []Filter{{Field: "password", Value: "a"}}}}
+			So(search.validate(), ShouldNotBeNil)
+			So((&UserSearch{Filter: &FilterGroup{Filters: []Filter{{Field: "password", Value: "a"}}}}).validate(), ShouldNotBeNil)
+		})
+	})
+}
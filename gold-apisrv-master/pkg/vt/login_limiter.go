@@ -0,0 +1,133 @@
+package vt
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	loginResultFailed = "failed"
+	loginResultLocked = "locked"
+)
+
+var statLogins *prometheus.CounterVec
+
+// SetStatLogins sets prometheus counter for failed and locked logins.
+func SetStatLogins(stat *prometheus.CounterVec) {
+	statLogins = stat
+}
+
+func incStatLogins(result string) {
+	if statLogins != nil {
+		statLogins.WithLabelValues(result).Inc()
+	}
+}
+
+// newLoginLockedError returns error for locked login with seconds to wait in data.
+func newLoginLockedError(retryAfter time.Duration) *zenrpc.Error {
+	return &zenrpc.Error{
+		Code:    http.StatusTooManyRequests,
+		Message: "too many login attempts",
+		Data:    map[string]int{"retryAfter": int(math.Ceil(retryAfter.Seconds()))},
+	}
+}
+
+// loginLimit is a lockout policy: after threshold failures during window key is locked for lock duration,
+// which doubles with every next failure up to maxLock.
+type loginLimit struct {
+	threshold int
+	window    time.Duration
+	lock      time.Duration
+	maxLock   time.Duration
+}
+
+var (
+	loginByLoginLimit = loginLimit{threshold: 5, window: 15 * time.Minute, lock: time.Minute, maxLock: time.Hour}
+	loginByIPLimit    = loginLimit{threshold: 20, window: 15 * time.Minute, lock: time.Minute, maxLock: time.Hour}
+)
+
+// loginLimiter tracks failed login attempts by login and client IP in database, so limits are shared by all instances.
+type loginLimiter struct {
+	commonRepo db.CommonRepo
+}
+
+func newLoginLimiter(dbo db.DB) *loginLimiter {
+	return &loginLimiter{commonRepo: db.NewCommonRepo(dbo)}
+}
+
+// loginKey returns tracked key of login.
+func loginKey(login string) string {
+	return "login:" + strings.ToLower(login)
+}
+
+// loginKeys returns tracked keys with their limits, empty ip is skipped.
+func loginKeys(login, ip string) map[string]loginLimit {
+	keys := map[string]loginLimit{loginKey(login): loginByLoginLimit}
+	if ip != "" {
+		keys["ip:"+ip] = loginByIPLimit
+	}
+
+	return keys
+}
+
+// lockedFor returns remaining lock duration for login or ip, zero if not locked.
+func (l *loginLimiter) lockedFor(ctx context.Context, login, ip string) (time.Duration, error) {
+	keys := make([]string, 0, 2)
+	for key := range loginKeys(login, ip) {
+		keys = append(keys, key)
+	}
+
+	return l.commonRepo.LoginAttemptsLockedFor(ctx, keys)
+}
+
+// fail registers failed attempt for login and ip.
+func (l *loginLimiter) fail(ctx context.Context, login, ip string) error {
+	for key, limit := range loginKeys(login, ip) {
+		failures, err := l.commonRepo.FailLoginAttempt(ctx, key, limit.window)
+		if err != nil {
+			return err
+		}
+
+		if over := failures - limit.threshold; over >= 0 {
+			if err := l.commonRepo.LockLoginAttempt(ctx, key, limit.lockDuration(over)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// reset removes failed attempts of login, ip attempts are kept.
+func (l *loginLimiter) reset(ctx context.Context, login string) error {
+	_, err := l.commonRepo.DeleteLoginAttempt(ctx, loginKey(login))
+	return err
+}
+
+// DeleteStaleLoginAttempts removes login attempts without recent failures and active locks.
+func DeleteStaleLoginAttempts(ctx context.Context, dbo orm.DB) (int, error) {
+	return db.NewCommonRepo(dbo).DeleteStaleLoginAttempts(ctx, loginByIPLimit.window)
+}
+
+// lockDuration returns lock duration for failures over threshold.
+func (ll loginLimit) lockDuration(over int) time.Duration {
+	d := ll.lock
+	for i := 0; i < over && d < ll.maxLock; i++ {
+		d *= 2
+	}
+
+	if d > ll.maxLock {
+		return ll.maxLock
+	}
+
+	return d
+}
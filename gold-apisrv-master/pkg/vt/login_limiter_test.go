@@ -0,0 +1,92 @@
+package vt
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoginLimiter(t *testing.T) {
+	Convey("Test login limiter", t, func() {
+		Convey("Lock duration grows and is capped", func() {
+			So(loginByLoginLimit.lockDuration(0), ShouldEqual, time.Minute)
+			So(loginByLoginLimit.lockDuration(2), ShouldEqual, 4*time.Minute)
+			So(loginByLoginLimit.lockDuration(20), ShouldEqual, time.Hour)
+		})
+
+		Convey("Keys are case insensitive and ip is optional", func() {
+			So(loginKeys("Admin", ""), ShouldResemble, map[string]loginLimit{"login:admin": loginByLoginLimit})
+			So(loginKeys("admin", "10.0.0.1"), ShouldResemble, map[string]loginLimit{"login:admin": loginByLoginLimit, "ip:10.0.0.1": loginByIPLimit})
+		})
+
+		Convey("Locked error contains retry after", func() {
+			err := newLoginLockedError(1500 * time.Millisecond)
+			So(err.Code, ShouldEqual, 429)
+			So(err.Data, ShouldResemble, map[string]int{"retryAfter": 2})
+		})
+
+		Convey("Dummy password is never valid", func() {
+			So(checkDummyPassword("dummy password"), ShouldBeFalse)
+		})
+	})
+}
+
+func TestDB_LoginLimiter(t *testing.T) {
+	Convey("Test login limiter in database", t, func() {
+		ctx := context.Background()
+		l := newLoginLimiter(testDb)
+		suffix := time.Now().UnixNano()
+		login, ip := fmt.Sprintf("limiter-%d", suffix), fmt.Sprintf("ip-%d", suffix)
+
+		failN := func(login, ip string, n int) {
+			for i := 0; i < n; i++ {
+				So(l.fail(ctx, login, ip), ShouldBeNil)
+			}
+		}
+		lockedFor := func(login, ip string) time.Duration {
+			d, err := l.lockedFor(ctx, login, ip)
+			So(err, ShouldBeNil)
+			return d.Round(time.Minute)
+		}
+
+		Convey("Login is locked after threshold failures", func() {
+			failN(login, "", loginByLoginLimit.threshold-1)
+			So(lockedFor(login, ""), ShouldEqual, 0)
+
+			So(l.fail(ctx, login, ""), ShouldBeNil)
+			So(lockedFor(login, ip), ShouldEqual, time.Minute)
+		})
+
+		Convey("Lock duration grows", func() {
+			failN(login, "", loginByLoginLimit.threshold+2)
+			So(lockedFor(login, ""), ShouldEqual, 4*time.Minute)
+		})
+
+		Convey("IP is locked for any login", func() {
+			for i := 0; i < loginByIPLimit.threshold; i++ {
+				So(l.fail(ctx, fmt.Sprintf("%s-%d", login, i), ip), ShouldBeNil)
+			}
+			So(lockedFor("another", ip), ShouldEqual, time.Minute)
+			So(lockedFor("another", ip+"-2"), ShouldEqual, 0)
+		})
+
+		Convey("Reset clears login failures only", func() {
+			failN(login, ip, loginByLoginLimit.threshold-1)
+			So(l.reset(ctx, login), ShouldBeNil)
+			So(l.fail(ctx, login, ip), ShouldBeNil)
+			So(lockedFor(login, ""), ShouldEqual, 0)
+		})
+
+		Reset(func() {
+			_, _ = testDb.ModelContext(ctx, (*db.LoginAttempt)(nil)).
+				Where("? LIKE ?", pg.Ident(db.Columns.LoginAttempt.ID), fmt.Sprintf("%%-%d%%", suffix)).
+				Delete()
+		})
+	})
+}
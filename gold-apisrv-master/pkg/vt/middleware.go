@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
-	"time"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
@@ -15,7 +14,9 @@ import (
 type userCtx string
 
 const (
-	userKey userCtx = "vt.user"
+	userKey    userCtx = "vt.user"
+	sessionKey userCtx = "vt.session"
+	apiKeyKey  userCtx = "vt.apiKey"
 )
 
 func authMiddleware(commonRepo *db.CommonRepo, logger embedlog.Logger) zenrpc.MiddlewareFunc {
@@ -28,35 +29,87 @@ func authMiddleware(commonRepo *db.CommonRepo, logger embedlog.Logger) zenrpc.Mi
 
 			ns := zenrpc.NamespaceFromContext(ctx)
 
-			// skip auth.Login method
-			if ns == NSAuth && method == RPC.AuthService.Login {
+			// skip public auth methods
+			if ns == NSAuth && isPublicAuthMethod(method) {
 				return h(ctx, method, params)
 			}
 
 			authHeader := req.Header.Get(AuthKey)
+
+			// authenticate machine client by api key
+			if apiKeyHeader := req.Header.Get(APIKeyHeader); authHeader == "" && apiKeyHeader != "" {
+				apiKey, err := apiKeyByToken(db.WithPrimary(ctx), commonRepo, apiKeyHeader)
+				if err != nil || apiKey == nil {
+					return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrUnauthorized.Code, ErrUnauthorized.Message, ErrUnauthorized.Data)
+				}
+
+				if err := touchAPIKey(ctx, commonRepo, apiKey); err != nil {
+					logger.Errorf("update api key usage error=%s", err)
+				}
+
+				// return error if key owner role or key scopes have no access to method
+				if !hasPermission(apiKey.User.Role, ns, method) || !apiKeyAllows(apiKey, ns, method) {
+					return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrForbidden.Code, ErrForbidden.Message, ErrForbidden.Data)
+				}
+
+				ctx = context.WithValue(ctx, userKey, apiKey.User)
+				ctx = context.WithValue(ctx, apiKeyKey, apiKey)
+				return h(ctx, method, params)
+			}
+
 			// return error if header is not set
 			if authHeader == "" {
 				return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrUnauthorized.Code, ErrUnauthorized.Message, ErrUnauthorized.Data)
 			}
 
-			// return error  if user not found
-			dbu, err := commonRepo.EnabledUserByAuthKey(ctx, authHeader)
-			if err != nil || dbu == nil {
+			// return error if session not found
+			session, err := sessionByToken(db.WithPrimary(ctx), commonRepo, authHeader)
+			if err != nil || session == nil {
 				return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrUnauthorized.Code, ErrUnauthorized.Message, ErrUnauthorized.Data)
 			}
+			dbu := session.User
 
 			// updating last activity
-			if dbu.LastActivityAt == nil || time.Since(*dbu.LastActivityAt) > time.Second*90 {
-				if _, err := commonRepo.UpdateUserActivity(ctx, dbu); err != nil {
-					logger.Errorf("update user activity error=%s", err)
-				}
+			if err := touchSession(ctx, commonRepo, session); err != nil {
+				logger.Errorf("update session activity error=%s", err)
+			}
+
+			// return error if user role has no access to method
+			if !hasPermission(dbu.Role, ns, method) {
+				return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrForbidden.Code, ErrForbidden.Message, ErrForbidden.Data)
+			}
+
+			ctx = context.WithValue(ctx, userKey, dbu)
+			ctx = context.WithValue(ctx, sessionKey, session)
+			return h(ctx, method, params)
+		}
+	}
+}
+
+// primaryMiddleware routes reads of auth and mutating methods to primary database, so changes are based on actual data.
+func primaryMiddleware() zenrpc.MiddlewareFunc {
+	return func(h zenrpc.InvokeFunc) zenrpc.InvokeFunc {
+		return func(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+			if ns := zenrpc.NamespaceFromContext(ctx); ns == NSAuth || auditedMethods.Has(ns, method) {
+				ctx = db.WithPrimary(ctx)
 			}
 
-			return h(context.WithValue(ctx, userKey, dbu), method, params)
+			return h(ctx, method, params)
 		}
 	}
 }
 
+// isPublicAuthMethod checks that auth method doesn't require authentication.
+func isPublicAuthMethod(method string) bool {
+	switch method {
+	case RPC.AuthService.Login, RPC.AuthService.LoginSecondFactor, RPC.AuthService.RequestPasswordReset,
+		RPC.AuthService.ResetPassword, RPC.AuthService.AcceptInvite:
+		return true
+	}
+
+	return false
+}
+
 func UserFromContext(ctx context.Context) *db.User {
 	if user, ok := ctx.Value(userKey).(*db.User); ok {
 		return user
@@ -64,22 +117,56 @@ func UserFromContext(ctx context.Context) *db.User {
 	return nil
 }
 
-// HTTPAuthMiddleware checks user from authKey header
+// SessionFromContext returns current user session.
+func SessionFromContext(ctx context.Context) *db.Session {
+	if session, ok := ctx.Value(sessionKey).(*db.Session); ok {
+		return session
+	}
+	return nil
+}
+
+// HTTPAuthMiddleware checks user session from authKey header or api key from APIKeyHeader.
 func HTTPAuthMiddleware(commonRepo db.CommonRepo, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errCode := http.StatusUnauthorized
+		authHeader := r.Header.Get(AuthKey)
+
+		// authenticate machine client by api key
+		if apiKeyHeader := r.Header.Get(APIKeyHeader); authHeader == "" && apiKeyHeader != "" {
+			apiKey, err := apiKeyByToken(db.WithPrimary(r.Context()), &commonRepo, apiKeyHeader)
+			if err != nil || apiKey == nil {
+				http.Error(w, "api key not found", errCode)
+				return
+			}
+
+			_ = touchAPIKey(r.Context(), &commonRepo, apiKey)
+
+			if !hasPermission(apiKey.User.Role, NSVFS, VfsUploadMethod) || !apiKeyAllows(apiKey, NSVFS, VfsUploadMethod) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+			return
+		}
 
 		// return error if header is not set
-		authHeader := r.Header.Get(AuthKey)
 		if authHeader == "" {
 			http.Error(w, "authorization required", errCode)
 			return
 		}
 
-		// return error if user not found
-		dbu, err := commonRepo.EnabledUserByAuthKey(r.Context(), authHeader)
-		if err != nil || dbu == nil {
-			http.Error(w, "user not found", errCode)
+		// return error if session not found
+		session, err := sessionByToken(db.WithPrimary(r.Context()), &commonRepo, authHeader)
+		if err != nil || session == nil {
+			http.Error(w, "session not found", errCode)
+			return
+		}
+		dbu := session.User
+
+		// return error if user role has no access to upload
+		if !hasPermission(dbu.Role, NSVFS, VfsUploadMethod) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
 			return
 		}
 
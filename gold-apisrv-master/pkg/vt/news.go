@@ -2,23 +2,38 @@ package vt
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
 
+	"github.com/go-pg/pg/v10"
 	"github.com/vmkteam/zenrpc/v2"
 )
 
+var categoryFilterFields = filterFields{
+	db.Columns.Category.ID:               idSearchTypes,
+	db.Columns.Category.ParentCategoryID: append([]int{db.SearchTypeNull}, idSearchTypes...),
+	db.Columns.Category.Title:            textSearchTypes,
+	db.Columns.Category.OrderNumber:      {db.SearchTypeEquals, db.SearchTypeGE, db.SearchTypeLE},
+	db.Columns.Category.StatusID:         idSearchTypes,
+}
+
 type CategoryService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db       db.DB
 	newsRepo db.NewsRepo
+	vfsRepo  db.VfsRepo
 }
 
 func NewCategoryService(dbo db.DB, logger embedlog.Logger) *CategoryService {
 	return &CategoryService{
 		Logger:   logger,
+		db:       dbo,
 		newsRepo: db.NewNewsRepo(dbo),
+		vfsRepo:  db.NewVfsRepo(dbo),
 	}
 }
 
@@ -40,8 +55,13 @@ func (s CategoryService) dbSort(ops *ViewOps) db.OpFunc {
 //
 //zenrpc:search CategorySearch
 //zenrpc:return int
+//zenrpc:400 Validation Error
 //zenrpc:500 Internal Error
 func (s CategoryService) Count(ctx context.Context, search *CategorySearch) (int, error) {
+	if err := search.validate(); err != nil {
+		return 0, err
+	}
+
 	count, err := s.newsRepo.CountCategories(ctx, search.ToDB())
 	if err != nil {
 		return 0, InternalError(err)
@@ -54,19 +74,55 @@ func (s CategoryService) Count(ctx context.Context, search *CategorySearch) (int
 //zenrpc:search CategorySearch
 //zenrpc:viewOps ViewOps
 //zenrpc:return []CategorySummary
+//zenrpc:400 Validation Error
 //zenrpc:500 Internal Error
 func (s CategoryService) Get(ctx context.Context, search *CategorySearch, viewOps *ViewOps) ([]CategorySummary, error) {
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
 	list, err := s.newsRepo.CategoriesByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.newsRepo.FullCategory())
 	if err != nil {
 		return nil, InternalError(err)
 	}
-	categories := make([]CategorySummary, 0, len(list))
-	for i := 0; i < len(list); i++ {
-		if category := NewCategorySummary(&list[i]); category != nil {
-			categories = append(categories, *NewCategorySummary(&list[i]))
-		}
+	return newCategorySummaries(list), nil
+}
+
+// List returns а page of Categories and total count according to conditions in search params.
+// Next pages are selected by cursor, page number is used only without cursor.
+//
+//zenrpc:search CategorySearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return CategoryList
+//zenrpc:400 Validation Error
+//zenrpc:500 Internal Error
+func (s CategoryService) List(ctx context.Context, search *CategorySearch, viewOps *ViewOps) (*CategoryList, error) {
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
+	pager, err := viewOps.keysetPager(db.Columns.Category.ID, db.NewSortField(db.Columns.Category.Title, false),
+		db.Columns.Category.ID, db.Columns.Category.Title, db.Columns.Category.OrderNumber, db.Columns.Category.StatusID)
+	if err != nil {
+		return nil, err
 	}
-	return categories, nil
+
+	list, err := s.newsRepo.CategoriesByFilters(ctx, search.ToDB(), pager, s.newsRepo.FullCategory())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	next, err := nextCursor(pager, &list)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	total, err := s.newsRepo.CountCategories(ctx, search.ToDB())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return &CategoryList{Items: newCategorySummaries(list), Total: total, NextCursor: next}, nil
 }
 
 // GetByID returns a Category by its ID.
@@ -93,6 +149,15 @@ func (s CategoryService) byID(ctx context.Context, id int) (*db.Category, error)
 	return db, nil
 }
 
+// conflict returns version conflict error with current version of Category.
+func (s CategoryService) conflict(ctx context.Context, id int) error {
+	category, err := s.byID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return VersionConflictError(category.Version)
+}
+
 // Add adds a Category from the query.
 //
 //zenrpc:category Category
@@ -104,7 +169,7 @@ func (s CategoryService) Add(ctx context.Context, category Category) (*Category,
 		return nil, ve.Error()
 	}
 
-	db, err := s.newsRepo.AddCategory(ctx, category.ToDB())
+	db, err := s.newsRepo.AddVersionedCategory(ctx, category.ToDB())
 	if err != nil {
 		return nil, InternalError(err)
 	}
@@ -112,12 +177,14 @@ func (s CategoryService) Add(ctx context.Context, category Category) (*Category,
 }
 
 // Update updates the Category data identified by id from the query.
+// Update is rejected with 409 if Category was changed since its version from Get, version is required.
 //
 //zenrpc:categories Category
 //zenrpc:return Category
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
 func (s CategoryService) Update(ctx context.Context, category Category) (bool, error) {
 	if _, err := s.byID(ctx, category.ID); err != nil {
 		return false, err
@@ -127,9 +194,28 @@ func (s CategoryService) Update(ctx context.Context, category Category) (bool, e
 		return false, ve.Error()
 	}
 
-	ok, err := s.newsRepo.UpdateCategory(ctx, category.ToDB())
-	if err != nil {
+	// parent is checked again under category order lock, so concurrent changes can't make a cycle
+	var (
+		v  Validator
+		ok bool
+	)
+	err := s.db.RunInLock(ctx, categoryOrderLock, func(tx *pg.Tx) (err error) {
+		repo := s.newsRepo.WithTransaction(tx)
+		if category.ParentCategoryID != nil {
+			if s.validateParent(ctx, repo, &v, category.ID, *category.ParentCategoryID); v.HasErrors() {
+				return nil
+			}
+		}
+
+		ok, err = repo.UpdateVersionedCategory(ctx, category.ToDB())
+		return err
+	})
+	if errors.Is(err, db.ErrVersionConflict) {
+		return false, s.conflict(ctx, category.ID)
+	} else if err != nil {
 		return false, InternalError(err)
+	} else if v.HasErrors() {
+		return false, v.Error()
 	}
 	return ok, nil
 }
@@ -153,6 +239,28 @@ func (s CategoryService) Delete(ctx context.Context, id int) (bool, error) {
 	return ok, err
 }
 
+// UpdateStatus sets status for Categories identified by ids in a single transaction.
+//
+//zenrpc:statusUpdate StatusUpdate
+//zenrpc:return []StatusUpdateResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s CategoryService) UpdateStatus(ctx context.Context, statusUpdate StatusUpdate) ([]StatusUpdateResult, error) {
+	return updateStatus(ctx, s.db, statusUpdate, func(tx *pg.Tx, id int) (string, error) {
+		repo := s.newsRepo.WithTransaction(tx)
+		category, err := repo.CategoryByID(ctx, id)
+		if err != nil {
+			return "", err
+		} else if category == nil {
+			return StatusUpdateErrorNotFound, nil
+		}
+
+		category.StatusID = statusUpdate.StatusID
+		_, err = repo.UpdateVersionedCategory(ctx, category, db.WithColumns(db.Columns.Category.StatusID))
+		return "", err
+	})
+}
+
 // Validate verifies that Category data is valid.
 //
 //zenrpc:category Category
@@ -182,20 +290,194 @@ func (s CategoryService) isValid(ctx context.Context, category Category, isUpdat
 		return v
 	}
 
+	// check parent
+	if category.ParentCategoryID != nil {
+		s.validateParent(ctx, s.newsRepo, &v, category.ID, *category.ParentCategoryID)
+	}
+
+	// check images
+	if category.CoverImage != nil {
+		validateVfsHashes(ctx, &v, s.vfsRepo, "coverImage", *category.CoverImage)
+	}
+
 	//custom validation starts here
 	return v
 }
 
+// validateParent checks that parent category exists and is not the category itself or its descendant.
+// Changes of parent must be made in transaction of repo under category order lock.
+func (s CategoryService) validateParent(ctx context.Context, repo db.NewsRepo, v *Validator, id, parentID int) {
+	parent, err := repo.CategoryByID(ctx, parentID)
+	if err != nil {
+		v.SetInternalError(err)
+		return
+	} else if parent == nil {
+		v.Append("parentCategoryId", FieldErrorIncorrect)
+		return
+	}
+
+	if id == 0 {
+		return
+	}
+
+	ids, err := repo.CategoryTreeIDs(ctx, id)
+	if err != nil {
+		v.SetInternalError(err)
+		return
+	}
+
+	for _, treeID := range ids {
+		if treeID == parentID {
+			v.Append("parentCategoryId", FieldErrorIncorrect)
+			return
+		}
+	}
+}
+
+// Tree returns all Categories as nested structure ordered by orderNumber.
+//
+//zenrpc:return []CategoryTree
+//zenrpc:500 Internal Error
+func (s CategoryService) Tree(ctx context.Context) ([]CategoryTree, error) {
+	list, err := s.newsRepo.CategoriesByFilters(ctx, nil, db.PagerNoLimit, db.WithSort(
+		db.SortField{Column: db.Columns.Category.OrderNumber, Direction: db.SortAsc},
+		db.SortField{Column: db.Columns.Category.ID, Direction: db.SortAsc},
+	))
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return newCategoryTree(list), nil
+}
+
+// Move moves the Category to the parent at the given position and rewrites orderNumber of siblings.
+//
+//zenrpc:id Category id
+//zenrpc:parentCategoryId new parent Category id, null for root
+//zenrpc:position 1-based position among siblings
+//zenrpc:return bool
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s CategoryService) Move(ctx context.Context, id int, parentCategoryId *int, position int) (bool, error) {
+	category, err := s.byID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	var v Validator
+	err = s.db.RunInLock(ctx, categoryOrderLock, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		if parentCategoryId != nil {
+			if s.validateParent(ctx, repo, &v, id, *parentCategoryId); v.HasErrors() {
+				return nil
+			}
+		}
+
+		siblings, err := repo.CategoryChildren(ctx, parentCategoryId)
+		if err != nil {
+			return err
+		}
+
+		if err = s.reorder(ctx, repo, parentCategoryId, moveCategory(siblings, id, position)); err != nil {
+			return err
+		}
+
+		// close the gap in the old parent
+		if !sameParent(category.ParentCategoryID, parentCategoryId) {
+			oldSiblings, err := repo.CategoryChildren(ctx, category.ParentCategoryID)
+			if err != nil {
+				return err
+			}
+
+			return s.reorder(ctx, repo, category.ParentCategoryID, categoryIDs(oldSiblings))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, InternalError(err)
+	} else if v.HasErrors() {
+		return false, v.Error()
+	}
+
+	return true, nil
+}
+
+// Reorder sets order of all children of the parent Category by ids.
+//
+//zenrpc:parentCategoryId parent Category id, null for root
+//zenrpc:ids ids of all children in new order
+//zenrpc:return bool
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s CategoryService) Reorder(ctx context.Context, parentCategoryId *int, ids []int) (bool, error) {
+	var isValid bool
+	err := s.db.RunInLock(ctx, categoryOrderLock, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+
+		siblings, err := repo.CategoryChildren(ctx, parentCategoryId)
+		if err != nil {
+			return err
+		}
+
+		if isValid = sameIDs(siblings, ids); !isValid {
+			return nil
+		}
+
+		return s.reorder(ctx, repo, parentCategoryId, ids)
+	})
+	if err != nil {
+		return false, InternalError(err)
+	} else if !isValid {
+		var v Validator
+		v.Append("ids", FieldErrorIncorrect)
+		return false, v.Error()
+	}
+
+	return true, nil
+}
+
+// reorder sets parent and sequential orderNumber for categories by ids.
+func (s CategoryService) reorder(ctx context.Context, repo db.NewsRepo, parentID *int, ids []int) error {
+	for i, id := range ids {
+		if _, err := repo.UpdateCategoryPosition(ctx, id, parentID, i+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var newsFilterFields = filterFields{
+	db.Columns.News.ID:              idSearchTypes,
+	db.Columns.News.Title:           textSearchTypes,
+	db.Columns.News.Alias:           textSearchTypes,
+	db.Columns.News.Content:         {db.SearchTypeNull, db.SearchTypeLike, db.SearchTypeILike},
+	db.Columns.News.CategoryID:      idSearchTypes,
+	db.Columns.News.TagIDs:          {db.SearchTypeArrayContains, db.SearchTypeArrayContained, db.SearchTypeArrayIntersect},
+	db.Columns.News.CreatedAt:       timeSearchTypes,
+	db.Columns.News.UpdatedAt:       nullTimeSearchTypes,
+	db.Columns.News.PublicationDate: timeSearchTypes,
+	db.Columns.News.UnpublishDate:   nullTimeSearchTypes,
+	db.Columns.News.StatusID:        idSearchTypes,
+	db.NewsSearchVector:             {db.SearchTypeFullText},
+}
+
 type NewsService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db       db.DB
 	newsRepo db.NewsRepo
+	vfsRepo  db.VfsRepo
 }
 
 func NewNewsService(dbo db.DB, logger embedlog.Logger) *NewsService {
 	return &NewsService{
 		Logger:   logger,
+		db:       dbo,
 		newsRepo: db.NewNewsRepo(dbo),
+		vfsRepo:  db.NewVfsRepo(dbo),
 	}
 }
 
@@ -217,8 +499,13 @@ func (s NewsService) dbSort(ops *ViewOps) db.OpFunc {
 //
 //zenrpc:search NewsSearch
 //zenrpc:return int
+//zenrpc:400 Validation Error
 //zenrpc:500 Internal Error
 func (s NewsService) Count(ctx context.Context, search *NewsSearch) (int, error) {
+	if err := search.validate(); err != nil {
+		return 0, err
+	}
+
 	count, err := s.newsRepo.CountNews(ctx, search.ToDB())
 	if err != nil {
 		return 0, InternalError(err)
@@ -231,21 +518,112 @@ func (s NewsService) Count(ctx context.Context, search *NewsSearch) (int, error)
 //zenrpc:search NewsSearch
 //zenrpc:viewOps ViewOps
 //zenrpc:return []NewsSummary
+//zenrpc:400 Validation Error
 //zenrpc:500 Internal Error
 func (s NewsService) Get(ctx context.Context, search *NewsSearch, viewOps *ViewOps) ([]NewsSummary, error) {
-	list, err := s.newsRepo.NewsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.newsRepo.FullNews())
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
+	sort := s.dbSort(viewOps)
+	if search.HasQuery() && (viewOps == nil || viewOps.SortColumn == "") {
+		sort = db.WithFullTextRank(db.NewsSearchVector, *search.Query)
+	}
+
+	list, err := s.newsRepo.NewsByFilters(ctx, search.ToDB(), viewOps.Pager(), sort, s.newsRepo.FullNews())
 	if err != nil {
 		return nil, InternalError(err)
 	}
+
+	newsList, err := s.summaries(ctx, list, search)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return newsList, nil
+}
+
+// List returns а page of News and total count according to conditions in search params.
+// Next pages are selected by cursor, page number is used only without cursor. Full-text results are not sorted by rank.
+//
+//zenrpc:search NewsSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return NewsList
+//zenrpc:400 Validation Error
+//zenrpc:500 Internal Error
+func (s NewsService) List(ctx context.Context, search *NewsSearch, viewOps *ViewOps) (*NewsList, error) {
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
+	pager, err := viewOps.keysetPager(db.Columns.News.ID, db.NewSortField(db.Columns.News.CreatedAt, true),
+		db.Columns.News.ID, db.Columns.News.Title, db.Columns.News.Alias, db.Columns.News.CategoryID, db.Columns.News.CreatedAt, db.Columns.News.PublicationDate, db.Columns.News.StatusID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.newsRepo.NewsByFilters(ctx, search.ToDB(), pager, s.newsRepo.FullNews())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	next, err := nextCursor(pager, &list)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	total, err := s.newsRepo.CountNews(ctx, search.ToDB())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	items, err := s.summaries(ctx, list, search)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return &NewsList{Items: items, Total: total, NextCursor: next}, nil
+}
+
+// summaries converts news list to summaries with headlines of full-text query.
+func (s NewsService) summaries(ctx context.Context, list []db.News, search *NewsSearch) ([]NewsSummary, error) {
 	newsList := make([]NewsSummary, 0, len(list))
 	for i := 0; i < len(list); i++ {
 		if news := NewNewsSummary(&list[i]); news != nil {
-			newsList = append(newsList, *NewNewsSummary(&list[i]))
+			newsList = append(newsList, *news)
 		}
 	}
+
+	if search.HasQuery() {
+		if err := s.fillHeadlines(ctx, newsList, *search.Query); err != nil {
+			return nil, err
+		}
+	}
+
 	return newsList, nil
 }
 
+// fillHeadlines sets content snippets matched by full-text query.
+func (s NewsService) fillHeadlines(ctx context.Context, list []NewsSummary, query string) error {
+	ids := make([]int, len(list))
+	for i := range list {
+		ids[i] = list[i].ID
+	}
+
+	headlines, err := s.newsRepo.NewsHeadlines(ctx, ids, query)
+	if err != nil {
+		return err
+	}
+
+	for i := range list {
+		if h, ok := headlines[list[i].ID]; ok {
+			list[i].Headline = &h
+		}
+	}
+
+	return nil
+}
+
 // GetByID returns a News by its ID.
 //
 //zenrpc:id int
@@ -270,6 +648,15 @@ func (s NewsService) byID(ctx context.Context, id int) (*db.News, error) {
 	return db, nil
 }
 
+// conflict returns version conflict error with current version of News.
+func (s NewsService) conflict(ctx context.Context, id int) error {
+	news, err := s.byID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return VersionConflictError(news.Version)
+}
+
 // Add adds a News from the query.
 //
 //zenrpc:news News
@@ -281,7 +668,16 @@ func (s NewsService) Add(ctx context.Context, news News) (*News, error) {
 		return nil, ve.Error()
 	}
 
-	db, err := s.newsRepo.AddNews(ctx, news.ToDB())
+	db := news.ToDB()
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		if _, err := repo.AddVersionedNews(ctx, db); err != nil {
+			return err
+		}
+
+		_, err := repo.AddNewsRevision(ctx, newNewsRevision(db, UserFromContext(ctx)))
+		return err
+	})
 	if err != nil {
 		return nil, InternalError(err)
 	}
@@ -289,14 +685,17 @@ func (s NewsService) Add(ctx context.Context, news News) (*News, error) {
 }
 
 // Update updates the News data identified by id from the query.
+// Update is rejected with 409 if News was changed since its version from Get, version is required.
 //
 //zenrpc:newsList News
 //zenrpc:return News
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
 func (s NewsService) Update(ctx context.Context, news News) (bool, error) {
-	if _, err := s.byID(ctx, news.ID); err != nil {
+	orig, err := s.byID(ctx, news.ID)
+	if err != nil {
 		return false, err
 	}
 
@@ -304,13 +703,42 @@ func (s NewsService) Update(ctx context.Context, news News) (bool, error) {
 		return false, ve.Error()
 	}
 
-	ok, err := s.newsRepo.UpdateNews(ctx, news.ToDB())
-	if err != nil {
+	cur := news.ToDB()
+	cur.PublishedAt = orig.PublishedAt
+	cur.UnpublishedAt = orig.UnpublishedAt
+
+	// reset scheduler marks if publication window was changed
+	if !cur.PublicationDate.Equal(orig.PublicationDate) {
+		cur.PublishedAt = nil
+	}
+	if cur.StatusID == db.StatusEnabled && orig.StatusID != db.StatusEnabled {
+		cur.UnpublishedAt = nil
+	}
+
+	ok, err := s.update(ctx, cur)
+	if errors.Is(err, db.ErrVersionConflict) {
+		return false, s.conflict(ctx, cur.ID)
+	} else if err != nil {
 		return false, InternalError(err)
 	}
 	return ok, nil
 }
 
+// update saves news and its revision in one transaction.
+func (s NewsService) update(ctx context.Context, news *db.News) (ok bool, err error) {
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		if ok, err = repo.UpdateVersionedNews(ctx, news); err != nil || !ok {
+			return err
+		}
+
+		_, err = repo.AddNewsRevision(ctx, newNewsRevision(news, UserFromContext(ctx)))
+		return err
+	})
+
+	return ok, err
+}
+
 // Delete deletes the News by its ID.
 //
 //zenrpc:id int
@@ -330,6 +758,135 @@ func (s NewsService) Delete(ctx context.Context, id int) (bool, error) {
 	return ok, err
 }
 
+// Revisions returns a list of News revisions, newest first.
+//
+//zenrpc:newsId News id
+//zenrpc:viewOps ViewOps
+//zenrpc:return []NewsRevisionSummary
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s NewsService) Revisions(ctx context.Context, newsId int, viewOps *ViewOps) ([]NewsRevisionSummary, error) {
+	if _, err := s.byID(ctx, newsId); err != nil {
+		return nil, err
+	}
+
+	list, err := s.newsRepo.NewsRevisionsByFilters(ctx, &db.NewsRevisionSearch{NewsID: &newsId}, viewOps.Pager(), s.newsRepo.DefaultNewsRevisionSort(), s.newsRepo.FullNewsRevision())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	revisions := make([]NewsRevisionSummary, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		revisions = append(revisions, *NewNewsRevisionSummary(&list[i]))
+	}
+	return revisions, nil
+}
+
+// RevisionDiff returns a field-level diff between two revisions of the same News.
+//
+//zenrpc:fromId Older revision id
+//zenrpc:toId Newer revision id
+//zenrpc:return []NewsRevisionDiff
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s NewsService) RevisionDiff(ctx context.Context, fromId, toId int) ([]NewsRevisionDiff, error) {
+	from, err := s.revisionByID(ctx, fromId)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.revisionByID(ctx, toId)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.NewsID != to.NewsID {
+		var v Validator
+		v.Append("toId", FieldErrorIncorrect)
+		return nil, v.Error()
+	}
+
+	return newsRevisionDiff(from, to), nil
+}
+
+// RestoreRevision restores News fields from the revision and saves them as a new revision.
+//
+//zenrpc:revisionId Revision id
+//zenrpc:return News
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
+func (s NewsService) RestoreRevision(ctx context.Context, revisionId int) (*News, error) {
+	rev, err := s.revisionByID(ctx, revisionId)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := s.byID(ctx, rev.NewsID)
+	if err != nil {
+		return nil, err
+	}
+
+	restoreNewsRevision(cur, rev)
+	if ve := s.isValid(ctx, *NewNews(cur), true); ve.HasErrors() {
+		return nil, ve.Error()
+	}
+
+	if _, err := s.update(ctx, cur); errors.Is(err, db.ErrVersionConflict) {
+		return nil, s.conflict(ctx, cur.ID)
+	} else if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return s.GetByID(ctx, cur.ID)
+}
+
+func (s NewsService) revisionByID(ctx context.Context, id int) (*db.NewsRevision, error) {
+	db, err := s.newsRepo.NewsRevisionByID(ctx, id)
+	if err != nil {
+		return nil, InternalError(err)
+	} else if db == nil {
+		return nil, ErrNotFound
+	}
+	return db, nil
+}
+
+// UpdateStatus sets status for News identified by ids in a single transaction.
+//
+//zenrpc:statusUpdate StatusUpdate
+//zenrpc:return []StatusUpdateResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s NewsService) UpdateStatus(ctx context.Context, statusUpdate StatusUpdate) ([]StatusUpdateResult, error) {
+	return updateStatus(ctx, s.db, statusUpdate, func(tx *pg.Tx, id int) (string, error) {
+		repo := s.newsRepo.WithTransaction(tx)
+		news, err := repo.NewsByID(ctx, id)
+		if err != nil {
+			return "", err
+		} else if news == nil {
+			return StatusUpdateErrorNotFound, nil
+		}
+
+		columns := []string{db.Columns.News.StatusID}
+		if statusUpdate.StatusID == db.StatusEnabled && news.StatusID != db.StatusEnabled {
+			if isUnpublished(news.UnpublishDate) {
+				return StatusUpdateErrorUnpublished, nil
+			}
+			news.UnpublishedAt = nil
+			columns = append(columns, db.Columns.News.UnpublishedAt)
+		}
+
+		news.StatusID = statusUpdate.StatusID
+		if _, err = repo.UpdateVersionedNews(ctx, news, db.WithColumns(columns...)); err != nil {
+			return "", err
+		}
+
+		_, err = repo.AddNewsRevision(ctx, newNewsRevision(news, UserFromContext(ctx)))
+		return "", err
+	})
+}
+
 // Validate verifies that News data is valid.
 //
 //zenrpc:news News
@@ -389,19 +946,46 @@ func (s NewsService) isValid(ctx context.Context, news News, isUpdate bool) Vali
 			v.Append("tagIds", FieldErrorIncorrect)
 		}
 	}
+
+	// check publication window, enabled news with passed unpublish date would be disabled by scheduler
+	if news.UnpublishDate != nil && !news.UnpublishDate.After(news.PublicationDate) {
+		v.Append("unpublishDate", FieldErrorIncorrect)
+	} else if news.StatusID == db.StatusEnabled && isUnpublished(news.UnpublishDate) {
+		v.Append("unpublishDate", FieldErrorIncorrect)
+	}
+
+	// check images
+	if news.CoverImage != nil {
+		validateVfsHashes(ctx, &v, s.vfsRepo, "coverImage", *news.CoverImage)
+	}
+	validateVfsHashes(ctx, &v, s.vfsRepo, "galleryImages", news.GalleryImages...)
 	//custom validation starts here
 	return v
 }
 
+// isUnpublished checks that unpublish date has passed.
+func isUnpublished(unpublishDate *time.Time) bool {
+	return unpublishDate != nil && !unpublishDate.After(time.Now())
+}
+
+var tagFilterFields = filterFields{
+	db.Columns.Tag.ID:       idSearchTypes,
+	db.Columns.Tag.Title:    textSearchTypes,
+	db.Columns.Tag.Alias:    textSearchTypes,
+	db.Columns.Tag.StatusID: idSearchTypes,
+}
+
 type TagService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db       db.DB
 	newsRepo db.NewsRepo
 }
 
 func NewTagService(dbo db.DB, logger embedlog.Logger) *TagService {
 	return &TagService{
 		Logger:   logger,
+		db:       dbo,
 		newsRepo: db.NewNewsRepo(dbo),
 	}
 }
@@ -413,7 +997,7 @@ func (s TagService) dbSort(ops *ViewOps) db.OpFunc {
 	}
 
 	switch ops.SortColumn {
-	case db.Columns.Tag.ID, db.Columns.Tag.Title, db.Columns.Tag.StatusID:
+	case db.Columns.Tag.ID, db.Columns.Tag.Title, db.Columns.Tag.Alias, db.Columns.Tag.StatusID:
 		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
 	}
 
@@ -424,8 +1008,13 @@ func (s TagService) dbSort(ops *ViewOps) db.OpFunc {
 //
 //zenrpc:search TagSearch
 //zenrpc:return int
+//zenrpc:400 Validation Error
 //zenrpc:500 Internal Error
 func (s TagService) Count(ctx context.Context, search *TagSearch) (int, error) {
+	if err := search.validate(); err != nil {
+		return 0, err
+	}
+
 	count, err := s.newsRepo.CountTags(ctx, search.ToDB())
 	if err != nil {
 		return 0, InternalError(err)
@@ -438,16 +1027,84 @@ func (s TagService) Count(ctx context.Context, search *TagSearch) (int, error) {
 //zenrpc:search TagSearch
 //zenrpc:viewOps ViewOps
 //zenrpc:return []TagSummary
+//zenrpc:400 Validation Error
 //zenrpc:500 Internal Error
 func (s TagService) Get(ctx context.Context, search *TagSearch, viewOps *ViewOps) ([]TagSummary, error) {
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
 	list, err := s.newsRepo.TagsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.newsRepo.FullTag())
 	if err != nil {
 		return nil, InternalError(err)
 	}
+
+	tags, err := s.summaries(ctx, list)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return tags, nil
+}
+
+// List returns а page of Tags and total count according to conditions in search params.
+// Next pages are selected by cursor, page number is used only without cursor.
+//
+//zenrpc:search TagSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return TagList
+//zenrpc:400 Validation Error
+//zenrpc:500 Internal Error
+func (s TagService) List(ctx context.Context, search *TagSearch, viewOps *ViewOps) (*TagList, error) {
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
+	pager, err := viewOps.keysetPager(db.Columns.Tag.ID, db.NewSortField(db.Columns.Tag.Title, false),
+		db.Columns.Tag.ID, db.Columns.Tag.Title, db.Columns.Tag.Alias, db.Columns.Tag.StatusID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.newsRepo.TagsByFilters(ctx, search.ToDB(), pager, s.newsRepo.FullTag())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	next, err := nextCursor(pager, &list)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	total, err := s.newsRepo.CountTags(ctx, search.ToDB())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	items, err := s.summaries(ctx, list)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return &TagList{Items: items, Total: total, NextCursor: next}, nil
+}
+
+// summaries converts tags to summaries with news counts.
+func (s TagService) summaries(ctx context.Context, list []db.Tag) ([]TagSummary, error) {
+	ids := make([]int, 0, len(list))
+	for i := range list {
+		ids = append(ids, list[i].ID)
+	}
+
+	counts, err := s.newsRepo.TagNewsCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	tags := make([]TagSummary, 0, len(list))
 	for i := 0; i < len(list); i++ {
 		if tag := NewTagSummary(&list[i]); tag != nil {
-			tags = append(tags, *NewTagSummary(&list[i]))
+			tag.NewsCount = counts[tag.ID]
+			tags = append(tags, *tag)
 		}
 	}
 	return tags, nil
@@ -477,6 +1134,15 @@ func (s TagService) byID(ctx context.Context, id int) (*db.Tag, error) {
 	return db, nil
 }
 
+// conflict returns version conflict error with current version of Tag.
+func (s TagService) conflict(ctx context.Context, id int) error {
+	tag, err := s.byID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return VersionConflictError(tag.Version)
+}
+
 // Add adds a Tag from the query.
 //
 //zenrpc:tag Tag
@@ -488,7 +1154,7 @@ func (s TagService) Add(ctx context.Context, tag Tag) (*Tag, error) {
 		return nil, ve.Error()
 	}
 
-	db, err := s.newsRepo.AddTag(ctx, tag.ToDB())
+	db, err := s.newsRepo.AddVersionedTag(ctx, tag.ToDB())
 	if err != nil {
 		return nil, InternalError(err)
 	}
@@ -496,12 +1162,14 @@ func (s TagService) Add(ctx context.Context, tag Tag) (*Tag, error) {
 }
 
 // Update updates the Tag data identified by id from the query.
+// Update is rejected with 409 if Tag was changed since its version from Get, version is required.
 //
 //zenrpc:tags Tag
 //zenrpc:return Tag
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
 func (s TagService) Update(ctx context.Context, tag Tag) (bool, error) {
 	if _, err := s.byID(ctx, tag.ID); err != nil {
 		return false, err
@@ -511,8 +1179,10 @@ func (s TagService) Update(ctx context.Context, tag Tag) (bool, error) {
 		return false, ve.Error()
 	}
 
-	ok, err := s.newsRepo.UpdateTag(ctx, tag.ToDB())
-	if err != nil {
+	ok, err := s.newsRepo.UpdateVersionedTag(ctx, tag.ToDB())
+	if errors.Is(err, db.ErrVersionConflict) {
+		return false, s.conflict(ctx, tag.ID)
+	} else if err != nil {
 		return false, InternalError(err)
 	}
 	return ok, nil
@@ -537,6 +1207,28 @@ func (s TagService) Delete(ctx context.Context, id int) (bool, error) {
 	return ok, err
 }
 
+// UpdateStatus sets status for Tags identified by ids in a single transaction.
+//
+//zenrpc:statusUpdate StatusUpdate
+//zenrpc:return []StatusUpdateResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s TagService) UpdateStatus(ctx context.Context, statusUpdate StatusUpdate) ([]StatusUpdateResult, error) {
+	return updateStatus(ctx, s.db, statusUpdate, func(tx *pg.Tx, id int) (string, error) {
+		repo := s.newsRepo.WithTransaction(tx)
+		tag, err := repo.TagByID(ctx, id)
+		if err != nil {
+			return "", err
+		} else if tag == nil {
+			return StatusUpdateErrorNotFound, nil
+		}
+
+		tag.StatusID = statusUpdate.StatusID
+		_, err = repo.UpdateVersionedTag(ctx, tag, db.WithColumns(db.Columns.Tag.StatusID))
+		return "", err
+	})
+}
+
 // Validate verifies that Tag data is valid.
 //
 //zenrpc:tag Tag
@@ -566,6 +1258,18 @@ func (s TagService) isValid(ctx context.Context, tag Tag, isUpdate bool) Validat
 		return v
 	}
 
+	//check alias unique
+	search := &db.TagSearch{
+		Alias: &tag.Alias,
+		NotID: &tag.ID,
+	}
+	item, err := s.newsRepo.OneTag(ctx, search)
+	if err != nil {
+		v.SetInternalError(err)
+	} else if item != nil {
+		v.Append("alias", FieldErrorUnique)
+	}
+
 	//custom validation starts here
 	return v
 }
@@ -10,11 +10,15 @@ func NewCategory(in *db.Category) *Category {
 	}
 
 	category := &Category{
-		ID:          in.ID,
-		Title:       in.Title,
-		OrderNumber: in.OrderNumber,
-		StatusID:    in.StatusID,
-
+		ID:               in.ID,
+		ParentCategoryID: in.ParentCategoryID,
+		Title:            in.Title,
+		OrderNumber:      in.OrderNumber,
+		CoverImage:       in.CoverImage,
+		Version:          in.Version,
+		StatusID:         in.StatusID,
+
+		Cover:  newVfsHashImagePtr(in.CoverImage),
 		Status: NewStatus(in.StatusID),
 	}
 
@@ -27,14 +31,25 @@ func NewCategorySummary(in *db.Category) *CategorySummary {
 	}
 
 	return &CategorySummary{
-		ID:          in.ID,
-		Title:       in.Title,
-		OrderNumber: in.OrderNumber,
+		ID:               in.ID,
+		ParentCategoryID: in.ParentCategoryID,
+		Title:            in.Title,
+		OrderNumber:      in.OrderNumber,
 
+		Cover:  newVfsHashImagePtr(in.CoverImage),
 		Status: NewStatus(in.StatusID),
 	}
 }
 
+func newCategorySummaries(in []db.Category) []CategorySummary {
+	categories := make([]CategorySummary, 0, len(in))
+	for i := range in {
+		categories = append(categories, *NewCategorySummary(&in[i]))
+	}
+
+	return categories
+}
+
 func NewNews(in *db.News) *News {
 	if in == nil {
 		return nil
@@ -49,10 +64,18 @@ func NewNews(in *db.News) *News {
 		CreatedAt:       in.CreatedAt,
 		UpdatedAt:       in.UpdatedAt,
 		PublicationDate: in.PublicationDate,
+		UnpublishDate:   in.UnpublishDate,
+		PublishedAt:     in.PublishedAt,
+		UnpublishedAt:   in.UnpublishedAt,
 		TagIDs:          in.TagIDs,
+		CoverImage:      in.CoverImage,
+		GalleryImages:   in.GalleryImages,
+		Version:         in.Version,
 		StatusID:        in.StatusID,
 
 		Category: NewCategorySummary(in.Category),
+		Cover:    newVfsHashImagePtr(in.CoverImage),
+		Gallery:  newVfsHashImages(in.GalleryImages),
 		Status:   NewStatus(in.StatusID),
 	}
 
@@ -73,8 +96,12 @@ func NewNewsSummary(in *db.News) *NewsSummary {
 		CreatedAt:       in.CreatedAt,
 		UpdatedAt:       in.UpdatedAt,
 		PublicationDate: in.PublicationDate,
+		UnpublishDate:   in.UnpublishDate,
+		PublishedAt:     in.PublishedAt,
+		UnpublishedAt:   in.UnpublishedAt,
 
 		Category: NewCategorySummary(in.Category),
+		Cover:    newVfsHashImagePtr(in.CoverImage),
 		Status:   NewStatus(in.StatusID),
 	}
 }
@@ -87,6 +114,8 @@ func NewTag(in *db.Tag) *Tag {
 	tag := &Tag{
 		ID:       in.ID,
 		Title:    in.Title,
+		Alias:    in.Alias,
+		Version:  in.Version,
 		StatusID: in.StatusID,
 
 		Status: NewStatus(in.StatusID),
@@ -103,6 +132,7 @@ func NewTagSummary(in *db.Tag) *TagSummary {
 	return &TagSummary{
 		ID:    in.ID,
 		Title: in.Title,
+		Alias: in.Alias,
 
 		Status: NewStatus(in.StatusID),
 	}
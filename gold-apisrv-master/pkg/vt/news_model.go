@@ -8,12 +8,16 @@ import (
 )
 
 type Category struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title" validate:"required,max=256"`
-	OrderNumber int    `json:"orderNumber" validate:"required"`
-	StatusID    int    `json:"statusId" validate:"required,status"`
-
-	Status *Status `json:"status"`
+	ID               int     `json:"id"`
+	ParentCategoryID *int    `json:"parentCategoryId"`
+	Title            string  `json:"title" validate:"required,max=256"`
+	OrderNumber      int     `json:"orderNumber" validate:"required"`
+	CoverImage       *string `json:"coverImage" validate:"omitempty,max=40"`
+	Version          int     `json:"version" validate:"required_with=ID"`
+	StatusID         int     `json:"statusId" validate:"required,status"`
+
+	Cover  *VfsHashImage `json:"cover"`
+	Status *Status       `json:"status"`
 }
 
 func (c *Category) ToDB() *db.Category {
@@ -22,21 +26,27 @@ func (c *Category) ToDB() *db.Category {
 	}
 
 	category := &db.Category{
-		ID:          c.ID,
-		Title:       c.Title,
-		OrderNumber: c.OrderNumber,
-		StatusID:    c.StatusID,
+		ID:               c.ID,
+		ParentCategoryID: c.ParentCategoryID,
+		Title:            c.Title,
+		OrderNumber:      c.OrderNumber,
+		CoverImage:       c.CoverImage,
+		Version:          c.Version,
+		StatusID:         c.StatusID,
 	}
 
 	return category
 }
 
 type CategorySearch struct {
-	ID          *int    `json:"id"`
-	Title       *string `json:"title"`
-	OrderNumber *int    `json:"orderNumber"`
-	StatusID    *int    `json:"statusId"`
-	IDs         []int   `json:"ids"`
+	ID               *int    `json:"id"`
+	ParentCategoryID *int    `json:"parentCategoryId"`
+	Title            *string `json:"title"`
+	OrderNumber      *int    `json:"orderNumber"`
+	StatusID         *int    `json:"statusId"`
+	IDs              []int   `json:"ids"`
+	// advanced filter by categoryFilterFields
+	Filter *FilterGroup `json:"filter"`
 }
 
 func (cs *CategorySearch) ToDB() *db.CategorySearch {
@@ -44,21 +54,46 @@ func (cs *CategorySearch) ToDB() *db.CategorySearch {
 		return nil
 	}
 
-	return &db.CategorySearch{
-		ID:          cs.ID,
-		TitleILike:  cs.Title,
-		OrderNumber: cs.OrderNumber,
-		StatusID:    cs.StatusID,
-		IDs:         cs.IDs,
+	search := &db.CategorySearch{
+		ID:               cs.ID,
+		ParentCategoryID: cs.ParentCategoryID,
+		TitleILike:       cs.Title,
+		OrderNumber:      cs.OrderNumber,
+		StatusID:         cs.StatusID,
+		IDs:              cs.IDs,
+	}
+
+	if cs.Filter != nil {
+		search.WithApply(cs.Filter.ToDB().Q())
+	}
+
+	return search
+}
+
+// validate checks advanced filter.
+func (cs *CategorySearch) validate() error {
+	if cs == nil {
+		return nil
 	}
+
+	return categoryFilterFields.validate(cs.Filter)
 }
 
 type CategorySummary struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title"`
-	OrderNumber int    `json:"orderNumber"`
+	ID               int    `json:"id"`
+	ParentCategoryID *int   `json:"parentCategoryId"`
+	Title            string `json:"title"`
+	OrderNumber      int    `json:"orderNumber"`
 
-	Status *Status `json:"status"`
+	Cover  *VfsHashImage `json:"cover"`
+	Status *Status       `json:"status"`
+}
+
+type CategoryList struct {
+	Items []CategorySummary `json:"items"`
+	Total int               `json:"total"`
+	// cursor of next page, null on the last page
+	NextCursor *string `json:"nextCursor"`
 }
 
 type News struct {
@@ -70,10 +105,18 @@ type News struct {
 	CreatedAt       time.Time  `json:"createdAt"`
 	UpdatedAt       *time.Time `json:"updatedAt"`
 	PublicationDate time.Time  `json:"publicationDate" validate:"required"`
+	UnpublishDate   *time.Time `json:"unpublishDate"`
+	PublishedAt     *time.Time `json:"publishedAt"`
+	UnpublishedAt   *time.Time `json:"unpublishedAt"`
 	TagIDs          []int      `json:"tagIds"`
+	CoverImage      *string    `json:"coverImage" validate:"omitempty,max=40"`
+	GalleryImages   []string   `json:"galleryImages" validate:"dive,required,max=40"`
+	Version         int        `json:"version" validate:"required_with=ID"`
 	StatusID        int        `json:"statusId" validate:"required,status"`
 
 	Category *CategorySummary `json:"category"`
+	Cover    *VfsHashImage    `json:"cover"`
+	Gallery  []VfsHashImage   `json:"gallery"`
 	Status   *Status          `json:"status"`
 }
 
@@ -91,7 +134,11 @@ func (n *News) ToDB() *db.News {
 		CreatedAt:       n.CreatedAt,
 		UpdatedAt:       n.UpdatedAt,
 		PublicationDate: n.PublicationDate,
+		UnpublishDate:   n.UnpublishDate,
 		TagIDs:          n.TagIDs,
+		CoverImage:      n.CoverImage,
+		GalleryImages:   n.GalleryImages,
+		Version:         n.Version,
 		StatusID:        n.StatusID,
 	}
 
@@ -110,6 +157,16 @@ type NewsSearch struct {
 	StatusID        *int       `json:"statusId"`
 	IDs             []int      `json:"ids"`
 	NotID           *int       `json:"notId"`
+	// enabled news waiting for publication date
+	IsScheduled *bool `json:"isScheduled"`
+	// news with passed unpublish date
+	IsExpired *bool `json:"isExpired"`
+	// full-text search by title and content
+	Query *string `json:"query"`
+	// include news from descendants of categoryId
+	WithSubcategories *bool `json:"withSubcategories"`
+	// advanced filter by newsFilterFields
+	Filter *FilterGroup `json:"filter"`
 }
 
 func (ns *NewsSearch) ToDB() *db.NewsSearch {
@@ -117,7 +174,7 @@ func (ns *NewsSearch) ToDB() *db.NewsSearch {
 		return nil
 	}
 
-	return &db.NewsSearch{
+	search := &db.NewsSearch{
 		ID:              ns.ID,
 		TitleILike:      ns.Title,
 		Alias:           ns.Alias,
@@ -130,6 +187,43 @@ func (ns *NewsSearch) ToDB() *db.NewsSearch {
 		IDs:             ns.IDs,
 		NotID:           ns.NotID,
 	}
+
+	if ns.IsScheduled != nil && *ns.IsScheduled {
+		search.WithScheduled()
+	}
+
+	if ns.IsExpired != nil && *ns.IsExpired {
+		search.WithExpired()
+	}
+
+	if ns.HasQuery() {
+		search.WithQuery(*ns.Query)
+	}
+
+	if ns.CategoryID != nil && ns.WithSubcategories != nil && *ns.WithSubcategories {
+		search.CategoryID = nil
+		search.WithCategoryTree(*ns.CategoryID)
+	}
+
+	if ns.Filter != nil {
+		search.WithApply(ns.Filter.ToDB().Q())
+	}
+
+	return search
+}
+
+// validate checks advanced filter.
+func (ns *NewsSearch) validate() error {
+	if ns == nil {
+		return nil
+	}
+
+	return newsFilterFields.validate(ns.Filter)
+}
+
+// HasQuery checks that full-text search query is set.
+func (ns *NewsSearch) HasQuery() bool {
+	return ns != nil && ns.Query != nil && *ns.Query != ""
 }
 
 type NewsSummary struct {
@@ -141,14 +235,29 @@ type NewsSummary struct {
 	CreatedAt       time.Time  `json:"createdAt"`
 	UpdatedAt       *time.Time `json:"updatedAt"`
 	PublicationDate time.Time  `json:"publicationDate"`
+	UnpublishDate   *time.Time `json:"unpublishDate"`
+	PublishedAt     *time.Time `json:"publishedAt"`
+	UnpublishedAt   *time.Time `json:"unpublishedAt"`
+	// content snippet with highlighted words of full-text query
+	Headline *string `json:"headline"`
 
 	Category *CategorySummary `json:"category"`
+	Cover    *VfsHashImage    `json:"cover"`
 	Status   *Status          `json:"status"`
 }
 
+type NewsList struct {
+	Items []NewsSummary `json:"items"`
+	Total int           `json:"total"`
+	// cursor of next page, null on the last page
+	NextCursor *string `json:"nextCursor"`
+}
+
 type Tag struct {
 	ID       int    `json:"id"`
 	Title    string `json:"title" validate:"required,max=256"`
+	Alias    string `json:"alias" validate:"required,alias,max=256"`
+	Version  int    `json:"version" validate:"required_with=ID"`
 	StatusID int    `json:"statusId" validate:"required,status"`
 
 	Status *Status `json:"status"`
@@ -162,6 +271,8 @@ func (t *Tag) ToDB() *db.Tag {
 	tag := &db.Tag{
 		ID:       t.ID,
 		Title:    t.Title,
+		Alias:    t.Alias,
+		Version:  t.Version,
 		StatusID: t.StatusID,
 	}
 
@@ -171,8 +282,11 @@ func (t *Tag) ToDB() *db.Tag {
 type TagSearch struct {
 	ID       *int    `json:"id"`
 	Title    *string `json:"title"`
+	Alias    *string `json:"alias"`
 	StatusID *int    `json:"statusId"`
 	IDs      []int   `json:"ids"`
+	// advanced filter by tagFilterFields
+	Filter *FilterGroup `json:"filter"`
 }
 
 func (ts *TagSearch) ToDB() *db.TagSearch {
@@ -180,17 +294,43 @@ func (ts *TagSearch) ToDB() *db.TagSearch {
 		return nil
 	}
 
-	return &db.TagSearch{
+	search := &db.TagSearch{
 		ID:         ts.ID,
 		TitleILike: ts.Title,
+		Alias:      ts.Alias,
 		StatusID:   ts.StatusID,
 		IDs:        ts.IDs,
 	}
+
+	if ts.Filter != nil {
+		search.WithApply(ts.Filter.ToDB().Q())
+	}
+
+	return search
+}
+
+// validate checks advanced filter.
+func (ts *TagSearch) validate() error {
+	if ts == nil {
+		return nil
+	}
+
+	return tagFilterFields.validate(ts.Filter)
 }
 
 type TagSummary struct {
 	ID    int    `json:"id"`
 	Title string `json:"title"`
+	Alias string `json:"alias"`
+	// count of news with the tag
+	NewsCount int `json:"newsCount"`
 
 	Status *Status `json:"status"`
 }
+
+type TagList struct {
+	Items []TagSummary `json:"items"`
+	Total int          `json:"total"`
+	// cursor of next page, null on the last page
+	NextCursor *string `json:"nextCursor"`
+}
@@ -0,0 +1,122 @@
+package vt
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+)
+
+type NewsRevisionSummary struct {
+	ID         int       `json:"id"`
+	NewsID     int       `json:"newsId"`
+	Title      string    `json:"title"`
+	Alias      string    `json:"alias"`
+	Content    *string   `json:"content"`
+	CategoryID int       `json:"categoryId"`
+	TagIDs     []int     `json:"tagIds"`
+	StatusID   int       `json:"statusId"`
+	CreatedAt  time.Time `json:"createdAt"`
+
+	User   *UserSummary `json:"user"`
+	Status *Status      `json:"status"`
+}
+
+type NewsRevisionDiff struct {
+	// field name
+	Field string `json:"field"`
+	// value in the older revision
+	From string `json:"from"`
+	// value in the newer revision
+	To string `json:"to"`
+}
+
+func NewNewsRevisionSummary(in *db.NewsRevision) *NewsRevisionSummary {
+	if in == nil {
+		return nil
+	}
+
+	return &NewsRevisionSummary{
+		ID:         in.ID,
+		NewsID:     in.NewsID,
+		Title:      in.Title,
+		Alias:      in.Alias,
+		Content:    in.Content,
+		CategoryID: in.CategoryID,
+		TagIDs:     in.TagIDs,
+		StatusID:   in.StatusID,
+		CreatedAt:  in.CreatedAt,
+
+		User:   NewUserSummary(in.User),
+		Status: NewStatus(in.StatusID),
+	}
+}
+
+// newNewsRevision returns snapshot of news made by user.
+func newNewsRevision(in *db.News, user *db.User) *db.NewsRevision {
+	rev := &db.NewsRevision{
+		NewsID:     in.ID,
+		Title:      in.Title,
+		Alias:      in.Alias,
+		Content:    in.Content,
+		CategoryID: in.CategoryID,
+		TagIDs:     in.TagIDs,
+		StatusID:   in.StatusID,
+	}
+
+	if user != nil {
+		rev.UserID = &user.ID
+	}
+
+	return rev
+}
+
+// restoreNewsRevision applies revision fields to news.
+func restoreNewsRevision(news *db.News, rev *db.NewsRevision) {
+	news.Title = rev.Title
+	news.Alias = rev.Alias
+	news.Content = rev.Content
+	news.CategoryID = rev.CategoryID
+	news.TagIDs = rev.TagIDs
+	news.StatusID = rev.StatusID
+}
+
+// newsRevisionDiff returns changed fields between two revisions.
+func newsRevisionDiff(from, to *db.NewsRevision) []NewsRevisionDiff {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{db.Columns.NewsRevision.Title, from.Title, to.Title},
+		{db.Columns.NewsRevision.Alias, from.Alias, to.Alias},
+		{db.Columns.NewsRevision.Content, stringValue(from.Content), stringValue(to.Content)},
+		{db.Columns.NewsRevision.CategoryID, strconv.Itoa(from.CategoryID), strconv.Itoa(to.CategoryID)},
+		{db.Columns.NewsRevision.TagIDs, joinIDs(from.TagIDs), joinIDs(to.TagIDs)},
+		{db.Columns.NewsRevision.StatusID, strconv.Itoa(from.StatusID), strconv.Itoa(to.StatusID)},
+	}
+
+	diff := []NewsRevisionDiff{}
+	for _, f := range fields {
+		if f.from != f.to {
+			diff = append(diff, NewsRevisionDiff{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+
+	return diff
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func joinIDs(ids []int) string {
+	s := make([]string, len(ids))
+	for i := range ids {
+		s[i] = strconv.Itoa(ids[i])
+	}
+	return strings.Join(s, ",")
+}
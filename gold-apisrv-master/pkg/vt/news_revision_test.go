@@ -0,0 +1,38 @@
+package vt
+
+import (
+	"testing"
+
+	"apisrv/pkg/db"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewsRevisionDiff(t *testing.T) {
+	Convey("Test newsRevisionDiff", t, func() {
+		content := "content"
+		from := &db.NewsRevision{Title: "title", Alias: "alias", CategoryID: 1, TagIDs: []int{1, 2}, StatusID: db.StatusDisabled}
+
+		Convey("Same revisions have empty diff", func() {
+			diff := newsRevisionDiff(from, from)
+			So(diff, ShouldNotBeNil)
+			So(diff, ShouldBeEmpty)
+		})
+
+		Convey("Changed fields are returned in order", func() {
+			to := *from
+			to.Title = "new title"
+			to.Content = &content
+			to.TagIDs = []int{2}
+			to.StatusID = db.StatusEnabled
+
+			diff := newsRevisionDiff(from, &to)
+			So(diff, ShouldResemble, []NewsRevisionDiff{
+				{Field: db.Columns.NewsRevision.Title, From: "title", To: "new title"},
+				{Field: db.Columns.NewsRevision.Content, From: "", To: content},
+				{Field: db.Columns.NewsRevision.TagIDs, From: "1,2", To: "2"},
+				{Field: db.Columns.NewsRevision.StatusID, From: "2", To: "1"},
+			})
+		})
+	})
+}
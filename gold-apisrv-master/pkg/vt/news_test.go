@@ -2,10 +2,14 @@ package vt
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vmkteam/zenrpc/v2"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
@@ -119,6 +123,51 @@ func TestDB_NewsService(t *testing.T) {
 				So(err, ShouldNotBeNil)
 				So(u2, ShouldBeNil)
 			})
+
+			Convey("Create news with unpublish date before publication date", func() {
+				unpublishDate := time.Now().Add(-time.Hour)
+				news := News{
+					Title:           "title",
+					Alias:           "alias-unpublish",
+					CategoryID:      1,
+					TagIDs:          []int{1, 2},
+					StatusID:        db.StatusEnabled,
+					PublicationDate: time.Now(),
+					UnpublishDate:   &unpublishDate,
+				}
+				u, err := srv.Add(ctx, news)
+				So(err, ShouldNotBeNil)
+				So(u, ShouldBeNil)
+			})
+
+			Convey("Enable news with passed unpublish date", func() {
+				unpublishDate := time.Now().Add(-time.Hour)
+				news := News{
+					Title:           "title",
+					Alias:           fmt.Sprintf("alias-unpublished-%d", time.Now().UnixNano()),
+					CategoryID:      1,
+					StatusID:        db.StatusEnabled,
+					PublicationDate: unpublishDate.Add(-time.Hour),
+					UnpublishDate:   &unpublishDate,
+				}
+				u, err := srv.Add(ctx, news)
+				So(err, ShouldNotBeNil)
+				So(u, ShouldBeNil)
+
+				news.StatusID = db.StatusDisabled
+				u, err = srv.Add(ctx, news)
+				So(err, ShouldBeNil)
+
+				res, err := srv.UpdateStatus(ctx, StatusUpdate{StatusID: db.StatusEnabled, ObjectIDs: []int{u.ID}})
+				So(err, ShouldBeNil)
+				So(res, ShouldResemble, []StatusUpdateResult{{ID: u.ID, Error: StatusUpdateErrorUnpublished}})
+
+				Reset(func() {
+					if u != nil {
+						_, _ = srv.Delete(ctx, u.ID)
+					}
+				})
+			})
 		})
 
 	})
@@ -134,6 +183,7 @@ func TestDb_TagService(t *testing.T) {
 			Convey("Test CRUD", func() {
 				inTag := Tag{
 					Title:    "unqiue",
+					Alias:    "unqiue",
 					StatusID: db.StatusEnabled,
 				}
 				// Add
@@ -169,18 +219,117 @@ func TestDb_TagService(t *testing.T) {
 				So(ok, ShouldBeTrue)
 
 			})
+
+			Convey("Test List by cursor", func() {
+				prefix := fmt.Sprintf("list-%d-", time.Now().UnixNano())
+				var ids []int
+				for i := 0; i < 3; i++ {
+					tag, err := srv.Add(ctx, Tag{Title: prefix + strconv.Itoa(i), Alias: prefix + strconv.Itoa(i), StatusID: db.StatusEnabled})
+					So(err, ShouldBeNil)
+					ids = append(ids, tag.ID)
+				}
+
+				search := &TagSearch{Title: &prefix}
+				viewOps := &ViewOps{PageSize: 2, SortColumn: db.Columns.Tag.ID, SortDesc: true}
+				page, err := srv.List(ctx, search, viewOps)
+				So(err, ShouldBeNil)
+				So(page.Total, ShouldEqual, 3)
+				So(page.Items, ShouldHaveLength, 2)
+				So(page.Items[0].ID, ShouldEqual, ids[2])
+				So(page.NextCursor, ShouldNotBeNil)
+
+				viewOps.Cursor = *page.NextCursor
+				page, err = srv.List(ctx, search, viewOps)
+				So(err, ShouldBeNil)
+				So(page.Items, ShouldHaveLength, 1)
+				So(page.Items[0].ID, ShouldEqual, ids[0])
+				So(page.NextCursor, ShouldBeNil)
+
+				viewOps.SortDesc = false
+				_, err = srv.List(ctx, search, viewOps)
+				So(err, ShouldNotBeNil)
+
+				Reset(func() {
+					for _, id := range ids {
+						_, _ = srv.Delete(ctx, id)
+					}
+				})
+			})
 		})
 		Convey("Negative testing", func() {
 
 			Convey("Create tag with empty title", func() {
 				tag := Tag{
 					Title:    "",
+					Alias:    "empty-title",
 					StatusID: db.StatusEnabled,
 				}
 				u, err := srv.Add(ctx, tag)
 				So(err, ShouldNotBeNil)
 				So(u, ShouldBeNil)
 			})
+
+			Convey("Create tag with incorrect alias", func() {
+				tag := Tag{
+					Title:    "incorrect alias",
+					Alias:    "incorrect alias",
+					StatusID: db.StatusEnabled,
+				}
+				u, err := srv.Add(ctx, tag)
+				So(err, ShouldNotBeNil)
+				So(u, ShouldBeNil)
+			})
+
+			Convey("Update tag with stale version", func() {
+				alias := fmt.Sprintf("stale-%d", time.Now().UnixNano())
+				tag, err := srv.Add(ctx, Tag{Title: alias, Alias: alias, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+				So(tag.Version, ShouldEqual, 1)
+
+				stale := *tag
+				tag.Title = "first"
+				ok, err := srv.Update(ctx, *tag)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				stale.Title = "second"
+				ok, err = srv.Update(ctx, stale)
+				So(ok, ShouldBeFalse)
+				So(err, ShouldNotBeNil)
+				zerr := err.(*zenrpc.Error)
+				So(zerr.Code, ShouldEqual, http.StatusConflict)
+				So(zerr.Data, ShouldResemble, map[string]int{"version": 2})
+
+				updated, err := srv.GetByID(ctx, tag.ID)
+				So(err, ShouldBeNil)
+				So(updated.Title, ShouldEqual, "first")
+
+				Reset(func() {
+					_, _ = srv.Delete(ctx, tag.ID)
+				})
+			})
+
+			Convey("Partial update of tag with stale version", func() {
+				alias := fmt.Sprintf("stale-partial-%d", time.Now().UnixNano())
+				tag, err := srv.newsRepo.AddVersionedTag(ctx, &db.Tag{Title: alias, Alias: alias, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+
+				stale := *tag
+				tag.StatusID = db.StatusDisabled
+				ok, err := srv.newsRepo.UpdateVersionedTag(ctx, tag, db.WithColumns(db.Columns.Tag.StatusID))
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(tag.Version, ShouldEqual, 2)
+
+				stale.Title = "second"
+				ok, err = srv.newsRepo.UpdateVersionedTag(ctx, &stale, db.WithColumns(db.Columns.Tag.Title))
+				So(ok, ShouldBeFalse)
+				So(err, ShouldEqual, db.ErrVersionConflict)
+
+				Reset(func() {
+					_, _ = srv.Delete(ctx, tag.ID)
+				})
+			})
 		})
 
 	})
@@ -248,6 +397,27 @@ func TestDB_CategoryService(t *testing.T) {
 				So(err, ShouldNotBeNil)
 				So(u, ShouldBeNil)
 			})
+
+			Convey("Move category under its child", func() {
+				parent, err := srv.Add(ctx, Category{Title: "parent", OrderNumber: 1, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+				child, err := srv.Add(ctx, Category{Title: "child", ParentCategoryID: &parent.ID, OrderNumber: 1, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+
+				ok, err := srv.Move(ctx, parent.ID, &child.ID, 1)
+				So(err, ShouldNotBeNil)
+				So(ok, ShouldBeFalse)
+
+				parent.ParentCategoryID = &child.ID
+				ok, err = srv.Update(ctx, *parent)
+				So(err, ShouldNotBeNil)
+				So(ok, ShouldBeFalse)
+
+				Reset(func() {
+					_, _ = srv.newsRepo.DeleteCategory(ctx, child.ID)
+					_, _ = srv.newsRepo.DeleteCategory(ctx, parent.ID)
+				})
+			})
 		})
 
 	})
@@ -0,0 +1,386 @@
+package vt
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/oidc"
+
+	zm "github.com/vmkteam/zenrpc-middleware"
+)
+
+const (
+	oidcStateTTL    = 10 * time.Minute
+	oidcStateCookie = "oidc_state"
+
+	defaultGroupsClaim = "groups"
+	userLoginLen       = 64
+)
+
+var errOIDCSubjectConflict = errors.New("user email is linked to other subject")
+
+// OIDCConfig is an OpenID Connect single sign-on configuration, sso is disabled if Issuer is empty.
+type OIDCConfig struct {
+	oidc.Config
+	AutoProvision   bool              // create users unknown by subject and verified email
+	GroupsClaim     string            // id token claim with user groups, groups by default
+	GroupRoles      map[string]string // role by group, the most privileged role of user groups is synced on every login
+	DefaultRole     string            // role of provisioned users without mapped groups, provisioning is denied if empty
+	SuccessURL      string            // vt url template, {token} in fragment is replaced with auth key, json is returned if empty
+	SecondFactorURL string            // vt url template for users with 2FA, {token} in fragment is replaced with challenge token, json is returned if empty
+}
+
+// Validate checks that tokens are passed in SuccessURL and SecondFactorURL fragments, so they are not sent to servers and are not logged.
+// Both urls are set or both are empty, so users with 2FA get the same kind of response as other users.
+func (c OIDCConfig) Validate() error {
+	if c.SuccessURL == "" && c.SecondFactorURL == "" {
+		return nil
+	}
+
+	if !hasFragmentToken(c.SuccessURL) {
+		return errors.New("oidc success url must contain {token} in fragment")
+	} else if !hasFragmentToken(c.SecondFactorURL) {
+		return errors.New("oidc second factor url must contain {token} in fragment")
+	}
+
+	return nil
+}
+
+// hasFragmentToken checks that url template contains {token} in fragment.
+func hasFragmentToken(tpl string) bool {
+	i := strings.Index(tpl, "#")
+	return i != -1 && strings.Contains(tpl[i:], "{token}")
+}
+
+// groupsClaim returns claim name with user groups.
+func (c OIDCConfig) groupsClaim() string {
+	if c.GroupsClaim == "" {
+		return defaultGroupsClaim
+	}
+
+	return c.GroupsClaim
+}
+
+// roleByGroups returns the most privileged role mapped from groups or empty string.
+func (c OIDCConfig) roleByGroups(groups []string) string {
+	mapped := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		if r, ok := c.GroupRoles[g]; ok {
+			mapped[r] = true
+		}
+	}
+
+	for _, r := range roles {
+		if mapped[r] {
+			return r
+		}
+	}
+
+	return ""
+}
+
+// loginCandidates returns unique logins for new user from preferred username, email and subject.
+func loginCandidates(c *oidc.Claims) []string {
+	var list []string
+	for _, l := range []string{c.PreferredUsername, c.Email, c.Subject} {
+		l = strings.TrimSpace(l)
+		if l == "" || len([]rune(l)) > userLoginLen {
+			continue
+		}
+
+		exists := false
+		for _, e := range list {
+			exists = exists || e == l
+		}
+		if !exists {
+			list = append(list, l)
+		}
+	}
+
+	return list
+}
+
+// newOIDCState returns state and new authorization request with nonce and PKCE verifier.
+func newOIDCState(remember bool) (string, *db.OIDCState, error) {
+	state, err := newSessionToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	nonce, err := newSessionToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return state, &db.OIDCState{
+		ID:         hashSessionToken(state),
+		Nonce:      nonce,
+		Verifier:   verifier,
+		IsRemember: remember,
+		ExpiresAt:  time.Now().Add(oidcStateTTL),
+	}, nil
+}
+
+// OIDCHandler handles OpenID Connect authorization code flow and starts VT sessions.
+type OIDCHandler struct {
+	embedlog.Logger
+	cfg        OIDCConfig
+	provider   *oidc.Provider
+	auth       AuthService
+	commonRepo db.CommonRepo
+}
+
+func NewOIDCHandler(dbo db.DB, logger embedlog.Logger, cfg OIDCConfig, provider *oidc.Provider) *OIDCHandler {
+	commonRepo := db.NewCommonRepo(dbo)
+
+	return &OIDCHandler{
+		Logger:     logger,
+		cfg:        cfg,
+		provider:   provider,
+		auth:       AuthService{Logger: logger, db: dbo, commonRepo: commonRepo},
+		commonRepo: commonRepo,
+	}
+}
+
+// Login redirects to identity provider, remember=true query param starts long session.
+// State is saved in DB and bound to browser by cookie.
+func (h *OIDCHandler) Login(w http.ResponseWriter, r *http.Request) {
+	state, req, err := h.addState(db.WithPrimary(r.Context()), r.URL.Query().Get("remember") == "true")
+	if err != nil {
+		h.Errorf("oidc state error=%s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, h.stateCookie(state, int(oidcStateTTL.Seconds())))
+	http.Redirect(w, r, h.provider.AuthCodeURL(state, req.Nonce, req.Verifier), http.StatusFound)
+}
+
+// addState saves new authorization request to DB, expired requests are deleted.
+func (h *OIDCHandler) addState(ctx context.Context, remember bool) (string, *db.OIDCState, error) {
+	state, req, err := newOIDCState(remember)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err := h.commonRepo.DeleteExpiredOIDCStates(ctx); err != nil {
+		return "", nil, err
+	}
+
+	_, err = h.commonRepo.AddOIDCState(ctx, req)
+	return state, req, err
+}
+
+// stateCookie returns cookie which binds state to browser, it is removed with negative maxAge.
+func (h *OIDCHandler) stateCookie(state string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(h.cfg.RedirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// Callback exchanges authorization code, finds or provisions user by id token claims and starts new session.
+// Auth key is passed to SuccessURL fragment or returned as json.
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		http.Error(w, "authorization failed: "+e, http.StatusUnauthorized)
+		return
+	}
+
+	// state must be started by the same browser, otherwise it is login csrf
+	state := q.Get("state")
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		http.Error(w, "invalid or expired state", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, h.stateCookie("", -1))
+
+	req, err := h.commonRepo.PopOIDCState(db.WithPrimary(r.Context()), hashSessionToken(state))
+	if err != nil {
+		h.Errorf("oidc state error=%s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	} else if req == nil {
+		http.Error(w, "invalid or expired state", http.StatusBadRequest)
+		return
+	}
+
+	ctx := db.WithPrimary(zm.NewUserAgentContext(r.Context(), r.UserAgent()))
+	token, err := h.provider.Exchange(ctx, q.Get("code"), req.Verifier)
+	if err != nil {
+		h.Printf("oidc exchange error=%s", err)
+		http.Error(w, "authorization failed", http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := h.provider.Verify(ctx, token.IDToken, req.Nonce)
+	if err != nil {
+		h.Printf("oidc verify error=%s", err)
+		http.Error(w, "authorization failed", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.userByClaims(ctx, claims)
+	switch {
+	case errors.Is(err, errOIDCSubjectConflict):
+		http.Error(w, "user is linked to other account", http.StatusForbidden)
+		return
+	case err != nil:
+		h.Errorf("oidc user error=%s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	case user == nil || user.StatusID != db.StatusEnabled:
+		http.Error(w, "user not found or disabled", http.StatusForbidden)
+		return
+	}
+
+	// identity provider can't be trusted to check the second factor, so it is required as in auth.login
+	if user.IsTotpEnabled {
+		h.requireSecondFactor(ctx, w, r, user, req.IsRemember)
+		return
+	}
+
+	authKey, err := h.startSession(ctx, user, req.IsRemember)
+	if err != nil {
+		h.Errorf("oidc session error=%s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if h.cfg.SuccessURL != "" {
+		http.Redirect(w, r, tokenURL(h.cfg.SuccessURL, authKey), http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"authKey": authKey})
+}
+
+// requireSecondFactor creates login challenge for user with two-factor authentication, session is started by auth.loginSecondFactor.
+// Challenge token is passed to SecondFactorURL fragment or returned as json with 428 status like auth.login error.
+func (h *OIDCHandler) requireSecondFactor(ctx context.Context, w http.ResponseWriter, r *http.Request, user *db.User, remember bool) {
+	token, err := h.auth.addChallenge(ctx, user.ID, remember)
+	if err != nil {
+		h.Errorf("oidc challenge error=%s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if h.cfg.SecondFactorURL != "" {
+		http.Redirect(w, r, tokenURL(h.cfg.SecondFactorURL, token), http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusPreconditionRequired)
+	_ = json.NewEncoder(w).Encode(map[string]string{"challengeToken": token})
+}
+
+// startSession updates user activity and starts new session.
+func (h *OIDCHandler) startSession(ctx context.Context, user *db.User, remember bool) (string, error) {
+	if _, err := h.commonRepo.DeleteExpiredSessions(ctx, user.ID); err != nil {
+		return "", err
+	}
+
+	if _, err := h.commonRepo.UpdateUserActivity(ctx, user); err != nil {
+		return "", err
+	}
+
+	return h.auth.startSession(ctx, user, remember)
+}
+
+// userByClaims returns user linked to subject or user with verified email, which is linked to subject.
+// Unknown users are provisioned if enabled. Role is synced with mapped groups. It returns nil if user is not found.
+func (h *OIDCHandler) userByClaims(ctx context.Context, c *oidc.Claims) (*db.User, error) {
+	user, err := h.commonRepo.UserByOIDCSubject(ctx, c.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	email := normalizeEmail(&c.Email)
+	if !c.EmailVerified {
+		email = nil
+	}
+
+	// link existing user by verified email
+	if user == nil && email != nil {
+		if user, err = h.commonRepo.OneUser(ctx, &db.UserSearch{Email: email}); err != nil {
+			return nil, err
+		} else if user != nil && user.OIDCSubject != nil {
+			return nil, errOIDCSubjectConflict
+		}
+	}
+
+	role := h.cfg.roleByGroups(c.Strings(h.cfg.groupsClaim()))
+	if user == nil {
+		return h.provisionUser(ctx, c, email, role)
+	}
+
+	var columns []string
+	if user.OIDCSubject == nil {
+		user.OIDCSubject = &c.Subject
+		columns = append(columns, db.Columns.User.OIDCSubject)
+	}
+	if role != "" && role != user.Role {
+		user.Role = role
+		columns = append(columns, db.Columns.User.Role)
+	}
+
+	if len(columns) > 0 {
+		if _, err := h.commonRepo.UpdateVersionedUser(ctx, user, db.WithColumns(columns...)); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// provisionUser adds enabled user without password by claims or returns nil if provisioning is not allowed.
+func (h *OIDCHandler) provisionUser(ctx context.Context, c *oidc.Claims, email *string, role string) (*db.User, error) {
+	if role == "" {
+		role = h.cfg.DefaultRole
+	}
+
+	if !h.cfg.AutoProvision || NewRole(role) == nil {
+		return nil, nil
+	}
+
+	for _, login := range loginCandidates(c) {
+		item, err := h.commonRepo.OneUser(ctx, &db.UserSearch{Login: &login})
+		if err != nil {
+			return nil, err
+		} else if item != nil {
+			continue
+		}
+
+		// empty password hash doesn't match any password, user signs in only by identity provider
+		return h.commonRepo.AddVersionedUser(ctx, &db.User{
+			Login:       login,
+			Email:       email,
+			OIDCSubject: &c.Subject,
+			Role:        role,
+			StatusID:    db.StatusEnabled,
+		})
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,224 @@
+package vt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mailer"
+	"apisrv/pkg/oidc"
+	"apisrv/pkg/oidc/oidctest"
+	"apisrv/pkg/totp"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const oidcTestCallback = "http://localhost/v1/auth/oidc/callback"
+
+func newTestOIDCHandler(idp *oidctest.Server, cfg OIDCConfig) *OIDCHandler {
+	cfg.Config = oidc.Config{Issuer: idp.URL, ClientID: idp.ClientID, ClientSecret: idp.ClientSecret, RedirectURL: oidcTestCallback}
+	provider, err := oidc.New(context.Background(), cfg.Config, nil)
+	So(err, ShouldBeNil)
+
+	return NewOIDCHandler(testDb, embedlog.Logger{}, cfg, provider)
+}
+
+// oidcLogin runs authorization flow through mock identity provider and returns callback response.
+func oidcLogin(h *OIDCHandler, remember bool) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.Login(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/auth/oidc/login?remember=%t", remember), nil))
+	So(w.Code, ShouldEqual, http.StatusFound)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(w.Header().Get("Location"))
+	So(err, ShouldBeNil)
+	resp.Body.Close()
+	So(resp.StatusCode, ShouldEqual, http.StatusFound)
+
+	r := httptest.NewRequest(http.MethodGet, resp.Header.Get("Location"), nil)
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+
+	w = httptest.NewRecorder()
+	h.Callback(w, r)
+	return w
+}
+
+func TestOIDC(t *testing.T) {
+	Convey("Test OpenID Connect helpers", t, func() {
+		Convey("The most privileged mapped role is used", func() {
+			cfg := OIDCConfig{GroupRoles: map[string]string{"staff": db.RoleViewer, "editors": db.RoleEditor, "admins": db.RoleAdmin}}
+			So(cfg.roleByGroups([]string{"staff", "editors"}), ShouldEqual, db.RoleEditor)
+			So(cfg.roleByGroups([]string{"admins", "staff"}), ShouldEqual, db.RoleAdmin)
+			So(cfg.roleByGroups([]string{"other"}), ShouldEqual, "")
+			So(cfg.roleByGroups(nil), ShouldEqual, "")
+			So(cfg.groupsClaim(), ShouldEqual, defaultGroupsClaim)
+		})
+
+		Convey("Login candidates are unique and fit login length", func() {
+			c := &oidc.Claims{Subject: "sub1", PreferredUsername: "user", Email: "user"}
+			So(loginCandidates(c), ShouldResemble, []string{"user", "sub1"})
+
+			c = &oidc.Claims{Subject: string(make([]rune, userLoginLen+1)), Email: "user@example.com"}
+			So(loginCandidates(c), ShouldResemble, []string{"user@example.com"})
+		})
+
+		Convey("Success urls must pass tokens in fragment", func() {
+			secondFactorURL := "http://vt/#/login-2fa?challengeToken={token}"
+			So(OIDCConfig{}.Validate(), ShouldBeNil)
+			So(OIDCConfig{SuccessURL: "http://vt/#/login?authKey={token}", SecondFactorURL: secondFactorURL}.Validate(), ShouldBeNil)
+			So(OIDCConfig{SuccessURL: "http://vt/login?authKey={token}", SecondFactorURL: secondFactorURL}.Validate(), ShouldNotBeNil)
+			So(OIDCConfig{SuccessURL: "http://vt/login?authKey={token}#/", SecondFactorURL: secondFactorURL}.Validate(), ShouldNotBeNil)
+			So(OIDCConfig{SuccessURL: "http://vt/#/login?authKey={token}", SecondFactorURL: "http://vt/login-2fa?challengeToken={token}"}.Validate(), ShouldNotBeNil)
+			So(OIDCConfig{SuccessURL: "http://vt/#/login?authKey={token}"}.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("Callback requires state bound by cookie", func() {
+			h := &OIDCHandler{}
+
+			w := httptest.NewRecorder()
+			h.Callback(w, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/callback?error=access_denied", nil))
+			So(w.Code, ShouldEqual, http.StatusUnauthorized)
+
+			w = httptest.NewRecorder()
+			h.Callback(w, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/callback?code=abc&state=unknown", nil))
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/callback?code=abc&state=unknown", nil)
+			r.AddCookie(h.stateCookie("other", 60))
+			w = httptest.NewRecorder()
+			h.Callback(w, r)
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func TestDB_OIDC(t *testing.T) {
+	Convey("Test OpenID Connect login", t, func() {
+		ctx := context.Background()
+		commonRepo := db.NewCommonRepo(testDb)
+		idp := oidctest.NewServer("vt", "secret")
+		defer idp.Close()
+
+		suffix := time.Now().UnixNano()
+		subject, email := fmt.Sprintf("sub-%d", suffix), fmt.Sprintf("oidc-%d@example.com", suffix)
+		idp.SetUser(subject, map[string]interface{}{"email": email, "email_verified": true, "preferred_username": fmt.Sprintf("oidc-%d", suffix), "groups": []string{"editors"}})
+
+		Convey("Handlers reject invalid requests", func() {
+			h := newTestOIDCHandler(idp, OIDCConfig{})
+
+			w := httptest.NewRecorder()
+			h.Login(w, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/login", nil))
+			So(w.Code, ShouldEqual, http.StatusFound)
+			So(w.Header().Get("Location"), ShouldStartWith, idp.URL+oidctest.AuthorizePath)
+			So(w.Header().Get("Location"), ShouldContainSubstring, "code_challenge_method=S256")
+
+			cookies := w.Result().Cookies()
+			So(cookies, ShouldHaveLength, 1)
+			So(cookies[0].HttpOnly, ShouldBeTrue)
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/callback?code=wrong&state="+cookies[0].Value, nil)
+			r.AddCookie(cookies[0])
+			w = httptest.NewRecorder()
+			h.Callback(w, r)
+			So(w.Code, ShouldEqual, http.StatusUnauthorized)
+
+			// state is single use
+			w = httptest.NewRecorder()
+			h.Callback(w, r)
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Unknown user is denied without provisioning", func() {
+			h := newTestOIDCHandler(idp, OIDCConfig{})
+			So(oidcLogin(h, false).Code, ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("User is provisioned with mapped role and gets auth key", func() {
+			h := newTestOIDCHandler(idp, OIDCConfig{AutoProvision: true, DefaultRole: db.RoleViewer, GroupRoles: map[string]string{"editors": db.RoleEditor}})
+			w := oidcLogin(h, true)
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			var resp map[string]string
+			So(json.Unmarshal(w.Body.Bytes(), &resp), ShouldBeNil)
+			So(resp["authKey"], ShouldHaveLength, sessionTokenBytes*2)
+
+			session, err := sessionByToken(ctx, &commonRepo, resp["authKey"])
+			So(err, ShouldBeNil)
+			So(session, ShouldNotBeNil)
+			So(session.IsRemember, ShouldBeTrue)
+			So(session.User.Login, ShouldEqual, fmt.Sprintf("oidc-%d", suffix))
+			So(*session.User.Email, ShouldEqual, email)
+			So(*session.User.OIDCSubject, ShouldEqual, subject)
+			So(session.User.Role, ShouldEqual, db.RoleEditor)
+
+			Convey("Role is synced on next login", func() {
+				idp.SetUser(subject, map[string]interface{}{"groups": []string{"admins"}})
+				h := newTestOIDCHandler(idp, OIDCConfig{SuccessURL: "http://vt/#/login?authKey={token}", GroupRoles: map[string]string{"admins": db.RoleAdmin}})
+				w := oidcLogin(h, false)
+				So(w.Code, ShouldEqual, http.StatusFound)
+				So(w.Header().Get("Location"), ShouldStartWith, "http://vt/#/login?authKey=")
+
+				user, err := commonRepo.UserByOIDCSubject(ctx, subject)
+				So(err, ShouldBeNil)
+				So(user.Role, ShouldEqual, db.RoleAdmin)
+			})
+
+			Convey("Other subject with same email is denied", func() {
+				idp.SetUser(subject+"-other", map[string]interface{}{"email": email, "email_verified": true})
+				So(oidcLogin(h, false).Code, ShouldEqual, http.StatusForbidden)
+			})
+
+			Convey("User with two-factor authentication must pass second factor", func() {
+				secret, err := totp.NewSecret()
+				So(err, ShouldBeNil)
+				session.User.TotpSecret, session.User.IsTotpEnabled = &secret, true
+				_, err = commonRepo.UpdateVersionedUser(ctx, session.User, db.WithColumns(db.Columns.User.TotpSecret, db.Columns.User.IsTotpEnabled))
+				So(err, ShouldBeNil)
+
+				w := oidcLogin(h, true)
+				So(w.Code, ShouldEqual, http.StatusPreconditionRequired)
+
+				var resp map[string]string
+				So(json.Unmarshal(w.Body.Bytes(), &resp), ShouldBeNil)
+				So(resp["authKey"], ShouldBeEmpty)
+
+				code, err := totp.Code(secret, time.Now())
+				So(err, ShouldBeNil)
+				srv := NewAuthService(testDb, embedlog.Logger{}, mailer.NewLogMailer("", embedlog.Logger{}))
+				authKey, err := srv.LoginSecondFactor(ctx, resp["challengeToken"], code)
+				So(err, ShouldBeNil)
+
+				s, err := sessionByToken(ctx, &commonRepo, authKey)
+				So(err, ShouldBeNil)
+				So(s.UserID, ShouldEqual, session.UserID)
+				So(s.IsRemember, ShouldBeTrue)
+
+				Convey("Challenge token is passed in second factor url", func() {
+					h := newTestOIDCHandler(idp, OIDCConfig{SuccessURL: "http://vt/#/login?authKey={token}", SecondFactorURL: "http://vt/#/login-2fa?challengeToken={token}"})
+					w := oidcLogin(h, false)
+					So(w.Code, ShouldEqual, http.StatusFound)
+					So(w.Header().Get("Location"), ShouldStartWith, "http://vt/#/login-2fa?challengeToken=")
+				})
+			})
+
+			Convey("Disabled user is denied", func() {
+				session.User.StatusID = db.StatusDisabled
+				_, err := commonRepo.UpdateVersionedUser(ctx, session.User, db.WithColumns(db.Columns.User.StatusID))
+				So(err, ShouldBeNil)
+				So(oidcLogin(h, false).Code, ShouldEqual, http.StatusForbidden)
+			})
+
+			Reset(func() {
+				_, _ = commonRepo.DeleteUser(ctx, session.User.ID)
+			})
+		})
+	})
+}
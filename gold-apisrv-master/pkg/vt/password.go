@@ -0,0 +1,68 @@
+package vt
+
+import (
+	"sync"
+
+	"apisrv/pkg/password"
+)
+
+var (
+	// PasswordHasher hashes new user passwords and verifies existing ones.
+	PasswordHasher = password.NewHasher(password.Config{})
+
+	// PasswordPolicy is checked for new user passwords.
+	PasswordPolicy password.Policy
+)
+
+// passwordHash returns hash of plain password by PasswordHasher.
+func passwordHash(plain string) (string, error) {
+	return PasswordHasher.Hash(plain)
+}
+
+// checkPassword verifies plain password, rehash is set if valid hash is outdated.
+func checkPassword(plain, hash string) (ok, rehash bool) {
+	ok, err := PasswordHasher.Verify(plain, hash)
+	if err != nil || !ok {
+		return false, false
+	}
+
+	return true, PasswordHasher.NeedsRehash(hash)
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// checkDummyPassword verifies plain password against fixed hash and always returns false.
+// It is used for unknown logins, so response time does not reveal if login exists.
+func checkDummyPassword(plain string) bool {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = PasswordHasher.Hash("dummy password")
+	})
+
+	_, _ = PasswordHasher.Verify(plain, dummyHash)
+	return false
+}
+
+// checkPasswordPolicy appends password field errors for broken PasswordPolicy rules.
+func checkPasswordPolicy(v *Validator, plain, login string) {
+	min, max := PasswordPolicy.Limits()
+	hasFormatError := false
+	for _, rule := range PasswordPolicy.Check(plain, login) {
+		switch rule {
+		case password.RuleMinLength:
+			v.Append("password", FieldErrorMin, func(c *FieldErrorConstraint) { c.Min = min })
+		case password.RuleMaxLength:
+			v.Append("password", FieldErrorMax, func(c *FieldErrorConstraint) { c.Max = max })
+		case password.RuleBanned:
+			v.Append("password", FieldErrorIncorrect)
+		default:
+			// character classes are reported once
+			if !hasFormatError {
+				v.Append("password", FieldErrorFormat)
+				hasFormatError = true
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package vt
+
+import (
+	"testing"
+
+	"apisrv/pkg/password"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPassword(t *testing.T) {
+	Convey("Test password hashing and policy", t, func() {
+		hasher, policy := PasswordHasher, PasswordPolicy
+		defer func() { PasswordHasher, PasswordPolicy = hasher, policy }()
+
+		PasswordHasher = password.NewHasher(password.Config{Argon2id: password.Argon2idParams{Time: 1, Memory: 1024, Threads: 1}})
+
+		Convey("Outdated bcrypt hash is verified and marked for rehash", func() {
+			ok, rehash := checkPassword("12345", "$2y$14$4IpqlaJ2Rvfgs.wb8f6lPODVLb/Ygl6zw1ZCUKz5CuT6WB6CV44AG")
+			So(ok, ShouldBeTrue)
+			So(rehash, ShouldBeTrue)
+
+			hash, err := passwordHash("12345")
+			So(err, ShouldBeNil)
+			ok, rehash = checkPassword("12345", hash)
+			So(ok, ShouldBeTrue)
+			So(rehash, ShouldBeFalse)
+
+			ok, _ = checkPassword("12345", "")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Policy violations are field errors", func() {
+			PasswordPolicy = password.Policy{MinLength: 10, RequireDigit: true, RequireSymbol: true}
+
+			var v Validator
+			checkPasswordPolicy(&v, "admin", "admin")
+			So(v.Fields(), ShouldResemble, []FieldError{
+				{Field: "password", Error: FieldErrorMin, Constraint: &FieldErrorConstraint{Min: 10}},
+				{Field: "password", Error: FieldErrorFormat},
+				{Field: "password", Error: FieldErrorIncorrect},
+			})
+
+			v = Validator{}
+			checkPasswordPolicy(&v, "correct-horse-1", "admin")
+			So(v.HasErrors(), ShouldBeFalse)
+		})
+	})
+}
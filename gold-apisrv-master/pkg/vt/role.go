@@ -0,0 +1,172 @@
+package vt
+
+import (
+	"context"
+	"errors"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	// anyPermission allows any namespace or method.
+	anyPermission = "*"
+
+	// VfsUploadMethod is a pseudo method of vfs namespace used for HTTP upload handlers.
+	VfsUploadMethod = "upload"
+)
+
+// Permissions is a list of allowed methods by zenrpc namespace, "*" allows any namespace or method.
+type Permissions map[string][]string
+
+// Has checks that method of namespace ns is allowed.
+func (p Permissions) Has(ns, method string) bool {
+	for _, n := range []string{ns, anyPermission} {
+		for _, m := range p[n] {
+			if m == anyPermission || m == method {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+var (
+	// readMethods are common read-only methods of CRUD services.
+	readMethods = []string{"count", "get", "list", "getbyid", "validate"}
+
+	vfsReadMethods = []string{"getfolder", "getfolderbranch", "getfiles", "countfiles", "searchfolderbyfileid",
+		"searchfolderbyfile", "getfavorites", "helpupload", "urlbyhash", "urlbyhashlist", RPC.VfsService.SignedURL}
+)
+
+// rolePermissions is a permission matrix for all user roles.
+var rolePermissions = map[string]Permissions{
+	db.RoleAdmin: {
+		anyPermission: {anyPermission},
+	},
+	db.RoleEditor: {
+		NSAuth:     {anyPermission},
+		NSNews:     {anyPermission},
+		NSCategory: {anyPermission},
+		NSTag:      {anyPermission},
+		NSVFS:      {anyPermission},
+		NSUser:     readMethods,
+		NSRole:     {RPC.RoleService.Get},
+	},
+	db.RoleAuthor: {
+		NSAuth:     {anyPermission},
+		NSNews:     append([]string{RPC.NewsService.Add, RPC.NewsService.Update, RPC.NewsService.Revisions, RPC.NewsService.RevisionDiff}, readMethods...),
+		NSCategory: append([]string{RPC.CategoryService.Tree}, readMethods...),
+		NSTag:      append([]string{RPC.TagService.Add}, readMethods...),
+		NSVFS:      {anyPermission},
+	},
+	db.RoleViewer: {
+		NSAuth:     {anyPermission},
+		NSNews:     append([]string{RPC.NewsService.Revisions, RPC.NewsService.RevisionDiff}, readMethods...),
+		NSCategory: append([]string{RPC.CategoryService.Tree}, readMethods...),
+		NSTag:      readMethods,
+		NSVFS:      vfsReadMethods,
+	},
+}
+
+// roleTitles are human-readable role names.
+var roleTitles = map[string]string{
+	db.RoleAdmin:  "Администратор",
+	db.RoleEditor: "Редактор",
+	db.RoleAuthor: "Автор",
+	db.RoleViewer: "Наблюдатель",
+}
+
+// roles is a list of roles in order of decreasing privileges.
+var roles = []string{db.RoleAdmin, db.RoleEditor, db.RoleAuthor, db.RoleViewer}
+
+// hasPermission checks that role is allowed to call method of namespace ns.
+func hasPermission(role, ns, method string) bool {
+	return rolePermissions[role].Has(ns, method)
+}
+
+type Role struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Permissions Permissions `json:"permissions"`
+}
+
+func NewRole(id string) *Role {
+	p, ok := rolePermissions[id]
+	if !ok {
+		return nil
+	}
+
+	return &Role{
+		ID:          id,
+		Title:       roleTitles[id],
+		Permissions: p,
+	}
+}
+
+type RoleService struct {
+	zenrpc.Service
+	embedlog.Logger
+	commonRepo db.CommonRepo
+}
+
+func NewRoleService(dbo db.DB, logger embedlog.Logger) *RoleService {
+	return &RoleService{
+		Logger:     logger,
+		commonRepo: db.NewCommonRepo(dbo),
+	}
+}
+
+// Get returns all roles with their permissions.
+//
+//zenrpc:return []Role
+func (s RoleService) Get(ctx context.Context) ([]Role, error) {
+	list := make([]Role, 0, len(roles))
+	for _, r := range roles {
+		list = append(list, *NewRole(r))
+	}
+
+	return list, nil
+}
+
+// Assign sets role for the User identified by id. Current user role can't be changed.
+//
+//zenrpc:userId User id
+//zenrpc:role Role id
+//zenrpc:return isUpdated
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:403 Forbidden
+//zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
+func (s RoleService) Assign(ctx context.Context, userId int, role string) (bool, error) {
+	if NewRole(role) == nil {
+		var v Validator
+		v.Append("role", FieldErrorIncorrect)
+		return false, v.Error()
+	}
+
+	user, err := s.commonRepo.UserByID(ctx, userId)
+	if err != nil {
+		return false, InternalError(err)
+	} else if user == nil {
+		return false, ErrNotFound
+	}
+
+	if current := UserFromContext(ctx); current != nil && current.ID == user.ID {
+		return false, ErrForbidden
+	}
+
+	user.Role = role
+	ok, err := s.commonRepo.UpdateVersionedUser(ctx, user, db.WithColumns(db.Columns.User.Role))
+	if errors.Is(err, db.ErrVersionConflict) {
+		return false, userConflict(ctx, s.commonRepo, user.ID)
+	} else if err != nil {
+		return false, InternalError(err)
+	}
+
+	return ok, nil
+}
@@ -0,0 +1,45 @@
+package vt
+
+import (
+	"testing"
+
+	"apisrv/pkg/db"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRolePermissions(t *testing.T) {
+	Convey("Test role permission matrix", t, func() {
+		Convey("Admin can call any method", func() {
+			So(hasPermission(db.RoleAdmin, NSUser, RPC.UserService.Delete), ShouldBeTrue)
+			So(hasPermission(db.RoleAdmin, NSRole, RPC.RoleService.Assign), ShouldBeTrue)
+			So(hasPermission(db.RoleAdmin, NSVFS, VfsUploadMethod), ShouldBeTrue)
+		})
+
+		Convey("Editor manages content but not users", func() {
+			So(hasPermission(db.RoleEditor, NSNews, RPC.NewsService.Delete), ShouldBeTrue)
+			So(hasPermission(db.RoleEditor, NSUser, RPC.UserService.Get), ShouldBeTrue)
+			So(hasPermission(db.RoleEditor, NSUser, RPC.UserService.Add), ShouldBeFalse)
+			So(hasPermission(db.RoleEditor, NSRole, RPC.RoleService.Assign), ShouldBeFalse)
+		})
+
+		Convey("Author writes news only", func() {
+			So(hasPermission(db.RoleAuthor, NSNews, RPC.NewsService.Update), ShouldBeTrue)
+			So(hasPermission(db.RoleAuthor, NSNews, RPC.NewsService.Delete), ShouldBeFalse)
+			So(hasPermission(db.RoleAuthor, NSCategory, RPC.CategoryService.Add), ShouldBeFalse)
+		})
+
+		Convey("Viewer can only read", func() {
+			So(hasPermission(db.RoleViewer, NSNews, RPC.NewsService.Get), ShouldBeTrue)
+			So(hasPermission(db.RoleViewer, NSAuth, RPC.AuthService.Profile), ShouldBeTrue)
+			So(hasPermission(db.RoleViewer, NSNews, RPC.NewsService.Add), ShouldBeFalse)
+			So(hasPermission(db.RoleViewer, NSVFS, VfsUploadMethod), ShouldBeFalse)
+			So(hasPermission(db.RoleViewer, NSUser, RPC.UserService.Get), ShouldBeFalse)
+		})
+
+		Convey("Unknown role has no permissions", func() {
+			So(hasPermission("", NSAuth, RPC.AuthService.Profile), ShouldBeFalse)
+			So(NewRole("root"), ShouldBeNil)
+		})
+	})
+}
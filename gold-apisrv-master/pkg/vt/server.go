@@ -5,6 +5,7 @@ import (
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mailer"
 
 	zm "github.com/vmkteam/zenrpc-middleware"
 	"github.com/vmkteam/zenrpc/v2"
@@ -22,6 +23,10 @@ const (
 	NSNews     = "news"
 	NSTag      = "tag"
 	NSCategory = "category"
+	NSRole     = "role"
+	NSVFS      = "vfs"
+	NSAudit    = "audit"
+	NSAPIKey   = "apikey"
 )
 
 var (
@@ -43,7 +48,7 @@ func httpAsRpcError(code int) *zenrpc.Error {
 }
 
 // New returns new zenrpc Server.
-func New(dbo db.DB, logger embedlog.Logger, isDevel bool) zenrpc.Server {
+func New(dbo db.DB, logger embedlog.Logger, m mailer.Mailer, isDevel bool) zenrpc.Server {
 	rpc := zenrpc.NewServer(zenrpc.Options{
 		ExposeSMD: true,
 		AllowCORS: true,
@@ -53,11 +58,13 @@ func New(dbo db.DB, logger embedlog.Logger, isDevel bool) zenrpc.Server {
 
 	// middleware
 	rpc.Use(
+		primaryMiddleware(),
 		authMiddleware(&commonRepo, logger),
 		zm.WithDevel(isDevel),
 		zm.WithHeaders(),
 		zm.WithSentry(zm.DefaultServerName),
 		zm.WithNoCancelContext(),
+		auditMiddleware(&commonRepo, logger),
 		zm.WithMetrics("vt"),
 		zm.WithTiming(isDevel, allowDebugFn()),
 		zm.WithSQLLogger(dbo.DB, isDevel, allowDebugFn(), allowDebugFn()),
@@ -72,11 +79,14 @@ func New(dbo db.DB, logger embedlog.Logger, isDevel bool) zenrpc.Server {
 
 	// services
 	rpc.RegisterAll(map[string]zenrpc.Invoker{
-		NSAuth:     NewAuthService(dbo, logger),
-		NSUser:     NewUserService(dbo, logger),
+		NSAuth:     NewAuthService(dbo, logger, m),
+		NSUser:     NewUserService(dbo, logger, m),
 		NSNews:     NewNewsService(dbo, logger),
 		NSCategory: NewCategoryService(dbo, logger),
 		NSTag:      NewTagService(dbo, logger),
+		NSRole:     NewRoleService(dbo, logger),
+		NSAudit:    NewAuditService(dbo, logger),
+		NSAPIKey:   NewAPIKeyService(dbo, logger),
 	})
 
 	return rpc
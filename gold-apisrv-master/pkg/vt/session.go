@@ -0,0 +1,109 @@
+package vt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"apisrv/pkg/db"
+
+	zm "github.com/vmkteam/zenrpc-middleware"
+)
+
+const (
+	sessionTTL                 = 24 * time.Hour
+	sessionIdleTimeout         = 2 * time.Hour
+	rememberSessionTTL         = 30 * 24 * time.Hour
+	rememberSessionIdleTimeout = 7 * 24 * time.Hour
+
+	// sessionActivityInterval is a minimal interval between session activity updates.
+	sessionActivityInterval = 90 * time.Second
+
+	sessionTokenBytes = 32
+	sessionDeviceLen  = 64
+	sessionIPLen      = 45
+)
+
+// sessionTimeouts returns absolute and idle session timeouts.
+func sessionTimeouts(remember bool) (ttl, idle time.Duration) {
+	if remember {
+		return rememberSessionTTL, rememberSessionIdleTimeout
+	}
+
+	return sessionTTL, sessionIdleTimeout
+}
+
+// newSessionToken returns random hex session token.
+func newSessionToken() (string, error) {
+	b := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// hashSessionToken returns sha256 of token, only hashes are stored in DB.
+func hashSessionToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// newSession returns new session for user with device, ip and user agent from context.
+func newSession(ctx context.Context, userID int, tokenHash string, remember bool) *db.Session {
+	ttl, idle := sessionTimeouts(remember)
+	now := time.Now()
+
+	return &db.Session{
+		UserID:         userID,
+		TokenHash:      tokenHash,
+		IsRemember:     remember,
+		Device:         cutStringPtr(zm.PlatformFromContext(ctx), sessionDeviceLen),
+		IP:             cutStringPtr(zm.IPFromContext(ctx), sessionIPLen),
+		UserAgent:      cutStringPtr(zm.UserAgentFromContext(ctx), 0),
+		CreatedAt:      now,
+		LastActivityAt: now,
+		ExpiresAt:      now.Add(ttl),
+		IdleExpiresAt:  now.Add(idle),
+	}
+}
+
+// cutStringPtr returns nil for empty string or pointer to string cut to length if length > 0.
+func cutStringPtr(s string, length int) *string {
+	if s == "" {
+		return nil
+	} else if length > 0 && len(s) > length {
+		s = s[:length]
+	}
+
+	return &s
+}
+
+// sessionByToken returns active session with enabled user by raw token or nil.
+func sessionByToken(ctx context.Context, commonRepo *db.CommonRepo, token string) (*db.Session, error) {
+	session, err := commonRepo.ActiveSessionByTokenHash(ctx, hashSessionToken(token))
+	if err != nil || session == nil {
+		return nil, err
+	} else if session.User == nil || session.User.StatusID != db.StatusEnabled {
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+// touchSession updates session and user activity not often than sessionActivityInterval.
+func touchSession(ctx context.Context, commonRepo *db.CommonRepo, session *db.Session) error {
+	if time.Since(session.LastActivityAt) <= sessionActivityInterval {
+		return nil
+	}
+
+	_, idle := sessionTimeouts(session.IsRemember)
+	if _, err := commonRepo.TouchSession(ctx, session, idle); err != nil {
+		return err
+	}
+
+	_, err := commonRepo.UpdateUserActivity(ctx, session.User)
+	return err
+}
@@ -0,0 +1,335 @@
+package vt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/totp"
+
+	zm "github.com/vmkteam/zenrpc-middleware"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	challengeTTL         = 5 * time.Minute
+	challengeMaxAttempts = 5
+
+	recoveryCodesCount = 10
+	recoveryCodeBytes  = 5
+)
+
+// TotpIssuer is an issuer name shown in authenticator apps.
+var TotpIssuer = "apisrv"
+
+var (
+	errTotpEnabled       = zenrpc.NewStringError(http.StatusBadRequest, "two-factor authentication is already enabled")
+	errTotpNotEnabled    = zenrpc.NewStringError(http.StatusBadRequest, "two-factor authentication is not enabled")
+	errInvalidChallenge  = zenrpc.NewStringError(http.StatusBadRequest, "invalid or expired challenge token")
+	errInvalidTotpCode   = zenrpc.NewStringError(http.StatusBadRequest, "invalid code")
+	errTotpNotConfigured = zenrpc.NewStringError(http.StatusBadRequest, "two-factor authentication enrollment is not started")
+)
+
+// newSecondFactorRequiredError returns error for login with enabled 2FA with challenge token in data.
+func newSecondFactorRequiredError(challengeToken string) *zenrpc.Error {
+	return &zenrpc.Error{
+		Code:    http.StatusPreconditionRequired,
+		Message: "second factor required",
+		Data:    map[string]string{"challengeToken": challengeToken},
+	}
+}
+
+type TotpEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+// addChallenge creates second factor challenge for user and returns its token, only token hash is stored in DB.
+func (s AuthService) addChallenge(ctx context.Context, userID int, remember bool) (string, error) {
+	token, err := newSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	challenge := &db.LoginChallenge{
+		UserID:     userID,
+		TokenHash:  hashSessionToken(token),
+		IsRemember: remember,
+		ExpiresAt:  time.Now().Add(challengeTTL),
+	}
+	if _, err := s.commonRepo.DeleteExpiredLoginChallenges(ctx); err != nil {
+		return "", err
+	}
+
+	if _, err := s.commonRepo.AddLoginChallenge(ctx, challenge); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// newRecoveryCodes returns plain recovery codes and their hashes.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < recoveryCodesCount; i++ {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err = rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		code := hex.EncodeToString(b)
+		codes = append(codes, code[:len(code)/2]+"-"+code[len(code)/2:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns sha256 of normalized recovery code.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
+
+// LoginSecondFactor completes login with TOTP or recovery code and starts new session.
+//
+//zenrpc:challengeToken Challenge token from auth.login error data
+//zenrpc:code TOTP or recovery code
+//zenrpc:return User authentication key
+//zenrpc:400 Invalid code or challenge token
+//zenrpc:429 Too many login attempts
+//zenrpc:500 Internal Error
+func (s AuthService) LoginSecondFactor(ctx context.Context, challengeToken, code string) (string, error) {
+	challenge, err := s.commonRepo.ActiveLoginChallengeByHash(ctx, hashSessionToken(challengeToken), challengeMaxAttempts)
+	if err != nil {
+		return "", InternalError(err)
+	} else if challenge == nil {
+		return "", errInvalidChallenge
+	}
+
+	dbu, err := s.commonRepo.UserByID(ctx, challenge.UserID)
+	if err != nil {
+		return "", InternalError(err)
+	} else if dbu == nil || dbu.StatusID != db.StatusEnabled || !dbu.IsTotpEnabled {
+		if _, err := s.commonRepo.DeleteLoginChallenge(ctx, challenge.ID); err != nil {
+			return "", InternalError(err)
+		}
+		return "", errInvalidChallenge
+	}
+
+	ip := zm.IPFromContext(ctx)
+	if d, err := s.limiter.lockedFor(ctx, dbu.Login, ip); err != nil {
+		return "", InternalError(err)
+	} else if d > 0 {
+		incStatLogins(loginResultLocked)
+		return "", newLoginLockedError(d)
+	}
+
+	if ok, err := s.checkSecondFactor(ctx, dbu, code); err != nil {
+		return "", InternalError(err)
+	} else if !ok {
+		if err := s.commonRepo.FailLoginChallenge(ctx, challenge.ID); err != nil {
+			return "", InternalError(err)
+		}
+		if err := s.limiter.fail(ctx, dbu.Login, ip); err != nil {
+			return "", InternalError(err)
+		}
+		incStatLogins(loginResultFailed)
+		return "", errInvalidTotpCode
+	}
+
+	// challenge is completed only once by concurrent requests
+	if ok, err := s.commonRepo.DeleteLoginChallenge(ctx, challenge.ID); err != nil {
+		return "", InternalError(err)
+	} else if !ok {
+		return "", errInvalidChallenge
+	}
+
+	// user is fully authenticated, so login failures are forgotten
+	if err := s.limiter.reset(ctx, dbu.Login); err != nil {
+		return "", InternalError(err)
+	}
+
+	if _, err := s.commonRepo.UpdateUserActivity(ctx, dbu); err != nil {
+		return "", InternalError(err)
+	}
+
+	return s.startSession(ctx, dbu, challenge.IsRemember)
+}
+
+// TotpEnroll starts two-factor authentication enrollment for current user.
+// Enrollment must be confirmed by auth.totpEnable with code from authenticator app.
+//
+//zenrpc:return TotpEnrollment
+//zenrpc:400 Two-factor authentication is already enabled
+//zenrpc:401 Invalid authentication credentials
+//zenrpc:409 Version Conflict
+//zenrpc:500 Internal Error
+func (s AuthService) TotpEnroll(ctx context.Context) (*TotpEnrollment, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthorized
+	} else if user.IsTotpEnabled {
+		return nil, errTotpEnabled
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	user.TotpSecret = &secret
+	if _, err := s.commonRepo.UpdateVersionedUser(ctx, user, db.WithColumns(db.Columns.User.TotpSecret)); errors.Is(err, db.ErrVersionConflict) {
+		return nil, userConflict(ctx, s.commonRepo, user.ID)
+	} else if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return &TotpEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(TotpIssuer, user.Login, secret),
+	}, nil
+}
+
+// TotpEnable confirms enrollment with code from authenticator app and enables two-factor authentication.
+//
+//zenrpc:code TOTP code
+//zenrpc:return Recovery codes, they are shown only once
+//zenrpc:400 Invalid code
+//zenrpc:401 Invalid authentication credentials
+//zenrpc:409 Version Conflict
+//zenrpc:500 Internal Error
+func (s AuthService) TotpEnable(ctx context.Context, code string) ([]string, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthorized
+	} else if user.IsTotpEnabled {
+		return nil, errTotpEnabled
+	} else if user.TotpSecret == nil {
+		return nil, errTotpNotConfigured
+	}
+
+	if ok, err := s.useTotpCode(ctx, user, code); err != nil {
+		return nil, InternalError(err)
+	} else if !ok {
+		return nil, errInvalidTotpCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	user.IsTotpEnabled, user.RecoveryCodes = true, hashes
+	if _, err := s.commonRepo.UpdateVersionedUser(ctx, user, db.WithColumns(db.Columns.User.IsTotpEnabled, db.Columns.User.RecoveryCodes)); errors.Is(err, db.ErrVersionConflict) {
+		return nil, userConflict(ctx, s.commonRepo, user.ID)
+	} else if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return codes, nil
+}
+
+// TotpDisable disables two-factor authentication for current user.
+//
+//zenrpc:code TOTP or recovery code
+//zenrpc:return isDisabled
+//zenrpc:400 Invalid code
+//zenrpc:401 Invalid authentication credentials
+//zenrpc:409 Version Conflict
+//zenrpc:500 Internal Error
+func (s AuthService) TotpDisable(ctx context.Context, code string) (bool, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return false, ErrUnauthorized
+	} else if !user.IsTotpEnabled {
+		return false, errTotpNotEnabled
+	}
+
+	if ok, err := s.checkSecondFactor(ctx, user, code); err != nil {
+		return false, InternalError(err)
+	} else if !ok {
+		return false, errInvalidTotpCode
+	}
+
+	user.TotpSecret, user.IsTotpEnabled, user.RecoveryCodes = nil, false, nil
+	ok, err := s.commonRepo.UpdateVersionedUser(ctx, user, db.WithColumns(db.Columns.User.TotpSecret, db.Columns.User.IsTotpEnabled, db.Columns.User.RecoveryCodes))
+	if errors.Is(err, db.ErrVersionConflict) {
+		return false, userConflict(ctx, s.commonRepo, user.ID)
+	} else if err != nil {
+		return false, InternalError(err)
+	}
+
+	return ok, nil
+}
+
+// TotpRecoveryCodes replaces recovery codes of current user with new ones.
+//
+//zenrpc:code TOTP code
+//zenrpc:return Recovery codes, they are shown only once
+//zenrpc:400 Invalid code
+//zenrpc:401 Invalid authentication credentials
+//zenrpc:409 Version Conflict
+//zenrpc:500 Internal Error
+func (s AuthService) TotpRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthorized
+	} else if !user.IsTotpEnabled || user.TotpSecret == nil {
+		return nil, errTotpNotEnabled
+	}
+
+	if ok, err := s.useTotpCode(ctx, user, code); err != nil {
+		return nil, InternalError(err)
+	} else if !ok {
+		return nil, errInvalidTotpCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	user.RecoveryCodes = hashes
+	if _, err := s.commonRepo.UpdateVersionedUser(ctx, user, db.WithColumns(db.Columns.User.RecoveryCodes)); errors.Is(err, db.ErrVersionConflict) {
+		return nil, userConflict(ctx, s.commonRepo, user.ID)
+	} else if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return codes, nil
+}
+
+// checkSecondFactor validates TOTP code or uses recovery code of the user.
+func (s AuthService) checkSecondFactor(ctx context.Context, user *db.User, code string) (bool, error) {
+	if ok, err := s.useTotpCode(ctx, user, code); err != nil || ok {
+		return ok, err
+	}
+
+	return s.commonRepo.UseUserRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+}
+
+// useTotpCode validates TOTP code of the user, code is rejected if its time step is not after last accepted one.
+func (s AuthService) useTotpCode(ctx context.Context, user *db.User, code string) (bool, error) {
+	if user.TotpSecret == nil {
+		return false, nil
+	}
+
+	step, ok := totp.ValidateStep(*user.TotpSecret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	if ok, err := s.commonRepo.UseUserTotpStep(ctx, user.ID, step); err != nil || !ok {
+		return false, err
+	}
+
+	user.TotpLastStep = &step
+	return true, nil
+}
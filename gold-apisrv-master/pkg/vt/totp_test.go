@@ -0,0 +1,107 @@
+package vt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mailer"
+	"apisrv/pkg/totp"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+func TestSecondFactor(t *testing.T) {
+	Convey("Test second factor helpers", t, func() {
+		Convey("Recovery codes are hashed normalized", func() {
+			codes, hashes, err := newRecoveryCodes()
+			So(err, ShouldBeNil)
+			So(codes, ShouldHaveLength, recoveryCodesCount)
+			So(hashes, ShouldHaveLength, recoveryCodesCount)
+			So(codes[0], ShouldHaveLength, recoveryCodeBytes*2+1)
+
+			So(hashes[3], ShouldEqual, hashRecoveryCode(codes[3]))
+			So(hashes[3], ShouldEqual, hashRecoveryCode(" "+strings.ToUpper(codes[3])))
+			So(hashes[3], ShouldEqual, hashRecoveryCode(strings.ReplaceAll(codes[3], "-", "")))
+		})
+	})
+}
+
+func TestDB_SecondFactor(t *testing.T) {
+	Convey("Test second factor", t, func() {
+		ctx := context.Background()
+		srv := NewAuthService(testDb, embedlog.Logger{}, mailer.NewLogMailer("", embedlog.Logger{}))
+
+		user, err := srv.commonRepo.EnabledUserByLogin(ctx, "admin")
+		So(err, ShouldBeNil)
+		So(user, ShouldNotBeNil)
+
+		secret, err := totp.NewSecret()
+		So(err, ShouldBeNil)
+		codes, hashes, err := newRecoveryCodes()
+		So(err, ShouldBeNil)
+
+		user.TotpSecret, user.IsTotpEnabled, user.RecoveryCodes, user.TotpLastStep = &secret, true, hashes, nil
+		_, err = srv.commonRepo.UpdateVersionedUser(ctx, user, db.WithColumns(db.Columns.User.TotpSecret, db.Columns.User.IsTotpEnabled, db.Columns.User.RecoveryCodes, db.Columns.User.TotpLastStep))
+		So(err, ShouldBeNil)
+
+		challenge := func() string {
+			_, err := srv.Login(ctx, "admin", "12345", false)
+			zerr, ok := err.(*zenrpc.Error)
+			So(ok, ShouldBeTrue)
+			So(zerr.Code, ShouldEqual, http.StatusPreconditionRequired)
+			return zerr.Data.(map[string]string)["challengeToken"]
+		}
+
+		Convey("TOTP code can't be reused", func() {
+			code, err := totp.Code(secret, time.Now())
+			So(err, ShouldBeNil)
+
+			authKey, err := srv.LoginSecondFactor(ctx, challenge(), code)
+			So(err, ShouldBeNil)
+			So(authKey, ShouldNotBeEmpty)
+
+			_, err = srv.LoginSecondFactor(ctx, challenge(), code)
+			So(err, ShouldEqual, errInvalidTotpCode)
+		})
+
+		Convey("Recovery code is single use", func() {
+			authKey, err := srv.LoginSecondFactor(ctx, challenge(), codes[0])
+			So(err, ShouldBeNil)
+			So(authKey, ShouldNotBeEmpty)
+
+			_, err = srv.LoginSecondFactor(ctx, challenge(), codes[0])
+			So(err, ShouldEqual, errInvalidTotpCode)
+		})
+
+		Convey("Challenge is completed once and limited by attempts", func() {
+			token := challenge()
+			_, err := srv.LoginSecondFactor(ctx, token, codes[1])
+			So(err, ShouldBeNil)
+			_, err = srv.LoginSecondFactor(ctx, token, codes[2])
+			So(err, ShouldEqual, errInvalidChallenge)
+
+			token = challenge()
+			for i := 0; i < challengeMaxAttempts; i++ {
+				_, err = srv.LoginSecondFactor(ctx, token, "000000")
+				So(err, ShouldNotBeNil)
+			}
+			_, err = srv.LoginSecondFactor(ctx, token, codes[2])
+			So(err, ShouldEqual, errInvalidChallenge)
+		})
+
+		Reset(func() {
+			// user version is changed by login with password rehash
+			user, err := srv.commonRepo.UserByID(ctx, user.ID)
+			So(err, ShouldBeNil)
+			user.TotpSecret, user.IsTotpEnabled, user.RecoveryCodes, user.TotpLastStep = nil, false, nil, nil
+			_, _ = srv.commonRepo.UpdateVersionedUser(ctx, user, db.WithColumns(db.Columns.User.TotpSecret, db.Columns.User.IsTotpEnabled, db.Columns.User.RecoveryCodes, db.Columns.User.TotpLastStep))
+			_ = srv.limiter.reset(ctx, "admin")
+		})
+	})
+}
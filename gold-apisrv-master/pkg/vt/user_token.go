@@ -0,0 +1,297 @@
+package vt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/mailer"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	passwordResetTTL = time.Hour
+	inviteTTL        = 7 * 24 * time.Hour
+
+	// passwordResetInterval is a minimal interval between password reset emails for the user.
+	passwordResetInterval = time.Minute
+)
+
+var (
+	// PasswordResetURL is a password reset page url template, {token} is replaced with reset token.
+	PasswordResetURL = "/reset-password?token={token}"
+
+	// InviteURL is an invitation page url template, {token} is replaced with invite token.
+	InviteURL = "/accept-invite?token={token}"
+)
+
+var errInvalidUserToken = zenrpc.NewStringError(http.StatusBadRequest, "invalid or expired token")
+
+type UserInvite struct {
+	Login string `json:"login" validate:"required,max=64"`
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" validate:"required,role"`
+}
+
+// normalizeEmail returns trimmed lowercase email or nil for empty one.
+func normalizeEmail(email *string) *string {
+	if email == nil {
+		return nil
+	}
+
+	e := strings.ToLower(strings.TrimSpace(*email))
+	if e == "" {
+		return nil
+	}
+
+	return &e
+}
+
+// tokenURL replaces {token} placeholder in url template.
+func tokenURL(tpl, token string) string {
+	return strings.ReplaceAll(tpl, "{token}", url.QueryEscape(token))
+}
+
+// addUserToken replaces unused user tokens of kind with new one and returns raw token, only hash is stored in DB.
+func addUserToken(ctx context.Context, commonRepo db.CommonRepo, userID int, kind string, ttl time.Duration) (string, error) {
+	token, err := newSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := commonRepo.DeleteUnusedUserTokens(ctx, userID, kind); err != nil {
+		return "", err
+	}
+
+	_, err = commonRepo.AddUserToken(ctx, &db.UserToken{
+		UserID:    userID,
+		Kind:      kind,
+		TokenHash: hashSessionToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+
+	return token, err
+}
+
+// activeUserToken returns not used and not expired user token of kind with its user or nil.
+func activeUserToken(ctx context.Context, commonRepo db.CommonRepo, kind, token string) (*db.UserToken, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	ut, err := commonRepo.ActiveUserTokenByHash(ctx, kind, hashSessionToken(token))
+	if err != nil || ut == nil || ut.User == nil || ut.User.StatusID == db.StatusDeleted {
+		return nil, err
+	}
+
+	return ut, nil
+}
+
+// useUserTokenWithPassword marks token as used and sets new user password and status in one transaction.
+// It returns false if token was already used.
+func useUserTokenWithPassword(ctx context.Context, dbo db.DB, ut *db.UserToken, password string, statusID int) (bool, error) {
+	p, err := passwordHash(password)
+	if err != nil {
+		return false, err
+	}
+
+	var used bool
+	err = dbo.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		repo := db.NewCommonRepo(dbo).WithTransaction(tx)
+		if used, err = repo.UseUserToken(ctx, ut); err != nil || !used {
+			return err
+		}
+
+		ut.User.Password, ut.User.StatusID = p, statusID
+		if _, err := repo.UpdateVersionedUser(ctx, ut.User, db.WithColumns(db.Columns.User.Password, db.Columns.User.StatusID)); err != nil {
+			return err
+		}
+
+		_, err := repo.DeleteUserSessions(ctx, ut.User.ID, nil)
+		return err
+	})
+
+	return used, err
+}
+
+// passwordResetMessage returns password reset email.
+func passwordResetMessage(u *db.User, token string) mailer.Message {
+	return mailer.Message{
+		To:      []string{*u.Email},
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Hello, %s!\n\nTo set a new password follow the link:\n%s\n\nThe link is valid for %s. "+
+			"If you did not request a password reset, ignore this email.\n", u.Login, tokenURL(PasswordResetURL, token), passwordResetTTL),
+	}
+}
+
+// inviteMessage returns invitation email.
+func inviteMessage(u *db.User, token string) mailer.Message {
+	return mailer.Message{
+		To:      []string{*u.Email},
+		Subject: "Invitation",
+		Body: fmt.Sprintf("Hello, %s!\n\nYou have been invited. To set a password and sign in follow the link:\n%s\n\n"+
+			"The link is valid for %s.\n", u.Login, tokenURL(InviteURL, token), inviteTTL),
+	}
+}
+
+// RequestPasswordReset sends password reset link to the email of enabled user.
+// It returns true even if user is not found or email is not sent.
+//
+//zenrpc:email User email
+//zenrpc:return isRequested
+//zenrpc:400 Validation Error
+//zenrpc:500 Internal Error
+func (s AuthService) RequestPasswordReset(ctx context.Context, email string) (bool, error) {
+	e := normalizeEmail(&email)
+	if e == nil {
+		var v Validator
+		v.Append("email", FieldErrorRequired)
+		return false, v.Error()
+	}
+
+	user, err := s.commonRepo.EnabledUserByEmail(ctx, *e)
+	if err != nil {
+		return false, InternalError(err)
+	} else if user == nil {
+		return true, nil
+	}
+
+	// don't send emails too often
+	kind := db.UserTokenPasswordReset
+	last, err := s.commonRepo.UserTokensByFilters(ctx, &db.UserTokenSearch{UserID: &user.ID, Kind: &kind}, db.PagerOne, s.commonRepo.DefaultUserTokenSort())
+	if err != nil {
+		return false, InternalError(err)
+	} else if len(last) > 0 && time.Since(last[0].CreatedAt) < passwordResetInterval {
+		return true, nil
+	}
+
+	token, err := addUserToken(ctx, s.commonRepo, user.ID, kind, passwordResetTTL)
+	if err != nil {
+		return false, InternalError(err)
+	}
+
+	// error is not returned, otherwise it reveals that email exists
+	if err := s.mailer.Send(ctx, passwordResetMessage(user, token)); err != nil {
+		s.Errorf("send password reset userId=%d error=%s", user.ID, err)
+	}
+
+	return true, nil
+}
+
+// ResetPassword sets new password by password reset token and ends all user sessions.
+//
+//zenrpc:token Password reset token
+//zenrpc:password New user password
+//zenrpc:return isReset
+//zenrpc:400 Invalid or expired token or validation error
+//zenrpc:500 Internal Error
+func (s AuthService) ResetPassword(ctx context.Context, token, password string) (bool, error) {
+	ut, err := activeUserToken(ctx, s.commonRepo, db.UserTokenPasswordReset, token)
+	if err != nil {
+		return false, InternalError(err)
+	} else if ut == nil || ut.User.StatusID != db.StatusEnabled {
+		return false, errInvalidUserToken
+	}
+
+	var v Validator
+	if checkPasswordPolicy(&v, password, ut.User.Login); v.HasErrors() {
+		return false, v.Error()
+	}
+
+	ok, err := useUserTokenWithPassword(ctx, s.db, ut, password, ut.User.StatusID)
+	if err != nil {
+		return false, InternalError(err)
+	} else if !ok {
+		return false, errInvalidUserToken
+	}
+
+	if err := s.limiter.reset(ctx, ut.User.Login); err != nil {
+		return false, InternalError(err)
+	}
+
+	return true, nil
+}
+
+// AcceptInvite sets password by invite token, enables invited user and starts new session.
+//
+//zenrpc:token Invite token
+//zenrpc:password User password
+//zenrpc:return User authentication key
+//zenrpc:400 Invalid or expired token or validation error
+//zenrpc:500 Internal Error
+func (s AuthService) AcceptInvite(ctx context.Context, token, password string) (string, error) {
+	ut, err := activeUserToken(ctx, s.commonRepo, db.UserTokenInvite, token)
+	if err != nil {
+		return "", InternalError(err)
+	} else if ut == nil || ut.User.StatusID != db.StatusDisabled {
+		return "", errInvalidUserToken
+	}
+
+	var v Validator
+	if checkPasswordPolicy(&v, password, ut.User.Login); v.HasErrors() {
+		return "", v.Error()
+	}
+
+	ok, err := useUserTokenWithPassword(ctx, s.db, ut, password, db.StatusEnabled)
+	if err != nil {
+		return "", InternalError(err)
+	} else if !ok {
+		return "", errInvalidUserToken
+	}
+
+	if _, err := s.commonRepo.UpdateUserActivity(ctx, ut.User); err != nil {
+		return "", InternalError(err)
+	}
+
+	return s.startSession(ctx, ut.User, false)
+}
+
+// Invite adds disabled User and sends invitation email, user is enabled after auth.acceptInvite.
+//
+//zenrpc:invite UserInvite
+//zenrpc:return User
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s UserService) Invite(ctx context.Context, invite UserInvite) (*User, error) {
+	var v Validator
+	if v.CheckBasic(ctx, invite); v.HasErrors() {
+		return nil, v.Error()
+	}
+
+	email := normalizeEmail(&invite.Email)
+	if s.checkUnique(ctx, &v, 0, invite.Login, email); v.HasErrors() {
+		return nil, v.Error()
+	}
+
+	// user and token are added only if invitation is sent
+	user := &db.User{
+		Login:    invite.Login,
+		Email:    email,
+		Role:     invite.Role,
+		StatusID: db.StatusDisabled,
+	}
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		repo := s.commonRepo.WithTransaction(tx)
+		if _, err := repo.AddVersionedUser(ctx, user); err != nil {
+			return err
+		}
+
+		token, err := addUserToken(ctx, repo, user.ID, db.UserTokenInvite, inviteTTL)
+		if err != nil {
+			return err
+		}
+
+		return s.mailer.Send(ctx, inviteMessage(user, token))
+	})
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return NewUser(user), nil
+}
@@ -0,0 +1,113 @@
+package vt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mailer"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUserToken(t *testing.T) {
+	Convey("Test user token helpers", t, func() {
+		Convey("Email is normalized", func() {
+			e := " User@Example.COM "
+			So(*normalizeEmail(&e), ShouldEqual, "user@example.com")
+
+			e = " "
+			So(normalizeEmail(&e), ShouldBeNil)
+			So(normalizeEmail(nil), ShouldBeNil)
+		})
+
+		Convey("Token is placed to url template", func() {
+			So(tokenURL("https://vt.example.com/#/reset?token={token}", "a b"), ShouldEqual, "https://vt.example.com/#/reset?token=a+b")
+		})
+
+		Convey("Messages contain token links", func() {
+			email := "user@example.com"
+			u := &db.User{Login: "user", Email: &email}
+
+			msg := passwordResetMessage(u, "abc")
+			So(msg.To, ShouldResemble, []string{email})
+			So(msg.Body, ShouldContainSubstring, tokenURL(PasswordResetURL, "abc"))
+
+			msg = inviteMessage(u, "def")
+			So(msg.Body, ShouldContainSubstring, tokenURL(InviteURL, "def"))
+		})
+
+		Convey("Token methods are public", func() {
+			So(isPublicAuthMethod(RPC.AuthService.ResetPassword), ShouldBeTrue)
+			So(isPublicAuthMethod(RPC.AuthService.AcceptInvite), ShouldBeTrue)
+			So(isPublicAuthMethod(RPC.AuthService.ChangePassword), ShouldBeFalse)
+		})
+	})
+}
+
+// failMailer is a mailer which can't send messages.
+type failMailer struct{}
+
+func (failMailer) Send(context.Context, mailer.Message) error {
+	return errors.New("mail server is unavailable")
+}
+
+func TestDB_UserInvite(t *testing.T) {
+	Convey("Test user invite", t, func() {
+		ctx := context.Background()
+		login := fmt.Sprintf("invite%d", time.Now().UnixNano())
+		invite := UserInvite{Login: login, Email: login + "@example.com", Role: db.RoleViewer}
+
+		Convey("User is not added if invitation is not sent", func() {
+			srv := NewUserService(testDb, embedlog.Logger{}, failMailer{})
+			u, err := srv.Invite(ctx, invite)
+			So(err, ShouldNotBeNil)
+			So(u, ShouldBeNil)
+
+			dbu, err := srv.commonRepo.OneUser(ctx, &db.UserSearch{Login: &login})
+			So(err, ShouldBeNil)
+			So(dbu, ShouldBeNil)
+		})
+
+		Convey("Invited user is disabled", func() {
+			srv := NewUserService(testDb, embedlog.Logger{}, mailer.NewLogMailer("", embedlog.Logger{}))
+			u, err := srv.Invite(ctx, invite)
+			So(err, ShouldBeNil)
+			So(u.StatusID, ShouldEqual, db.StatusDisabled)
+
+			Reset(func() {
+				_, _ = srv.commonRepo.DeleteUser(ctx, u.ID)
+			})
+		})
+	})
+}
+
+func TestDB_RequestPasswordReset(t *testing.T) {
+	Convey("Test password reset request", t, func() {
+		ctx := context.Background()
+		srv := NewAuthService(testDb, embedlog.Logger{}, failMailer{})
+
+		login := fmt.Sprintf("reset%d", time.Now().UnixNano())
+		email := login + "@example.com"
+		user, err := srv.commonRepo.AddVersionedUser(ctx, &db.User{Login: login, Email: &email, Role: db.RoleViewer, StatusID: db.StatusEnabled})
+		So(err, ShouldBeNil)
+
+		Convey("Mail error doesn't reveal that email exists", func() {
+			ok, err := srv.RequestPasswordReset(ctx, email)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			ok, err = srv.RequestPasswordReset(ctx, "unknown-"+email)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Reset(func() {
+			_, _ = srv.commonRepo.DeleteUser(ctx, user.ID)
+		})
+	})
+}
@@ -26,6 +26,7 @@ const (
 const (
 	CustomStatusTag = "status"
 	CustomAliasTag  = "alias"
+	CustomRoleTag   = "role"
 
 	fieldPathSeparator = "."
 )
@@ -34,10 +35,13 @@ var errorMap = map[string]string{
 	"max":           FieldErrorMax,
 	"min":           FieldErrorMin,
 	"required":      FieldErrorRequired,
+	"required_with": FieldErrorRequired,
 	"gt":            FieldErrorRequired,
 	"len":           FieldErrorLen,
+	"email":         FieldErrorFormat,
 	CustomStatusTag: FieldErrorIncorrect,
 	CustomAliasTag:  FieldErrorFormat,
+	CustomRoleTag:   FieldErrorIncorrect,
 }
 
 var validate = newPlaygroundValidator()
@@ -53,6 +57,7 @@ func newPlaygroundValidator() *validator.Validate {
 	})
 	_ = validate.RegisterValidationCtx(CustomStatusTag, validateStatus)
 	_ = validate.RegisterValidationCtx(CustomAliasTag, validateAlias)
+	_ = validate.RegisterValidationCtx(CustomRoleTag, validateRole)
 	return validate
 }
 
@@ -67,6 +72,11 @@ func validateAlias(_ context.Context, fl validator.FieldLevel) bool {
 	return aliasRegex.Match([]byte(fl.Field().String()))
 }
 
+func validateRole(_ context.Context, fl validator.FieldLevel) bool {
+	_, ok := rolePermissions[fl.Field().String()]
+	return ok
+}
+
 type FieldError struct {
 	Field      string                `json:"field"`
 	Error      string                `json:"error"`
@@ -183,3 +193,8 @@ func InternalError(err error) *zenrpc.Error {
 func ValidationError(fieldErrors []FieldError) *zenrpc.Error {
 	return &zenrpc.Error{Code: http.StatusBadRequest, Data: fieldErrors, Message: "Validation err"}
 }
+
+// VersionConflictError returns error for update of item changed by someone else, data has current version of item.
+func VersionConflictError(version int) *zenrpc.Error {
+	return &zenrpc.Error{Code: http.StatusConflict, Data: map[string]int{"version": version}, Message: "Version conflict"}
+}
@@ -1,23 +1,10 @@
-//nolint:unused
 package vt
 
 import (
-	"path"
+	"context"
 
 	"apisrv/pkg/db"
-)
-
-const (
-	mediaNormalImage = "normal"
-	mediaBigImage    = "big"
-	mediaMediumImage = "medium"
-	mediaSmallImage  = "small"
-	media128Image    = "128"
-	media256Image    = "256"
-	media512Image    = "512"
-	media768Image    = "768"
-	media1024Image   = "1024"
-	media2048Image   = "2048"
+	"apisrv/pkg/media"
 )
 
 type VfsFileSummary struct {
@@ -29,10 +16,10 @@ type VfsFileSummary struct {
 type VfsHashImage struct {
 	Hash    string `json:"hash"`
 	WebPath string `json:"webPath"`
+	// web paths by preset size
+	Sizes map[string]string `json:"sizes"`
 }
 
-var WebPath string
-
 // NewVfsFileSummary converts db.VfsFile to VfsFileSummary.
 func NewVfsFileSummary(in *db.VfsFile) *VfsFileSummary {
 	if in == nil {
@@ -42,7 +29,7 @@ func NewVfsFileSummary(in *db.VfsFile) *VfsFileSummary {
 	return &VfsFileSummary{
 		ID:   in.ID,
 		Name: in.Title,
-		Path: WebPath + in.Path,
+		Path: media.WebPath + in.Path,
 	}
 }
 
@@ -54,31 +41,43 @@ func newVfsHashImage(in string) *VfsHashImage {
 
 	return &VfsHashImage{
 		Hash:    in,
-		WebPath: mediaImage(in, media256Image),
+		WebPath: media.ImagePath(in, media.DefaultImage),
+		Sizes:   media.ImageSizePaths(in),
+	}
+}
+
+// newVfsHashImagePtr converts *string to VfsHashImage.
+func newVfsHashImagePtr(in *string) *VfsHashImage {
+	if in == nil {
+		return nil
 	}
+
+	return newVfsHashImage(*in)
 }
 
-// newVfsHashImages converts []string to []VfsHashImage.
+// newVfsHashImages converts []string to []VfsHashImage, empty hashes are skipped.
 func newVfsHashImages(in []string) (out []VfsHashImage) {
-	out = make([]VfsHashImage, len(in))
+	out = make([]VfsHashImage, 0, len(in))
 
-	for i, value := range in {
-		out[i] = *newVfsHashImage(value)
+	for _, value := range in {
+		if img := newVfsHashImage(value); img != nil {
+			out = append(out, *img)
+		}
 	}
 
 	return
 }
 
-// mediaImage returns full path for vfs image.
-func mediaImage(hash, size string) string {
-	if len(hash) != 32 {
-		return ""
+// validateVfsHashes appends field error if any of hashes is not found in vfsHashes.
+func validateVfsHashes(ctx context.Context, v *Validator, repo db.VfsRepo, field string, hashes ...string) {
+	if len(hashes) == 0 {
+		return
 	}
 
-	return WebPath + path.Join(
-		size,
-		hash[:1],
-		hash[1:3],
-		hash+".jpg",
-	)
+	missing, err := repo.MissingVfsHashes(ctx, hashes)
+	if err != nil {
+		v.SetInternalError(err)
+	} else if len(missing) != 0 {
+		v.Append(field, FieldErrorIncorrect)
+	}
 }
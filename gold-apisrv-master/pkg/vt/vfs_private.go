@@ -0,0 +1,225 @@
+package vt
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/media"
+
+	"github.com/vmkteam/zenrpc/v2"
+	"github.com/vmkteam/zenrpc/v2/smd"
+)
+
+const (
+	vfsExpiresParam   = "expires"
+	vfsSignatureParam = "signature"
+
+	defaultVfsSignedURLTTL = time.Hour
+	maxVfsSignedURLTTL     = 7 * 24 * time.Hour
+)
+
+// VfsURLSecret is a HMAC key of signed vfs urls, files of private folders are not served if it is empty.
+var VfsURLSecret []byte
+
+var errVfsSigningDisabled = zenrpc.NewStringError(http.StatusNotImplemented, "signed urls are not configured")
+
+// vfsSignature returns hex HMAC-SHA256 of file path and expiration time.
+func vfsSignature(p string, expires int64) string {
+	mac := hmac.New(sha256.New, VfsURLSecret)
+	mac.Write([]byte(p + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signedVfsURL returns url of file path relative to media.WebPath, which is valid until expires.
+func signedVfsURL(p string, expires time.Time) string {
+	q := url.Values{
+		vfsExpiresParam:   {strconv.FormatInt(expires.Unix(), 10)},
+		vfsSignatureParam: {vfsSignature(p, expires.Unix())},
+	}
+
+	return media.WebPath + p + "?" + q.Encode()
+}
+
+// isValidVfsSignature checks that query has not expired signature of file path.
+func isValidVfsSignature(p string, q url.Values, now time.Time) bool {
+	if len(VfsURLSecret) == 0 {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(q.Get(vfsExpiresParam), 10, 64)
+	if err != nil || now.Unix() >= expires {
+		return false
+	}
+
+	return hmac.Equal([]byte(q.Get(vfsSignatureParam)), []byte(vfsSignature(p, expires)))
+}
+
+// vfsFilePaths returns possible file paths of request path: file path is relative to namespace directory,
+// so request path and its suffixes after every directory are returned.
+func vfsFilePaths(p string) []string {
+	paths := []string{p}
+	for i := strings.Index(p, "/"); i != -1; i = strings.Index(p, "/") {
+		p = p[i+1:]
+		paths = append(paths, p)
+	}
+
+	return paths
+}
+
+// privateVfsFile returns file of private folder or nil.
+func privateVfsFile(files []db.VfsFile) *db.VfsFile {
+	for i := range files {
+		if files[i].Folder != nil && files[i].Folder.IsPrivate {
+			return &files[i]
+		}
+	}
+
+	return nil
+}
+
+// VfsFileHandler serves files by next, files of private folders are served only by signed urls.
+// Files are found by path, so renamed and hash uploaded files are checked too. Request path must be relative to media.WebPath.
+func VfsFileHandler(repo db.VfsRepo, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		files, err := repo.VfsFilesWithFolderByPaths(r.Context(), vfsFilePaths(p))
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		// file is denied if it is in any private folder
+		if file := privateVfsFile(files); file != nil {
+			if !isValidVfsSignature(file.Path, r.URL.Query(), time.Now()) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Cache-Control", "private, no-store")
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+type VfsSignedURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// VfsService adds private folders and signed urls to vfs namespace, other methods are served by vfs.Service.
+type VfsService struct {
+	zenrpc.Service
+	embedlog.Logger
+	vfsRepo db.VfsRepo
+}
+
+func NewVfsService(dbo db.DB, logger embedlog.Logger) *VfsService {
+	return &VfsService{
+		Logger:  logger,
+		vfsRepo: db.NewVfsRepo(dbo),
+	}
+}
+
+// SignedURL returns url of the file, which is valid for ttl seconds. It is required for files of private folders.
+//
+//zenrpc:fileId File id
+//zenrpc:ttl=3600 Url lifetime in seconds, max - 604800
+//zenrpc:return VfsSignedURL
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+//zenrpc:500 Internal Error
+//zenrpc:501 Signed urls are not configured
+func (s VfsService) SignedURL(ctx context.Context, fileId int, ttl *int) (*VfsSignedURL, error) {
+	if len(VfsURLSecret) == 0 {
+		return nil, errVfsSigningDisabled
+	}
+
+	d := defaultVfsSignedURLTTL
+	if ttl != nil {
+		d = time.Duration(*ttl) * time.Second
+	}
+	if d <= 0 || d > maxVfsSignedURLTTL {
+		var v Validator
+		v.Append("ttl", FieldErrorIncorrect)
+		return nil, v.Error()
+	}
+
+	file, err := s.vfsRepo.VfsFileByID(ctx, fileId)
+	if err != nil {
+		return nil, InternalError(err)
+	} else if file == nil {
+		return nil, ErrNotFound
+	}
+
+	expires := time.Now().Add(d).Truncate(time.Second)
+	return &VfsSignedURL{URL: signedVfsURL(file.Path, expires), ExpiresAt: expires}, nil
+}
+
+// SetFolderPrivate sets private flag of the folder, files of private folders are served only by signed urls.
+//
+//zenrpc:folderId Folder id
+//zenrpc:isPrivate Private flag
+//zenrpc:return isUpdated
+//zenrpc:404 Not Found
+//zenrpc:500 Internal Error
+func (s VfsService) SetFolderPrivate(ctx context.Context, folderId int, isPrivate bool) (bool, error) {
+	folder, err := s.vfsRepo.VfsFolderByID(ctx, folderId)
+	if err != nil {
+		return false, InternalError(err)
+	} else if folder == nil {
+		return false, ErrNotFound
+	}
+
+	folder.IsPrivate = isPrivate
+	ok, err := s.vfsRepo.UpdateVfsFolder(ctx, folder, db.WithColumns(db.Columns.VfsFolder.IsPrivate))
+	if err != nil {
+		return false, InternalError(err)
+	}
+
+	return ok, nil
+}
+
+// vfsInvoker serves VfsService methods and passes other methods to base vfs service.
+type vfsInvoker struct {
+	base    zenrpc.Invoker
+	service *VfsService
+}
+
+// NewVfsInvoker returns vfs namespace invoker with methods of base service and VfsService.
+func NewVfsInvoker(base zenrpc.Invoker, service *VfsService) zenrpc.Invoker {
+	return vfsInvoker{base: base, service: service}
+}
+
+func (vi vfsInvoker) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	switch method {
+	case RPC.VfsService.SignedURL, RPC.VfsService.SetFolderPrivate:
+		return vi.service.Invoke(ctx, method, params)
+	}
+
+	return vi.base.Invoke(ctx, method, params)
+}
+
+func (vi vfsInvoker) SMD() smd.ServiceInfo {
+	info := vi.base.SMD()
+	methods := make(map[string]smd.Service, len(info.Methods)+2)
+	for name, m := range info.Methods {
+		methods[name] = m
+	}
+	for name, m := range vi.service.SMD().Methods {
+		methods[name] = m
+	}
+	info.Methods = methods
+
+	return info
+}
@@ -0,0 +1,142 @@
+package vt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/media"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vmkteam/zenrpc/v2"
+	"github.com/vmkteam/zenrpc/v2/smd"
+)
+
+// testVfsInvoker is a base vfs service, which returns method name.
+type testVfsInvoker struct{}
+
+func (testVfsInvoker) Invoke(_ context.Context, method string, _ json.RawMessage) zenrpc.Response {
+	r := zenrpc.Response{}
+	r.Set(method)
+	return r
+}
+
+func (testVfsInvoker) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{Methods: map[string]smd.Service{"GetFiles": {}}}
+}
+
+func TestVfsPrivate(t *testing.T) {
+	Convey("Test private vfs files", t, func() {
+		secret := VfsURLSecret
+		VfsURLSecret = []byte("secret")
+		defer func() { VfsURLSecret = secret }()
+
+		Convey("File paths are request path and its suffixes", func() {
+			So(vfsFilePaths("ns/202301/1_9.png"), ShouldResemble, []string{"ns/202301/1_9.png", "202301/1_9.png", "1_9.png"})
+			So(vfsFilePaths("abc.jpg"), ShouldResemble, []string{"abc.jpg"})
+		})
+
+		Convey("File of any private folder is found", func() {
+			files := []db.VfsFile{{ID: 1, Folder: &db.VfsFolder{}}, {ID: 2}, {ID: 3, Folder: &db.VfsFolder{IsPrivate: true}}}
+			So(privateVfsFile(files).ID, ShouldEqual, 3)
+			So(privateVfsFile(files[:2]), ShouldBeNil)
+		})
+
+		Convey("Signature is valid until expiration", func() {
+			now := time.Now()
+			u, err := url.Parse(signedVfsURL("202301/1_9.png", now.Add(time.Minute)))
+			So(err, ShouldBeNil)
+			So(u.Path, ShouldEqual, media.WebPath+"202301/1_9.png")
+
+			q := u.Query()
+			So(isValidVfsSignature("202301/1_9.png", q, now), ShouldBeTrue)
+			So(isValidVfsSignature("202301/1_10.png", q, now), ShouldBeFalse)
+			So(isValidVfsSignature("202301/1_9.png", q, now.Add(time.Minute)), ShouldBeFalse)
+
+			q.Set(vfsExpiresParam, "9999999999")
+			So(isValidVfsSignature("202301/1_9.png", q, now), ShouldBeFalse)
+			So(isValidVfsSignature("202301/1_9.png", url.Values{}, now), ShouldBeFalse)
+
+			VfsURLSecret = nil
+			So(isValidVfsSignature("202301/1_9.png", u.Query(), now), ShouldBeFalse)
+		})
+
+		Convey("Signed url requires valid ttl and secret", func() {
+			s := NewVfsService(testDb, embedlog.Logger{})
+			ttl := 0
+			_, err := s.SignedURL(context.Background(), 1, &ttl)
+			So(err, ShouldNotBeNil)
+			So(err.(*zenrpc.Error).Code, ShouldEqual, http.StatusBadRequest)
+
+			VfsURLSecret = nil
+			_, err = s.SignedURL(context.Background(), 1, nil)
+			So(err, ShouldEqual, errVfsSigningDisabled)
+		})
+
+		Convey("Vfs invoker merges methods", func() {
+			vi := NewVfsInvoker(testVfsInvoker{}, NewVfsService(testDb, embedlog.Logger{}))
+			methods := vi.SMD().Methods
+			So(methods, ShouldContainKey, "GetFiles")
+			So(methods, ShouldContainKey, "SignedURL")
+			So(methods, ShouldContainKey, "SetFolderPrivate")
+
+			resp := vi.Invoke(context.Background(), "getfiles", nil)
+			So(string(*resp.Result), ShouldEqual, `"getfiles"`)
+
+			resp = vi.Invoke(context.Background(), RPC.VfsService.SignedURL, json.RawMessage(`{"fileId":1,"ttl":0}`))
+			So(resp.Error, ShouldNotBeNil)
+			So(resp.Error.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Viewers can get signed urls", func() {
+			So(hasPermission(db.RoleViewer, NSVFS, RPC.VfsService.SignedURL), ShouldBeTrue)
+			So(hasPermission(db.RoleViewer, NSVFS, RPC.VfsService.SetFolderPrivate), ShouldBeFalse)
+			So(auditedMethods.Has(NSVFS, RPC.VfsService.SetFolderPrivate), ShouldBeTrue)
+		})
+	})
+}
+
+func TestDB_VfsFileHandler(t *testing.T) {
+	Convey("Test private vfs files handler", t, func() {
+		ctx := context.Background()
+		secret := VfsURLSecret
+		VfsURLSecret = []byte("secret")
+		defer func() { VfsURLSecret = secret }()
+
+		repo := db.NewVfsRepo(testDb)
+		folder, err := repo.AddVfsFolder(ctx, &db.VfsFolder{Title: "private", IsPrivate: true, StatusID: db.StatusEnabled})
+		So(err, ShouldBeNil)
+
+		// hash uploaded file doesn't have folder and file ids in name
+		p := fmt.Sprintf("a/bc/abc%d.jpg", time.Now().UnixNano())
+		file, err := repo.AddVfsFile(ctx, &db.VfsFile{FolderID: folder.ID, Title: "file", Path: p, MimeType: "image/jpeg", StatusID: db.StatusEnabled})
+		So(err, ShouldBeNil)
+
+		h := VfsFileHandler(repo, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+		serve := func(target string) int {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+			return w.Code
+		}
+
+		So(serve("/"+p), ShouldEqual, http.StatusForbidden)
+		So(serve("/ns/"+p), ShouldEqual, http.StatusForbidden)
+		So(serve("/"+strings.TrimPrefix(signedVfsURL(p, time.Now().Add(time.Minute)), media.WebPath)), ShouldEqual, http.StatusTeapot)
+		So(serve("/a/bc/other.jpg"), ShouldEqual, http.StatusTeapot)
+
+		Reset(func() {
+			_, _ = testDb.ModelContext(ctx, file).WherePK().Delete()
+			_, _ = testDb.ModelContext(ctx, folder).WherePK().Delete()
+		})
+	})
+}
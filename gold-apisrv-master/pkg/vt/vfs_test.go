@@ -0,0 +1,31 @@
+package vt
+
+import (
+	"testing"
+
+	"apisrv/pkg/media"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVfsHashImage(t *testing.T) {
+	Convey("Test newVfsHashImage", t, func() {
+		hash := "0123456789abcdef0123456789abcdef"
+
+		Convey("Empty hash returns nil", func() {
+			So(newVfsHashImage(""), ShouldBeNil)
+			So(newVfsHashImagePtr(nil), ShouldBeNil)
+		})
+
+		Convey("Web paths are returned for each preset size", func() {
+			img := newVfsHashImage(hash)
+			So(img.WebPath, ShouldEqual, media.WebPath+"256/0/12/"+hash+".jpg")
+			So(img.Sizes, ShouldHaveLength, len(media.ImageSizes))
+			So(img.Sizes[media.BigImage], ShouldEqual, media.WebPath+"big/0/12/"+hash+".jpg")
+		})
+
+		Convey("Empty hashes are skipped in gallery", func() {
+			So(newVfsHashImages([]string{hash, ""}), ShouldHaveLength, 1)
+		})
+	})
+}
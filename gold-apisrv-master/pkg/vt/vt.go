@@ -1,7 +1,12 @@
 package vt
 
 import (
+	"context"
+	"errors"
+
 	"apisrv/pkg/db"
+
+	"github.com/go-pg/pg/v10"
 )
 
 const maxPageSize = 500
@@ -15,6 +20,8 @@ type ViewOps struct {
 	SortColumn string `json:"sortColumn"`
 	// descending sort
 	SortDesc bool `json:"sortDesc"`
+	// cursor of next page from list result, used by List methods instead of page
+	Cursor string `json:"cursor,omitempty"`
 }
 
 func (v *ViewOps) Pager() db.Pager {
@@ -31,6 +38,41 @@ func (v *ViewOps) Pager() db.Pager {
 	return db.Pager{Page: v.Page, PageSize: v.PageSize}
 }
 
+// keysetPager returns keyset pager sorted by view ops column if it is one of columns or by def.
+// Primary key pk is a tie breaker, columns must be not null.
+func (v *ViewOps) keysetPager(pk string, def db.SortField, columns ...string) (db.Pager, error) {
+	pager, sort, cursor := v.Pager(), def, ""
+	if v != nil {
+		for _, c := range columns {
+			if c == v.SortColumn {
+				sort = db.NewSortField(v.SortColumn, v.SortDesc)
+			}
+		}
+		cursor = v.Cursor
+	}
+
+	keyset, err := db.NewKeyset(sort.Column, pk, sort.Direction == db.SortDesc, cursor)
+	if err != nil {
+		var vl Validator
+		vl.Append("cursor", FieldErrorIncorrect)
+		return pager, vl.Error()
+	}
+	pager.Keyset = keyset
+
+	return pager, nil
+}
+
+// nextCursor cuts list selected by keyset pager and returns next page cursor or nil on the last page.
+func nextCursor(pager db.Pager, list interface{}) (*string, error) {
+	c, err := pager.Next(list)
+	if err != nil || c == nil {
+		return nil, err
+	}
+
+	s := c.String()
+	return &s, nil
+}
+
 type Status struct {
 	ID    int    `json:"id"`
 	Alias string `json:"alias" validate:"required,max=32"`
@@ -53,3 +95,56 @@ type StatusUpdate struct {
 	StatusID  int   `json:"statusId" validate:"required,status"`
 	ObjectIDs []int `json:"ids" validate:"required,gt=0"`
 }
+
+const (
+	StatusUpdateErrorNotFound    = "notFound"
+	StatusUpdateErrorForbidden   = "forbidden"
+	StatusUpdateErrorUnpublished = "unpublished"
+	StatusUpdateErrorConflict    = "conflict"
+)
+
+type StatusUpdateResult struct {
+	ID        int  `json:"id"`
+	IsUpdated bool `json:"isUpdated"`
+	// reason why object was not updated: notFound, forbidden, unpublished or conflict
+	Error string `json:"error,omitempty"`
+}
+
+// statusUpdateFunc updates status of one object in transaction and returns reason if object was not updated.
+type statusUpdateFunc func(tx *pg.Tx, id int) (reason string, err error)
+
+// updateStatus validates StatusUpdate and calls fn for each unique id in a single transaction.
+func updateStatus(ctx context.Context, dbc db.DB, su StatusUpdate, fn statusUpdateFunc) ([]StatusUpdateResult, error) {
+	var v Validator
+	if v.CheckBasic(ctx, su); v.HasErrors() {
+		return nil, v.Error()
+	}
+
+	seen := make(map[int]bool, len(su.ObjectIDs))
+	results := make([]StatusUpdateResult, 0, len(su.ObjectIDs))
+	err := dbc.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		for _, id := range su.ObjectIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			reason, err := fn(tx, id)
+			if errors.Is(err, db.ErrVersionConflict) {
+				// object was changed concurrently after it was read
+				reason, err = StatusUpdateErrorConflict, nil
+			}
+			if err != nil {
+				return err
+			}
+
+			results = append(results, StatusUpdateResult{ID: id, IsUpdated: reason == "", Error: reason})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return results, nil
+}
@@ -13,8 +13,11 @@ func NewUser(in *db.User) *User {
 		ID:             in.ID,
 		CreatedAt:      in.CreatedAt,
 		Login:          in.Login,
+		Email:          in.Email,
 		LastActivityAt: in.LastActivityAt,
+		Version:        in.Version,
 		StatusID:       in.StatusID,
+		Role:           in.Role,
 		Status:         NewStatus(in.StatusID),
 	}
 
@@ -30,11 +33,22 @@ func NewUserSummary(in *db.User) *UserSummary {
 		ID:             in.ID,
 		CreatedAt:      in.CreatedAt,
 		Login:          in.Login,
+		Email:          in.Email,
 		LastActivityAt: in.LastActivityAt,
+		Role:           in.Role,
 		Status:         NewStatus(in.StatusID),
 	}
 }
 
+func newUserSummaries(in []db.User) []UserSummary {
+	users := make([]UserSummary, 0, len(in))
+	for i := range in {
+		users = append(users, *NewUserSummary(&in[i]))
+	}
+
+	return users
+}
+
 func NewUserProfile(in *db.User) *UserProfile {
 	if in == nil {
 		return nil
@@ -44,7 +58,71 @@ func NewUserProfile(in *db.User) *UserProfile {
 		ID:             in.ID,
 		CreatedAt:      in.CreatedAt,
 		Login:          in.Login,
+		Email:          in.Email,
 		LastActivityAt: in.LastActivityAt,
 		StatusID:       in.StatusID,
+		Role:           in.Role,
+		IsTotpEnabled:  in.IsTotpEnabled,
+		Permissions:    rolePermissions[in.Role],
+	}
+}
+
+func NewAPIKey(in *db.APIKey) *APIKey {
+	if in == nil {
+		return nil
+	}
+
+	return &APIKey{
+		ID:         in.ID,
+		UserID:     in.UserID,
+		Title:      in.Title,
+		Scopes:     in.Scopes,
+		IsReadOnly: in.IsReadOnly,
+		ExpiresAt:  in.ExpiresAt,
+		KeyPrefix:  in.KeyPrefix,
+		LastUsedAt: in.LastUsedAt,
+		CreatedAt:  in.CreatedAt,
+		StatusID:   in.StatusID,
+		User:       NewUserSummary(in.User),
+		Status:     NewStatus(in.StatusID),
+	}
+}
+
+func NewAuditLog(in *db.AuditLog) *AuditLog {
+	if in == nil {
+		return nil
+	}
+
+	return &AuditLog{
+		ID:         in.ID,
+		UserID:     in.UserID,
+		Namespace:  in.Namespace,
+		Method:     in.Method,
+		ObjectID:   in.ObjectID,
+		Params:     in.Params,
+		ResultCode: in.ResultCode,
+		IP:         in.IP,
+		RequestID:  in.RequestID,
+		CreatedAt:  in.CreatedAt,
+		User:       NewUserSummary(in.User),
+	}
+}
+
+func NewSession(in *db.Session, currentID int) *Session {
+	if in == nil {
+		return nil
+	}
+
+	return &Session{
+		ID:             in.ID,
+		Device:         in.Device,
+		IP:             in.IP,
+		UserAgent:      in.UserAgent,
+		IsRemember:     in.IsRemember,
+		CreatedAt:      in.CreatedAt,
+		LastActivityAt: in.LastActivityAt,
+		ExpiresAt:      in.ExpiresAt,
+		IdleExpiresAt:  in.IdleExpiresAt,
+		IsCurrent:      in.ID == currentID,
 	}
 }
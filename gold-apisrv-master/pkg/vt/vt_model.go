@@ -11,10 +11,14 @@ type User struct {
 	ID             int        `json:"id"`
 	CreatedAt      time.Time  `json:"createdAt"`
 	Login          string     `json:"login" validate:"required,max=64"`
-	Password       string     `json:"password" validate:"max=64"`
+	Email          *string    `json:"email" validate:"omitempty,email,max=255"`
+	Password       string     `json:"password"`
 	LastActivityAt *time.Time `json:"lastActivityAt"`
+	Version        int        `json:"version" validate:"required_with=ID"`
 	StatusID       int        `json:"statusId" validate:"required,status"`
 
+	// read-only, use role.assign to change
+	Role   string  `json:"role"`
 	Status *Status `json:"status"`
 }
 
@@ -26,7 +30,9 @@ func (u *User) ToDB() *db.User {
 	user := &db.User{
 		ID:             u.ID,
 		Login:          u.Login,
+		Email:          normalizeEmail(u.Email),
 		LastActivityAt: u.LastActivityAt,
+		Version:        u.Version,
 		StatusID:       u.StatusID,
 	}
 
@@ -36,11 +42,15 @@ func (u *User) ToDB() *db.User {
 type UserSearch struct {
 	ID                 *int       `json:"id"`
 	Login              *string    `json:"login" validate:"max=64"`
+	Email              *string    `json:"email" validate:"omitempty,max=255"`
+	Role               *string    `json:"role" validate:"omitempty,role"`
 	StatusID           *int       `json:"statusId" validate:"status"`
 	LastActivityAtFrom *time.Time `json:"lastActivityAtFrom"`
 	LastActivityAtTo   *time.Time `json:"lastActivityAtTo"`
 	IDs                []int      `json:"ids"`
 	NotID              *int       `json:"notId"`
+	// advanced filter by userFilterFields
+	Filter *FilterGroup `json:"filter"`
 }
 
 func (us *UserSearch) ToDB() *db.UserSearch {
@@ -48,30 +58,175 @@ func (us *UserSearch) ToDB() *db.UserSearch {
 		return nil
 	}
 
-	return &db.UserSearch{
+	search := &db.UserSearch{
 		ID:                 us.ID,
 		LoginILike:         us.Login,
+		Email:              normalizeEmail(us.Email),
+		Role:               us.Role,
 		StatusID:           us.StatusID,
 		LastActivityAtFrom: us.LastActivityAtFrom,
 		LastActivityAtTo:   us.LastActivityAtTo,
 		IDs:                us.IDs,
 		NotID:              us.NotID,
 	}
+
+	if us.Filter != nil {
+		search.WithApply(us.Filter.ToDB().Q())
+	}
+
+	return search
+}
+
+// validate checks advanced filter.
+func (us *UserSearch) validate() error {
+	if us == nil {
+		return nil
+	}
+
+	return userFilterFields.validate(us.Filter)
 }
 
 type UserSummary struct {
 	ID             int        `json:"id"`
 	CreatedAt      time.Time  `json:"createdAt"`
 	Login          string     `json:"login"`
+	Email          *string    `json:"email"`
 	LastActivityAt *time.Time `json:"lastActivityAt"`
+	Role           string     `json:"role"`
 
 	Status *Status `json:"status"`
 }
 
+type UserList struct {
+	Items []UserSummary `json:"items"`
+	Total int           `json:"total"`
+	// cursor of next page, null on the last page
+	NextCursor *string `json:"nextCursor"`
+}
+
+type Session struct {
+	ID             int       `json:"id"`
+	Device         *string   `json:"device"`
+	IP             *string   `json:"ip"`
+	UserAgent      *string   `json:"userAgent"`
+	IsRemember     bool      `json:"isRemember"`
+	CreatedAt      time.Time `json:"createdAt"`
+	LastActivityAt time.Time `json:"lastActivityAt"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	IdleExpiresAt  time.Time `json:"idleExpiresAt"`
+	IsCurrent      bool      `json:"isCurrent"`
+}
+
+type APIKey struct {
+	ID         int         `json:"id"`
+	UserID     int         `json:"userId"`
+	Title      string      `json:"title" validate:"required,max=128"`
+	Scopes     Permissions `json:"scopes" validate:"required"`
+	IsReadOnly bool        `json:"isReadOnly"`
+	ExpiresAt  *time.Time  `json:"expiresAt"`
+
+	// read-only
+	KeyPrefix  string       `json:"keyPrefix"`
+	LastUsedAt *time.Time   `json:"lastUsedAt"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	StatusID   int          `json:"statusId"`
+	Key        *string      `json:"key,omitempty"` // raw key, returned only by apikey.add
+	User       *UserSummary `json:"user"`
+	Status     *Status      `json:"status"`
+}
+
+func (ak *APIKey) ToDB() *db.APIKey {
+	if ak == nil {
+		return nil
+	}
+
+	return &db.APIKey{
+		ID:         ak.ID,
+		UserID:     ak.UserID,
+		Title:      ak.Title,
+		Scopes:     ak.Scopes,
+		IsReadOnly: ak.IsReadOnly,
+		ExpiresAt:  ak.ExpiresAt,
+	}
+}
+
+type APIKeySearch struct {
+	ID         *int    `json:"id"`
+	UserID     *int    `json:"userId"`
+	Title      *string `json:"title" validate:"omitempty,max=128"`
+	IsReadOnly *bool   `json:"isReadOnly"`
+	StatusID   *int    `json:"statusId" validate:"omitempty,status"`
+	IDs        []int   `json:"ids"`
+}
+
+func (aks *APIKeySearch) ToDB() *db.APIKeySearch {
+	if aks == nil {
+		return nil
+	}
+
+	return &db.APIKeySearch{
+		ID:         aks.ID,
+		UserID:     aks.UserID,
+		TitleILike: aks.Title,
+		IsReadOnly: aks.IsReadOnly,
+		StatusID:   aks.StatusID,
+		IDs:        aks.IDs,
+	}
+}
+
+type AuditLogSearch struct {
+	ID            *int       `json:"id"`
+	UserID        *int       `json:"userId"`
+	Namespace     *string    `json:"namespace" validate:"omitempty,max=32"`
+	Method        *string    `json:"method" validate:"omitempty,max=64"`
+	ObjectID      *int       `json:"objectId"`
+	ResultCode    *int       `json:"resultCode"`
+	RequestID     *string    `json:"requestId" validate:"omitempty,max=64"`
+	CreatedAtFrom *time.Time `json:"createdAtFrom"`
+	CreatedAtTo   *time.Time `json:"createdAtTo"`
+}
+
+func (als *AuditLogSearch) ToDB() *db.AuditLogSearch {
+	if als == nil {
+		return nil
+	}
+
+	return &db.AuditLogSearch{
+		ID:            als.ID,
+		UserID:        als.UserID,
+		Namespace:     als.Namespace,
+		Method:        als.Method,
+		ObjectID:      als.ObjectID,
+		ResultCode:    als.ResultCode,
+		RequestID:     als.RequestID,
+		CreatedAtFrom: als.CreatedAtFrom,
+		CreatedAtTo:   als.CreatedAtTo,
+	}
+}
+
+type AuditLog struct {
+	ID         int                    `json:"id"`
+	UserID     *int                   `json:"userId"`
+	Namespace  string                 `json:"namespace"`
+	Method     string                 `json:"method"`
+	ObjectID   *int                   `json:"objectId"`
+	Params     map[string]interface{} `json:"params"`
+	ResultCode int                    `json:"resultCode"`
+	IP         *string                `json:"ip"`
+	RequestID  *string                `json:"requestId"`
+	CreatedAt  time.Time              `json:"createdAt"`
+
+	User *UserSummary `json:"user"`
+}
+
 type UserProfile struct {
-	ID             int        `json:"id"`
-	CreatedAt      time.Time  `json:"createdAt"`
-	Login          string     `json:"login"`
-	LastActivityAt *time.Time `json:"lastActivityAt"`
-	StatusID       int        `json:"statusId"`
+	ID             int         `json:"id"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	Login          string      `json:"login"`
+	Email          *string     `json:"email"`
+	LastActivityAt *time.Time  `json:"lastActivityAt"`
+	StatusID       int         `json:"statusId"`
+	Role           string      `json:"role"`
+	IsTotpEnabled  bool        `json:"isTotpEnabled"`
+	Permissions    Permissions `json:"permissions"`
 }
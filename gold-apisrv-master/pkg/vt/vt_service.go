@@ -2,78 +2,127 @@ package vt
 
 import (
 	"context"
-	"crypto/md5"
-	"fmt"
-	"math/rand"
+	"errors"
 	"net/http"
-	"time"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mailer"
 
+	"github.com/go-pg/pg/v10"
+	zm "github.com/vmkteam/zenrpc-middleware"
 	"github.com/vmkteam/zenrpc/v2"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db         db.DB
 	commonRepo db.CommonRepo
+	mailer     mailer.Mailer
+	limiter    *loginLimiter
 }
 
 var (
 	errInvalidLoginPassword = zenrpc.NewStringError(http.StatusBadRequest, "invalid login or password")
 )
 
-func NewAuthService(dbo db.DB, logger embedlog.Logger) *AuthService {
+func NewAuthService(dbo db.DB, logger embedlog.Logger, m mailer.Mailer) *AuthService {
 	return &AuthService{
 		Logger:     logger,
+		db:         dbo,
 		commonRepo: db.NewCommonRepo(dbo),
+		mailer:     m,
+		limiter:    newLoginLimiter(dbo),
 	}
 }
 
-// Login authenticates user.
+// Login authenticates user and starts new session.
 //
 //zenrpc:login User login
 //zenrpc:password User password
-//zenrpc:remember Remember for week
+//zenrpc:remember Remember for month
 //zenrpc:return User authentication key
 //zenrpc:400 Invalid login or password
+//zenrpc:428 Second factor required, challenge token for auth.loginSecondFactor is in data
+//zenrpc:429 Too many login attempts
 //zenrpc:500 Internal Error
 func (s AuthService) Login(ctx context.Context, login, password string, remember bool) (string, error) {
 	if login == "" || password == "" {
 		return "", errInvalidLoginPassword
 	}
+
+	// check lock before password hash comparison
+	ip := zm.IPFromContext(ctx)
+	if d, err := s.limiter.lockedFor(ctx, login, ip); err != nil {
+		return "", InternalError(err)
+	} else if d > 0 {
+		incStatLogins(loginResultLocked)
+		return "", newLoginLockedError(d)
+	}
+
 	dbu, err := s.commonRepo.EnabledUserByLogin(ctx, login)
 	if err != nil {
 		return "", InternalError(err)
-	} else if dbu == nil {
-		return "", errInvalidLoginPassword
 	}
 
-	if ok := s.checkHash(password, dbu.Password); !ok {
+	// unknown login is checked against dummy hash for the same response time
+	var ok, rehash bool
+	if dbu != nil {
+		ok, rehash = checkPassword(password, dbu.Password)
+	} else {
+		ok = checkDummyPassword(password)
+	}
+	if !ok {
+		if err := s.limiter.fail(ctx, login, ip); err != nil {
+			return "", InternalError(err)
+		}
+		incStatLogins(loginResultFailed)
 		return "", errInvalidLoginPassword
 	}
 
-	if ok, err := s.commonRepo.AuthenticateUser(ctx, dbu, s.generateAuthKey(dbu, remember)); err != nil || !ok {
+	// upgrade hash made by outdated algorithm or parameters
+	if rehash {
+		s.rehashPassword(ctx, dbu, password)
+	}
+
+	// second factor is required, session is started by auth.loginSecondFactor.
+	// Login failures are kept until second factor is passed, so codes can't be guessed without limit.
+	if dbu.IsTotpEnabled {
+		token, err := s.addChallenge(ctx, dbu.ID, remember)
+		if err != nil {
+			return "", InternalError(err)
+		}
+		return "", newSecondFactorRequiredError(token)
+	}
+
+	if err := s.limiter.reset(ctx, login); err != nil {
+		return "", InternalError(err)
+	}
+
+	if _, err := s.commonRepo.DeleteExpiredSessions(ctx, dbu.ID); err != nil {
+		return "", InternalError(err)
+	}
+
+	if _, err := s.commonRepo.UpdateUserActivity(ctx, dbu); err != nil {
 		return "", InternalError(err)
 	}
 
-	return dbu.AuthKey, nil
+	return s.startSession(ctx, dbu, remember)
 }
 
-// Logout current user from every session
+// Logout ends current user session.
 //
 //zenrpc:return Successful logout
 //zenrpc:401 Invalid authentication credentials
 //zenrpc:500 Internal Error
 func (s AuthService) Logout(ctx context.Context) (bool, error) {
-	user := UserFromContext(ctx)
-	if user == nil {
+	session := SessionFromContext(ctx)
+	if session == nil {
 		return false, ErrUnauthorized
 	}
 
-	if ok, err := s.commonRepo.AuthenticateUser(ctx, user, ""); err != nil || !ok {
+	if ok, err := s.commonRepo.DeleteSession(ctx, session.ID); err != nil || !ok {
 		return false, InternalError(err)
 	}
 
@@ -93,75 +142,162 @@ func (s AuthService) Profile(ctx context.Context) (*UserProfile, error) {
 	return NewUserProfile(user), nil
 }
 
-// ChangePassword changes current user password.
+// ChangePassword changes current user password, ends all user sessions and starts new one.
 //
 //zenrpc:password New user password
 //zenrpc:return New user authentication key
+//zenrpc:400 Validation Error
 //zenrpc:401 Invalid authentication credentials
+//zenrpc:409 Version Conflict
 //zenrpc:500 Internal Error
 func (s AuthService) ChangePassword(ctx context.Context, password string) (string, error) {
-	user := UserFromContext(ctx)
-	if user == nil {
+	user, session := UserFromContext(ctx), SessionFromContext(ctx)
+	if user == nil || session == nil {
 		return "", ErrUnauthorized
 	}
 
+	var v Validator
+	if checkPasswordPolicy(&v, password, user.Login); v.HasErrors() {
+		return "", v.Error()
+	}
+
 	p, err := passwordHash(password)
 	if err != nil {
 		return "", InternalError(err)
 	}
 	user.Password = p
-	user.AuthKey = s.generateAuthKey(user, false)
 
-	if ok, err := s.commonRepo.UpdateUserPassword(ctx, user); err != nil || !ok {
+	if _, err := s.commonRepo.UpdateUserPassword(ctx, user); errors.Is(err, db.ErrVersionConflict) {
+		return "", userConflict(ctx, s.commonRepo, user.ID)
+	} else if err != nil {
 		return "", InternalError(err)
 	}
-	return user.AuthKey, nil
+
+	if _, err := s.commonRepo.DeleteUserSessions(ctx, user.ID, nil); err != nil {
+		return "", InternalError(err)
+	}
+
+	return s.startSession(ctx, user, session.IsRemember)
 }
 
 // VfsAuthToken get auth token for VFS requests
 func (s AuthService) VfsAuthToken(ctx context.Context) (string, error) {
-	user := UserFromContext(ctx)
-	return user.AuthKey, nil
+	req, ok := zenrpc.RequestFromContext(ctx)
+	if !ok {
+		return "", ErrUnauthorized
+	}
+
+	return req.Header.Get(AuthKey), nil
 }
 
-func (s AuthService) checkHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// Sessions returns active sessions of current user, newest activity first.
+//
+//zenrpc:return []Session
+//zenrpc:401 Invalid authentication credentials
+//zenrpc:500 Internal Error
+func (s AuthService) Sessions(ctx context.Context) ([]Session, error) {
+	user, current := UserFromContext(ctx), SessionFromContext(ctx)
+	if user == nil || current == nil {
+		return nil, ErrUnauthorized
+	}
+
+	search := &db.SessionSearch{UserID: &user.ID}
+	search.WithActive()
+
+	list, err := s.commonRepo.SessionsByFilters(ctx, search, db.PagerNoLimit, s.commonRepo.DefaultSessionSort())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	sessions := make([]Session, 0, len(list))
+	for i := range list {
+		sessions = append(sessions, *NewSession(&list[i], current.ID))
+	}
+
+	return sessions, nil
 }
 
-func (s AuthService) generateAuthKey(u *db.User, remember bool) string {
-	if remember {
-		// valid only on current week
-		key := fmt.Sprintf("%s:%s:%d:%d", u.Login, u.Password, u.ID, time.Now().Weekday())
-		return fmt.Sprintf("%x", md5.Sum([]byte(key)))
+// RevokeSession ends current user session identified by id.
+//
+//zenrpc:id Session id
+//zenrpc:return isRevoked
+//zenrpc:401 Invalid authentication credentials
+//zenrpc:404 Not Found
+//zenrpc:500 Internal Error
+func (s AuthService) RevokeSession(ctx context.Context, id int) (bool, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return false, ErrUnauthorized
+	}
+
+	session, err := s.commonRepo.OneSession(ctx, &db.SessionSearch{ID: &id, UserID: &user.ID})
+	if err != nil {
+		return false, InternalError(err)
+	} else if session == nil {
+		return false, ErrNotFound
+	}
+
+	ok, err := s.commonRepo.DeleteSession(ctx, session.ID)
+	if err != nil {
+		return false, InternalError(err)
+	}
+
+	return ok, nil
+}
+
+// rehashPassword updates user password hash with current hasher, errors are only logged.
+func (s AuthService) rehashPassword(ctx context.Context, u *db.User, password string) {
+	p, err := passwordHash(password)
+	if err != nil {
+		s.Errorf("rehash password userId=%d error=%s", u.ID, err)
+		return
+	}
+
+	u.Password = p
+	if _, err := s.commonRepo.UpdateUserPassword(ctx, u); err != nil {
+		s.Errorf("update rehashed password userId=%d error=%s", u.ID, err)
 	}
-	return s.generateRandom(32)
 }
 
-func (s AuthService) generateRandom(length int) string {
-	var letterRunes = []rune("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
-	b := make([]rune, length)
-	for i := range b {
-		b[i] = letterRunes[rand.Intn(len(letterRunes))]
+// startSession adds new user session and returns its token.
+func (s AuthService) startSession(ctx context.Context, u *db.User, remember bool) (string, error) {
+	token, err := newSessionToken()
+	if err != nil {
+		return "", InternalError(err)
+	}
+
+	if _, err := s.commonRepo.AddSession(ctx, newSession(ctx, u.ID, hashSessionToken(token), remember)); err != nil {
+		return "", InternalError(err)
 	}
-	return string(b)
+
+	return token, nil
 }
 
-func passwordHash(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+var userFilterFields = filterFields{
+	db.Columns.User.ID:             idSearchTypes,
+	db.Columns.User.Login:          textSearchTypes,
+	db.Columns.User.Email:          append([]int{db.SearchTypeNull}, textSearchTypes...),
+	db.Columns.User.Role:           idSearchTypes,
+	db.Columns.User.CreatedAt:      timeSearchTypes,
+	db.Columns.User.LastActivityAt: nullTimeSearchTypes,
+	db.Columns.User.IsTotpEnabled:  {db.SearchTypeEquals},
+	db.Columns.User.StatusID:       idSearchTypes,
 }
 
 type UserService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db         db.DB
 	commonRepo db.CommonRepo
+	mailer     mailer.Mailer
 }
 
-func NewUserService(dbo db.DB, logger embedlog.Logger) *UserService {
+func NewUserService(dbo db.DB, logger embedlog.Logger, m mailer.Mailer) *UserService {
 	return &UserService{
 		Logger:     logger,
+		db:         dbo,
 		commonRepo: db.NewCommonRepo(dbo),
+		mailer:     m,
 	}
 }
 
@@ -183,8 +319,13 @@ func (s UserService) dbSort(ops *ViewOps) db.OpFunc {
 //
 //zenrpc:search UserSearch
 //zenrpc:return int
+//zenrpc:400 Validation Error
 //zenrpc:500 Internal Error
 func (s UserService) Count(ctx context.Context, search *UserSearch) (int, error) {
+	if err := search.validate(); err != nil {
+		return 0, err
+	}
+
 	count, err := s.commonRepo.CountUsers(ctx, search.ToDB())
 	if err != nil {
 		return 0, InternalError(err)
@@ -197,19 +338,55 @@ func (s UserService) Count(ctx context.Context, search *UserSearch) (int, error)
 //zenrpc:search UserSearch
 //zenrpc:viewOps ViewOps
 //zenrpc:return []UserSummary
+//zenrpc:400 Validation Error
 //zenrpc:500 Internal Error
 func (s UserService) Get(ctx context.Context, search *UserSearch, viewOps *ViewOps) ([]UserSummary, error) {
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
 	list, err := s.commonRepo.UsersByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.commonRepo.FullUser())
 	if err != nil {
 		return nil, InternalError(err)
 	}
-	users := make([]UserSummary, 0, len(list))
-	for i := 0; i < len(list); i++ {
-		if user := NewUserSummary(&list[i]); user != nil {
-			users = append(users, *NewUserSummary(&list[i]))
-		}
+	return newUserSummaries(list), nil
+}
+
+// List returns а page of Users and total count according to conditions in search params.
+// Next pages are selected by cursor, page number is used only without cursor.
+//
+//zenrpc:search UserSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return UserList
+//zenrpc:400 Validation Error
+//zenrpc:500 Internal Error
+func (s UserService) List(ctx context.Context, search *UserSearch, viewOps *ViewOps) (*UserList, error) {
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
+	pager, err := viewOps.keysetPager(db.Columns.User.ID, db.NewSortField(db.Columns.User.CreatedAt, true),
+		db.Columns.User.ID, db.Columns.User.CreatedAt, db.Columns.User.Login, db.Columns.User.StatusID)
+	if err != nil {
+		return nil, err
 	}
-	return users, nil
+
+	list, err := s.commonRepo.UsersByFilters(ctx, search.ToDB(), pager, s.commonRepo.FullUser())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	next, err := nextCursor(pager, &list)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	total, err := s.commonRepo.CountUsers(ctx, search.ToDB())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	return &UserList{Items: newUserSummaries(list), Total: total, NextCursor: next}, nil
 }
 
 // GetByID returns a User by its ID.
@@ -236,6 +413,22 @@ func (s UserService) byID(ctx context.Context, id int) (*db.User, error) {
 	return db, nil
 }
 
+// conflict returns version conflict error with current version of User.
+func (s UserService) conflict(ctx context.Context, id int) error {
+	return userConflict(ctx, s.commonRepo, id)
+}
+
+// userConflict returns version conflict error with current version of User, which was changed concurrently.
+func userConflict(ctx context.Context, repo db.CommonRepo, id int) error {
+	user, err := repo.UserByID(ctx, id)
+	if err != nil {
+		return InternalError(err)
+	} else if user == nil {
+		return ErrNotFound
+	}
+	return VersionConflictError(user.Version)
+}
+
 // Add a User from the query
 //
 //zenrpc:user User
@@ -254,8 +447,9 @@ func (s UserService) Add(ctx context.Context, user User) (*User, error) {
 
 	u := user.ToDB()
 	u.Password = p
+	u.Role = db.RoleViewer
 
-	dbc, err := s.commonRepo.AddUser(ctx, u)
+	dbc, err := s.commonRepo.AddVersionedUser(ctx, u)
 	if err != nil {
 		return nil, InternalError(err)
 	}
@@ -263,12 +457,14 @@ func (s UserService) Add(ctx context.Context, user User) (*User, error) {
 }
 
 // Update updates the User data identified by id from the query
+// Update is rejected with 409 if User was changed since its version from Get, version is required.
 //
 //zenrpc:users User
 //zenrpc:return User
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
 func (s UserService) Update(ctx context.Context, user User) (bool, error) {
 	orig, err := s.byID(ctx, user.ID)
 	if err != nil {
@@ -281,7 +477,9 @@ func (s UserService) Update(ctx context.Context, user User) (bool, error) {
 
 	cur := user.ToDB()
 	cur.Password = orig.Password
-	cur.AuthKey = orig.AuthKey
+	cur.Role = orig.Role
+	cur.OIDCSubject = orig.OIDCSubject
+	cur.TotpSecret, cur.IsTotpEnabled, cur.RecoveryCodes, cur.TotpLastStep = orig.TotpSecret, orig.IsTotpEnabled, orig.RecoveryCodes, orig.TotpLastStep
 
 	if user.Password != "" {
 		p, err := passwordHash(user.Password)
@@ -289,28 +487,42 @@ func (s UserService) Update(ctx context.Context, user User) (bool, error) {
 			return false, InternalError(err)
 		}
 		cur.Password = p
-		cur.AuthKey = ""
 	}
 
-	ok, err := s.commonRepo.UpdateUser(ctx, cur)
-	if err != nil {
+	ok, err := s.commonRepo.UpdateVersionedUser(ctx, cur)
+	if errors.Is(err, db.ErrVersionConflict) {
+		return false, s.conflict(ctx, cur.ID)
+	} else if err != nil {
 		return false, InternalError(err)
 	}
+
+	// end all user sessions on password change
+	if user.Password != "" {
+		if _, err := s.commonRepo.DeleteUserSessions(ctx, cur.ID, nil); err != nil {
+			return false, InternalError(err)
+		}
+	}
+
 	return ok, nil
 }
 
-// Delete deletes the User by its ID.
+// Delete deletes the User by its ID. Current user can't be deleted.
 //
 //zenrpc:id int
 //zenrpc:return isDeleted
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
+//zenrpc:403 Forbidden
 //zenrpc:404 Not Found
 func (s UserService) Delete(ctx context.Context, id int) (bool, error) {
 	if _, err := s.byID(ctx, id); err != nil {
 		return false, err
 	}
 
+	if current := UserFromContext(ctx); current != nil && current.ID == id {
+		return false, ErrForbidden
+	}
+
 	ok, err := s.commonRepo.DeleteUser(ctx, id)
 	if err != nil {
 		return false, InternalError(err)
@@ -318,6 +530,32 @@ func (s UserService) Delete(ctx context.Context, id int) (bool, error) {
 	return ok, err
 }
 
+// UpdateStatus sets status for Users identified by ids in a single transaction.
+// Current user status can't be changed.
+//
+//zenrpc:statusUpdate StatusUpdate
+//zenrpc:return []StatusUpdateResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s UserService) UpdateStatus(ctx context.Context, statusUpdate StatusUpdate) ([]StatusUpdateResult, error) {
+	current := UserFromContext(ctx)
+	return updateStatus(ctx, s.db, statusUpdate, func(tx *pg.Tx, id int) (string, error) {
+		repo := s.commonRepo.WithTransaction(tx)
+		user, err := repo.UserByID(ctx, id)
+		if err != nil {
+			return "", err
+		} else if user == nil {
+			return StatusUpdateErrorNotFound, nil
+		} else if current != nil && current.ID == user.ID {
+			return StatusUpdateErrorForbidden, nil
+		}
+
+		user.StatusID = statusUpdate.StatusID
+		_, err = repo.UpdateVersionedUser(ctx, user, db.WithColumns(db.Columns.User.StatusID))
+		return "", err
+	})
+}
+
 // Validate Verifies that User data is valid.
 //
 //zenrpc:user User
@@ -347,18 +585,37 @@ func (s UserService) isValid(ctx context.Context, user User, isUpdate bool) Vali
 		return v
 	}
 
-	//check login unique
-	item, err := s.commonRepo.OneUser(ctx, &db.UserSearch{Login: &user.Login, NotID: &user.ID})
+	// check login and email unique
+	s.checkUnique(ctx, &v, user.ID, user.Login, normalizeEmail(user.Email))
+
+	// check empty password for add and password policy
+	if !isUpdate && user.Password == "" {
+		v.Append("password", FieldErrorRequired)
+	} else if user.Password != "" {
+		checkPasswordPolicy(&v, user.Password, user.Login)
+	}
+
+	return v
+}
+
+// checkUnique checks that login and email are not used by other users.
+func (s UserService) checkUnique(ctx context.Context, v *Validator, userID int, login string, email *string) {
+	item, err := s.commonRepo.OneUser(ctx, &db.UserSearch{Login: &login, NotID: &userID})
 	if err != nil {
 		v.SetInternalError(err)
+		return
 	} else if item != nil {
 		v.Append("login", FieldErrorUnique)
 	}
 
-	// check empty password for add
-	if !isUpdate && user.Password == "" {
-		v.Append("password", FieldErrorRequired)
+	if email == nil {
+		return
 	}
 
-	return v
+	item, err = s.commonRepo.OneUser(ctx, &db.UserSearch{Email: email, NotID: &userID})
+	if err != nil {
+		v.SetInternalError(err)
+	} else if item != nil {
+		v.Append("email", FieldErrorUnique)
+	}
 }
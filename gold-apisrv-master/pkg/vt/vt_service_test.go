@@ -8,6 +8,7 @@ import (
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mailer"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -15,7 +16,7 @@ import (
 func TestDB_AuthService(t *testing.T) {
 	Convey("Test AuthService", t, func() {
 		ctx := context.Background()
-		srv := NewAuthService(testDb, embedlog.Logger{})
+		srv := NewAuthService(testDb, embedlog.Logger{}, mailer.NewLogMailer("", embedlog.Logger{}))
 		So(srv, ShouldNotBeNil)
 
 		Convey("Positive testing", func() {
@@ -25,17 +26,25 @@ func TestDB_AuthService(t *testing.T) {
 				So(err, ShouldBeNil)
 				authKey2, err := srv.Login(ctx, "admin", "12345", true)
 				So(err, ShouldBeNil)
-				So(authKey, ShouldEqual, authKey2)
+				So(authKey, ShouldNotEqual, authKey2)
+
+				session, err := sessionByToken(ctx, &srv.commonRepo, authKey)
+				So(err, ShouldBeNil)
+				So(session, ShouldNotBeNil)
+				So(session.IsRemember, ShouldBeTrue)
+				So(session.TokenHash, ShouldNotEqual, authKey)
 			})
 
 			Convey("Login without remember password", func() {
 				authKey, err := srv.Login(ctx, "admin", "12345", false)
 				So(err, ShouldBeNil)
-				So(authKey, ShouldHaveLength, 32)
+				So(authKey, ShouldHaveLength, sessionTokenBytes*2)
 
-				u, err := srv.commonRepo.EnabledUserByAuthKey(ctx, authKey)
+				session, err := sessionByToken(ctx, &srv.commonRepo, authKey)
 				So(err, ShouldBeNil)
-				ctx = context.WithValue(ctx, userKey, u)
+				So(session, ShouldNotBeNil)
+				ctx = context.WithValue(ctx, userKey, session.User)
+				ctx = context.WithValue(ctx, sessionKey, session)
 
 				Convey("Get profile", func() {
 					user, err := srv.Profile(ctx)
@@ -43,10 +52,36 @@ func TestDB_AuthService(t *testing.T) {
 					So(user, ShouldNotBeNil)
 				})
 
+				Convey("Get sessions", func() {
+					list, err := srv.Sessions(ctx)
+					So(err, ShouldBeNil)
+					So(list, ShouldNotBeEmpty)
+					So(list[0].IsCurrent, ShouldBeTrue)
+				})
+
+				Convey("Revoke other session", func() {
+					authKey2, err := srv.Login(ctx, "admin", "12345", false)
+					So(err, ShouldBeNil)
+					other, err := sessionByToken(ctx, &srv.commonRepo, authKey2)
+					So(err, ShouldBeNil)
+
+					ok, err := srv.RevokeSession(ctx, other.ID)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeTrue)
+
+					other, err = sessionByToken(ctx, &srv.commonRepo, authKey2)
+					So(err, ShouldBeNil)
+					So(other, ShouldBeNil)
+				})
+
 				Convey("Logout", func() {
 					ok, err := srv.Logout(ctx)
 					So(err, ShouldBeNil)
 					So(ok, ShouldBeTrue)
+
+					session, err := sessionByToken(ctx, &srv.commonRepo, authKey)
+					So(err, ShouldBeNil)
+					So(session, ShouldBeNil)
 				})
 			})
 		})
@@ -89,7 +124,7 @@ func TestDB_AuthService(t *testing.T) {
 func TestDB_UserService(t *testing.T) {
 	Convey("Test UserService", t, func() {
 		ctx := context.Background()
-		srv := NewUserService(testDb, embedlog.Logger{})
+		srv := NewUserService(testDb, embedlog.Logger{}, mailer.NewLogMailer("", embedlog.Logger{}))
 		So(srv, ShouldNotBeNil)
 
 		Convey("Positive testing", func() {
@@ -143,6 +178,22 @@ func TestDB_UserService(t *testing.T) {
 
 		Convey("Negative testing", func() {
 
+			Convey("Delete current user", func() {
+				user, err := srv.Add(ctx, User{Login: fmt.Sprintf("self_%d", time.Now().UnixNano()), Password: "password", StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+				Reset(func() {
+					_, _ = srv.commonRepo.DeleteUser(ctx, user.ID)
+				})
+
+				ok, err := srv.Delete(context.WithValue(ctx, userKey, &db.User{ID: user.ID}), user.ID)
+				So(err, ShouldEqual, ErrForbidden)
+				So(ok, ShouldBeFalse)
+
+				u, err := srv.GetByID(ctx, user.ID)
+				So(err, ShouldBeNil)
+				So(u, ShouldNotBeNil)
+			})
+
 			Convey("Create user with empty login", func() {
 				user := User{
 					Login:    "",
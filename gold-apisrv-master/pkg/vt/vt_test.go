@@ -0,0 +1,72 @@
+package vt
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+func TestViewOps(t *testing.T) {
+	Convey("Test keyset pagination", t, func() {
+		def := db.NewSortField(db.Columns.News.CreatedAt, true)
+
+		Convey("Allowed sort column is used", func() {
+			var v *ViewOps
+			p, err := v.keysetPager(db.Columns.News.ID, def, db.Columns.News.Title)
+			So(err, ShouldBeNil)
+			So(*p.Keyset, ShouldResemble, db.Keyset{Column: db.Columns.News.CreatedAt, PK: db.Columns.News.ID, Desc: true})
+
+			v = &ViewOps{PageSize: 10, SortColumn: db.Columns.News.Title}
+			p, err = v.keysetPager(db.Columns.News.ID, def, db.Columns.News.Title)
+			So(err, ShouldBeNil)
+			So(p.Keyset.Column, ShouldEqual, db.Columns.News.Title)
+			So(p.Keyset.Desc, ShouldBeFalse)
+
+			v.SortColumn = db.Columns.News.UpdatedAt
+			p, err = v.keysetPager(db.Columns.News.ID, def, db.Columns.News.Title)
+			So(err, ShouldBeNil)
+			So(p.Keyset.Column, ShouldEqual, db.Columns.News.CreatedAt)
+		})
+
+		Convey("Next page cursor is made from the last row", func() {
+			now := time.Now()
+			v := &ViewOps{PageSize: 2}
+			p, err := v.keysetPager(db.Columns.News.ID, def)
+			So(err, ShouldBeNil)
+
+			list := []db.News{{ID: 3, CreatedAt: now}, {ID: 2, CreatedAt: now}, {ID: 1, CreatedAt: now}}
+			next, err := nextCursor(p, &list)
+			So(err, ShouldBeNil)
+			So(next, ShouldNotBeNil)
+			So(list, ShouldHaveLength, 2)
+
+			v.Cursor = *next
+			p, err = v.keysetPager(db.Columns.News.ID, def)
+			So(err, ShouldBeNil)
+			So(p.Keyset.After.PK, ShouldEqual, "2")
+			So(p.Keyset.After.Value, ShouldEqual, now.Format(time.RFC3339Nano))
+
+			next, err = nextCursor(p, &list)
+			So(err, ShouldBeNil)
+			So(next, ShouldBeNil)
+		})
+
+		Convey("Invalid cursor is rejected", func() {
+			for _, c := range []string{"abc", db.Cursor{Column: db.Columns.News.Title, Value: "a", PK: 1}.String(), db.Cursor{Column: db.Columns.News.CreatedAt}.String()} {
+				v := &ViewOps{Cursor: c}
+				_, err := v.keysetPager(db.Columns.News.ID, def)
+				So(err, ShouldNotBeNil)
+				So(err.(*zenrpc.Error).Code, ShouldEqual, http.StatusBadRequest)
+			}
+		})
+
+		Convey("Viewers can list", func() {
+			So(hasPermission(db.RoleViewer, NSNews, RPC.NewsService.List), ShouldBeTrue)
+		})
+	})
+}
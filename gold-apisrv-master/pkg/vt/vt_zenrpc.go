@@ -11,84 +11,137 @@ import (
 )
 
 var RPC = struct {
-	CategoryService struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	NewsService     struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	TagService      struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	AuthService     struct{ Login, Logout, Profile, ChangePassword, VfsAuthToken string }
-	UserService     struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
+	APIKeyService   struct{ Count, Get, GetByID, Add, Revoke string }
+	AuditService    struct{ Count, Get string }
+	CategoryService struct{ Count, Get, List, GetByID, Add, Update, Delete, UpdateStatus, Validate, Tree, Move, Reorder string }
+	NewsService     struct{ Count, Get, List, GetByID, Add, Update, Delete, Revisions, RevisionDiff, RestoreRevision, UpdateStatus, Validate string }
+	TagService      struct{ Count, Get, List, GetByID, Add, Update, Delete, UpdateStatus, Validate string }
+	RoleService     struct{ Get, Assign string }
+	VfsService      struct{ SignedURL, SetFolderPrivate string }
+	AuthService     struct{ LoginSecondFactor, TotpEnroll, TotpEnable, TotpDisable, TotpRecoveryCodes, RequestPasswordReset, ResetPassword, AcceptInvite, Login, Logout, Profile, ChangePassword, VfsAuthToken, Sessions, RevokeSession string }
+	UserService     struct{ Invite, Count, Get, List, GetByID, Add, Update, Delete, UpdateStatus, Validate string }
 }{
-	CategoryService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
-		Count:    "count",
-		Get:      "get",
-		GetByID:  "getbyid",
-		Add:      "add",
-		Update:   "update",
-		Delete:   "delete",
-		Validate: "validate",
+	APIKeyService: struct{ Count, Get, GetByID, Add, Revoke string }{
+		Count:   "count",
+		Get:     "get",
+		GetByID: "getbyid",
+		Add:     "add",
+		Revoke:  "revoke",
 	},
-	NewsService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
-		Count:    "count",
-		Get:      "get",
-		GetByID:  "getbyid",
-		Add:      "add",
-		Update:   "update",
-		Delete:   "delete",
-		Validate: "validate",
+	AuditService: struct{ Count, Get string }{
+		Count: "count",
+		Get:   "get",
 	},
-	TagService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
-		Count:    "count",
-		Get:      "get",
-		GetByID:  "getbyid",
-		Add:      "add",
-		Update:   "update",
-		Delete:   "delete",
-		Validate: "validate",
+	CategoryService: struct{ Count, Get, List, GetByID, Add, Update, Delete, UpdateStatus, Validate, Tree, Move, Reorder string }{
+		Count:        "count",
+		Get:          "get",
+		List:         "list",
+		GetByID:      "getbyid",
+		Add:          "add",
+		Update:       "update",
+		Delete:       "delete",
+		UpdateStatus: "updatestatus",
+		Validate:     "validate",
+		Tree:         "tree",
+		Move:         "move",
+		Reorder:      "reorder",
 	},
-	AuthService: struct{ Login, Logout, Profile, ChangePassword, VfsAuthToken string }{
-		Login:          "login",
-		Logout:         "logout",
-		Profile:        "profile",
-		ChangePassword: "changepassword",
-		VfsAuthToken:   "vfsauthtoken",
+	NewsService: struct{ Count, Get, List, GetByID, Add, Update, Delete, Revisions, RevisionDiff, RestoreRevision, UpdateStatus, Validate string }{
+		Count:           "count",
+		Get:             "get",
+		List:            "list",
+		GetByID:         "getbyid",
+		Add:             "add",
+		Update:          "update",
+		Delete:          "delete",
+		Revisions:       "revisions",
+		RevisionDiff:    "revisiondiff",
+		RestoreRevision: "restorerevision",
+		UpdateStatus:    "updatestatus",
+		Validate:        "validate",
 	},
-	UserService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
-		Count:    "count",
-		Get:      "get",
-		GetByID:  "getbyid",
-		Add:      "add",
-		Update:   "update",
-		Delete:   "delete",
-		Validate: "validate",
+	TagService: struct{ Count, Get, List, GetByID, Add, Update, Delete, UpdateStatus, Validate string }{
+		Count:        "count",
+		Get:          "get",
+		List:         "list",
+		GetByID:      "getbyid",
+		Add:          "add",
+		Update:       "update",
+		Delete:       "delete",
+		UpdateStatus: "updatestatus",
+		Validate:     "validate",
+	},
+	RoleService: struct{ Get, Assign string }{
+		Get:    "get",
+		Assign: "assign",
+	},
+	VfsService: struct{ SignedURL, SetFolderPrivate string }{
+		SignedURL:        "signedurl",
+		SetFolderPrivate: "setfolderprivate",
+	},
+	AuthService: struct{ LoginSecondFactor, TotpEnroll, TotpEnable, TotpDisable, TotpRecoveryCodes, RequestPasswordReset, ResetPassword, AcceptInvite, Login, Logout, Profile, ChangePassword, VfsAuthToken, Sessions, RevokeSession string }{
+		LoginSecondFactor:    "loginsecondfactor",
+		TotpEnroll:           "totpenroll",
+		TotpEnable:           "totpenable",
+		TotpDisable:          "totpdisable",
+		TotpRecoveryCodes:    "totprecoverycodes",
+		RequestPasswordReset: "requestpasswordreset",
+		ResetPassword:        "resetpassword",
+		AcceptInvite:         "acceptinvite",
+		Login:                "login",
+		Logout:               "logout",
+		Profile:              "profile",
+		ChangePassword:       "changepassword",
+		VfsAuthToken:         "vfsauthtoken",
+		Sessions:             "sessions",
+		RevokeSession:        "revokesession",
+	},
+	UserService: struct{ Invite, Count, Get, List, GetByID, Add, Update, Delete, UpdateStatus, Validate string }{
+		Invite:       "invite",
+		Count:        "count",
+		Get:          "get",
+		List:         "list",
+		GetByID:      "getbyid",
+		Add:          "add",
+		Update:       "update",
+		Delete:       "delete",
+		UpdateStatus: "updatestatus",
+		Validate:     "validate",
 	},
 }
 
-func (CategoryService) SMD() smd.ServiceInfo {
+func (APIKeyService) SMD() smd.ServiceInfo {
 	return smd.ServiceInfo{
 		Methods: map[string]smd.Service{
 			"Count": {
-				Description: `Count returns count Categories according to conditions in search params.`,
+				Description: `Count APIKeys according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `CategorySearch`,
+						Description: `APIKeySearch`,
 						Type:        smd.Object,
-						TypeName:    "CategorySearch",
+						TypeName:    "APIKeySearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
 								Optional: true,
 								Type:     smd.Integer,
 							},
+							{
+								Name:     "userId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
 							{
 								Name:     "title",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "orderNumber",
+								Name:     "isReadOnly",
 								Optional: true,
-								Type:     smd.Integer,
+								Type:     smd.Boolean,
 							},
 							{
 								Name:     "statusId",
@@ -114,29 +167,34 @@ func (CategoryService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Get": {
-				Description: `Get returns а list of Categories according to conditions in search params.`,
+				Description: `Get а list of APIKeys according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `CategorySearch`,
+						Description: `APIKeySearch`,
 						Type:        smd.Object,
-						TypeName:    "CategorySearch",
+						TypeName:    "APIKeySearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
 								Optional: true,
 								Type:     smd.Integer,
 							},
+							{
+								Name:     "userId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
 							{
 								Name:     "title",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "orderNumber",
+								Name:     "isReadOnly",
 								Optional: true,
-								Type:     smd.Integer,
+								Type:     smd.Boolean,
 							},
 							{
 								Name:     "statusId",
@@ -179,32 +237,131 @@ func (CategoryService) SMD() smd.ServiceInfo {
 								Description: `descending sort`,
 								Type:        smd.Boolean,
 							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
 						},
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]CategorySummary`,
+					Description: `[]APIKey`,
 					Type:        smd.Array,
-					TypeName:    "[]CategorySummary",
+					TypeName:    "[]APIKey",
 					Items: map[string]string{
-						"$ref": "#/definitions/CategorySummary",
+						"$ref": "#/definitions/APIKey",
 					},
 					Definitions: map[string]smd.Definition{
-						"CategorySummary": {
+						"APIKey": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
 									Name: "id",
 									Type: smd.Integer,
 								},
+								{
+									Name: "userId",
+									Type: smd.Integer,
+								},
 								{
 									Name: "title",
 									Type: smd.String,
 								},
 								{
-									Name: "orderNumber",
+									Name: "scopes",
+									Ref:  "#/definitions/Permissions",
+									Type: smd.Object,
+								},
+								{
+									Name: "isReadOnly",
+									Type: smd.Boolean,
+								},
+								{
+									Name:     "expiresAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name:        "keyPrefix",
+									Description: `read-only`,
+									Type:        smd.String,
+								},
+								{
+									Name:     "lastUsedAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "statusId",
+									Type: smd.Integer,
+								},
+								{
+									Name:        "key",
+									Optional:    true,
+									Description: `raw key, returned only by apikey.add`,
+									Type:        smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Permissions": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
 									Type: smd.Integer,
 								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
 								{
 									Name:     "status",
 									Optional: true,
@@ -237,7 +394,7 @@ func (CategoryService) SMD() smd.ServiceInfo {
 				},
 			},
 			"GetByID": {
-				Description: `GetByID returns a Category by its ID.`,
+				Description: `GetByID returns an APIKey by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "id",
@@ -246,27 +403,70 @@ func (CategoryService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `Category`,
+					Description: `APIKey`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "Category",
+					TypeName:    "APIKey",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
+						{
+							Name: "userId",
+							Type: smd.Integer,
+						},
 						{
 							Name: "title",
 							Type: smd.String,
 						},
 						{
-							Name: "orderNumber",
-							Type: smd.Integer,
+							Name: "scopes",
+							Ref:  "#/definitions/Permissions",
+							Type: smd.Object,
+						},
+						{
+							Name: "isReadOnly",
+							Type: smd.Boolean,
+						},
+						{
+							Name:     "expiresAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name:        "keyPrefix",
+							Description: `read-only`,
+							Type:        smd.String,
+						},
+						{
+							Name:     "lastUsedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name: "createdAt",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
 						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
+						{
+							Name:        "key",
+							Optional:    true,
+							Description: `raw key, returned only by apikey.add`,
+							Type:        smd.String,
+						},
+						{
+							Name:     "user",
+							Optional: true,
+							Ref:      "#/definitions/UserSummary",
+							Type:     smd.Object,
+						},
 						{
 							Name:     "status",
 							Optional: true,
@@ -275,6 +475,53 @@ func (CategoryService) SMD() smd.ServiceInfo {
 						},
 					},
 					Definitions: map[string]smd.Definition{
+						"Permissions": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
 						"Status": {
 							Type: "object",
 							Properties: smd.PropertyList{
@@ -300,30 +547,74 @@ func (CategoryService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Add": {
-				Description: `Add adds a Category from the query.`,
+				Description: `Add creates an APIKey for the user, current user is used by default.
+Raw key is returned only once in key field.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "category",
-						Description: `Category`,
+						Name:        "apiKey",
+						Description: `APIKey`,
 						Type:        smd.Object,
-						TypeName:    "Category",
+						TypeName:    "APIKey",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
+							{
+								Name: "userId",
+								Type: smd.Integer,
+							},
 							{
 								Name: "title",
 								Type: smd.String,
 							},
 							{
-								Name: "orderNumber",
-								Type: smd.Integer,
+								Name: "scopes",
+								Ref:  "#/definitions/Permissions",
+								Type: smd.Object,
+							},
+							{
+								Name: "isReadOnly",
+								Type: smd.Boolean,
+							},
+							{
+								Name:     "expiresAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:        "keyPrefix",
+								Description: `read-only`,
+								Type:        smd.String,
+							},
+							{
+								Name:     "lastUsedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name: "createdAt",
+								Ref:  "#/definitions/time.Time",
+								Type: smd.Object,
 							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:        "key",
+								Optional:    true,
+								Description: `raw key, returned only by apikey.add`,
+								Type:        smd.String,
+							},
+							{
+								Name:     "user",
+								Optional: true,
+								Ref:      "#/definitions/UserSummary",
+								Type:     smd.Object,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -332,7 +623,15 @@ func (CategoryService) SMD() smd.ServiceInfo {
 							},
 						},
 						Definitions: map[string]smd.Definition{
-							"Status": {
+							"Permissions": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"UserSummary": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
@@ -340,40 +639,122 @@ func (CategoryService) SMD() smd.ServiceInfo {
 										Type: smd.Integer,
 									},
 									{
-										Name: "alias",
+										Name: "createdAt",
+										Ref:  "#/definitions/time.Time",
+										Type: smd.Object,
+									},
+									{
+										Name: "login",
 										Type: smd.String,
 									},
 									{
-										Name: "title",
+										Name:     "email",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name:     "lastActivityAt",
+										Optional: true,
+										Ref:      "#/definitions/time.Time",
+										Type:     smd.Object,
+									},
+									{
+										Name: "role",
 										Type: smd.String,
 									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
 								},
 							},
-						},
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `Category`,
-					Optional:    true,
-					Type:        smd.Object,
-					TypeName:    "Category",
-					Properties: smd.PropertyList{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `APIKey`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "APIKey",
+					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
+						{
+							Name: "userId",
+							Type: smd.Integer,
+						},
 						{
 							Name: "title",
 							Type: smd.String,
 						},
 						{
-							Name: "orderNumber",
-							Type: smd.Integer,
+							Name: "scopes",
+							Ref:  "#/definitions/Permissions",
+							Type: smd.Object,
+						},
+						{
+							Name: "isReadOnly",
+							Type: smd.Boolean,
+						},
+						{
+							Name:     "expiresAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name:        "keyPrefix",
+							Description: `read-only`,
+							Type:        smd.String,
+						},
+						{
+							Name:     "lastUsedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name: "createdAt",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
 						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
+						{
+							Name:        "key",
+							Optional:    true,
+							Description: `raw key, returned only by apikey.add`,
+							Type:        smd.String,
+						},
+						{
+							Name:     "user",
+							Optional: true,
+							Ref:      "#/definitions/UserSummary",
+							Type:     smd.Object,
+						},
 						{
 							Name:     "status",
 							Optional: true,
@@ -382,7 +763,15 @@ func (CategoryService) SMD() smd.ServiceInfo {
 						},
 					},
 					Definitions: map[string]smd.Definition{
-						"Status": {
+						"Permissions": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"UserSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -390,87 +779,63 @@ func (CategoryService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "alias",
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "login",
 									Type: smd.String,
 								},
 								{
-									Name: "title",
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "role",
 									Type: smd.String,
 								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
-					},
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-				},
-			},
-			"Update": {
-				Description: `Update updates the Category data identified by id from the query.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:     "category",
-						Type:     smd.Object,
-						TypeName: "Category",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "orderNumber",
-								Type: smd.Integer,
-							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
-							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
-							},
-						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
 								},
 							},
 						},
 					},
 				},
-				Returns: smd.JSONSchema{
-					Description: `Category`,
-					Type:        smd.Boolean,
-					TypeName:    "Category",
-				},
 				Errors: map[int]string{
 					500: "Internal Error",
 					400: "Validation Error",
-					404: "Not Found",
 				},
 			},
-			"Delete": {
-				Description: `Delete deletes the Category by its ID.`,
+			"Revoke": {
+				Description: `Revoke revokes the APIKey by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "id",
@@ -479,130 +844,27 @@ func (CategoryService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `isDeleted`,
+					Description: `isRevoked`,
 					Type:        smd.Boolean,
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
-					400: "Validation Error",
 					404: "Not Found",
 				},
 			},
-			"Validate": {
-				Description: `Validate verifies that Category data is valid.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "category",
-						Description: `Category`,
-						Type:        smd.Object,
-						TypeName:    "Category",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "orderNumber",
-								Type: smd.Integer,
-							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
-							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
-							},
-						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
-								},
-							},
-						},
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `[]FieldError`,
-					Type:        smd.Array,
-					TypeName:    "[]FieldError",
-					Items: map[string]string{
-						"$ref": "#/definitions/FieldError",
-					},
-					Definitions: map[string]smd.Definition{
-						"FieldError": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "field",
-									Type: smd.String,
-								},
-								{
-									Name: "error",
-									Type: smd.String,
-								},
-								{
-									Name:        "constraint",
-									Optional:    true,
-									Description: `Help with generating an error message.`,
-									Ref:         "#/definitions/FieldErrorConstraint",
-									Type:        smd.Object,
-								},
-							},
-						},
-						"FieldErrorConstraint": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name:        "max",
-									Description: `Max value for field.`,
-									Type:        smd.Integer,
-								},
-								{
-									Name:        "min",
-									Description: `Min value for field.`,
-									Type:        smd.Integer,
-								},
-							},
-						},
-					},
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-				},
-			},
 		},
 	}
 }
 
 // Invoke is as generated code from zenrpc cmd
-func (s CategoryService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+func (s APIKeyService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
 	resp := zenrpc.Response{}
 	var err error
 
 	switch method {
-	case RPC.CategoryService.Count:
+	case RPC.APIKeyService.Count:
 		var args = struct {
-			Search *CategorySearch `json:"search"`
+			Search *APIKeySearch `json:"search"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -619,10 +881,10 @@ func (s CategoryService) Invoke(ctx context.Context, method string, params json.
 
 		resp.Set(s.Count(ctx, args.Search))
 
-	case RPC.CategoryService.Get:
+	case RPC.APIKeyService.Get:
 		var args = struct {
-			Search  *CategorySearch `json:"search"`
-			ViewOps *ViewOps        `json:"viewOps"`
+			Search  *APIKeySearch `json:"search"`
+			ViewOps *ViewOps      `json:"viewOps"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -639,7 +901,7 @@ func (s CategoryService) Invoke(ctx context.Context, method string, params json.
 
 		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
 
-	case RPC.CategoryService.GetByID:
+	case RPC.APIKeyService.GetByID:
 		var args = struct {
 			Id int `json:"id"`
 		}{}
@@ -658,32 +920,13 @@ func (s CategoryService) Invoke(ctx context.Context, method string, params json.
 
 		resp.Set(s.GetByID(ctx, args.Id))
 
-	case RPC.CategoryService.Add:
-		var args = struct {
-			Category Category `json:"category"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Add(ctx, args.Category))
-
-	case RPC.CategoryService.Update:
+	case RPC.APIKeyService.Add:
 		var args = struct {
-			Category Category `json:"category"`
+			ApiKey APIKey `json:"apiKey"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"apiKey"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -694,9 +937,9 @@ func (s CategoryService) Invoke(ctx context.Context, method string, params json.
 			}
 		}
 
-		resp.Set(s.Update(ctx, args.Category))
+		resp.Set(s.Add(ctx, args.ApiKey))
 
-	case RPC.CategoryService.Delete:
+	case RPC.APIKeyService.Revoke:
 		var args = struct {
 			Id int `json:"id"`
 		}{}
@@ -713,26 +956,7 @@ func (s CategoryService) Invoke(ctx context.Context, method string, params json.
 			}
 		}
 
-		resp.Set(s.Delete(ctx, args.Id))
-
-	case RPC.CategoryService.Validate:
-		var args = struct {
-			Category Category `json:"category"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Validate(ctx, args.Category))
+		resp.Set(s.Revoke(ctx, args.Id))
 
 	default:
 		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
@@ -741,18 +965,18 @@ func (s CategoryService) Invoke(ctx context.Context, method string, params json.
 	return resp
 }
 
-func (NewsService) SMD() smd.ServiceInfo {
+func (AuditService) SMD() smd.ServiceInfo {
 	return smd.ServiceInfo{
 		Methods: map[string]smd.Service{
 			"Count": {
-				Description: `Count returns count News according to conditions in search params.`,
+				Description: `Count AuditLogs according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `NewsSearch`,
+						Description: `AuditLogSearch`,
 						Type:        smd.Object,
-						TypeName:    "NewsSearch",
+						TypeName:    "AuditLogSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -760,59 +984,46 @@ func (NewsService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name:     "userId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "alias",
+								Name:     "namespace",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "content",
+								Name:     "method",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "categoryId",
+								Name:     "objectId",
 								Optional: true,
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "createdAt",
+								Name:     "resultCode",
 								Optional: true,
-								Ref:      "#/definitions/time.Time",
-								Type:     smd.Object,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "updatedAt",
+								Name:     "requestId",
 								Optional: true,
-								Ref:      "#/definitions/time.Time",
-								Type:     smd.Object,
+								Type:     smd.String,
 							},
 							{
-								Name:     "publicationDate",
+								Name:     "createdAtFrom",
 								Optional: true,
 								Ref:      "#/definitions/time.Time",
 								Type:     smd.Object,
 							},
 							{
-								Name:     "statusId",
-								Optional: true,
-								Type:     smd.Integer,
-							},
-							{
-								Name: "ids",
-								Type: smd.Array,
-								Items: map[string]string{
-									"type": smd.Integer,
-								},
-							},
-							{
-								Name:     "notId",
+								Name:     "createdAtTo",
 								Optional: true,
-								Type:     smd.Integer,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
 							},
 						},
 						Definitions: map[string]smd.Definition{
@@ -832,14 +1043,14 @@ func (NewsService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Get": {
-				Description: `Get returns а list of News according to conditions in search params.`,
+				Description: `Get а list of AuditLogs according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `NewsSearch`,
+						Description: `AuditLogSearch`,
 						Type:        smd.Object,
-						TypeName:    "NewsSearch",
+						TypeName:    "AuditLogSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -847,59 +1058,46 @@ func (NewsService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name:     "userId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "alias",
+								Name:     "namespace",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "content",
+								Name:     "method",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "categoryId",
+								Name:     "objectId",
 								Optional: true,
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "createdAt",
+								Name:     "resultCode",
 								Optional: true,
-								Ref:      "#/definitions/time.Time",
-								Type:     smd.Object,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "updatedAt",
+								Name:     "requestId",
 								Optional: true,
-								Ref:      "#/definitions/time.Time",
-								Type:     smd.Object,
+								Type:     smd.String,
 							},
 							{
-								Name:     "publicationDate",
+								Name:     "createdAtFrom",
 								Optional: true,
 								Ref:      "#/definitions/time.Time",
 								Type:     smd.Object,
 							},
 							{
-								Name:     "statusId",
-								Optional: true,
-								Type:     smd.Integer,
-							},
-							{
-								Name: "ids",
-								Type: smd.Array,
-								Items: map[string]string{
-									"type": smd.Integer,
-								},
-							},
-							{
-								Name:     "notId",
+								Name:     "createdAtTo",
 								Optional: true,
-								Type:     smd.Integer,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
 							},
 						},
 						Definitions: map[string]smd.Definition{
@@ -936,18 +1134,23 @@ func (NewsService) SMD() smd.ServiceInfo {
 								Description: `descending sort`,
 								Type:        smd.Boolean,
 							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
 						},
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]NewsSummary`,
+					Description: `[]AuditLog`,
 					Type:        smd.Array,
-					TypeName:    "[]NewsSummary",
+					TypeName:    "[]AuditLog",
 					Items: map[string]string{
-						"$ref": "#/definitions/NewsSummary",
+						"$ref": "#/definitions/AuditLog",
 					},
 					Definitions: map[string]smd.Definition{
-						"NewsSummary": {
+						"AuditLog": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -955,48 +1158,50 @@ func (NewsService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "title",
+									Name:     "userId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "namespace",
 									Type: smd.String,
 								},
 								{
-									Name: "alias",
+									Name: "method",
 									Type: smd.String,
 								},
 								{
-									Name:     "content",
+									Name:     "objectId",
 									Optional: true,
-									Type:     smd.String,
+									Type:     smd.Integer,
 								},
 								{
-									Name: "categoryId",
+									Name: "params",
+									Type: smd.Object,
+								},
+								{
+									Name: "resultCode",
 									Type: smd.Integer,
 								},
 								{
-									Name: "createdAt",
-									Ref:  "#/definitions/time.Time",
-									Type: smd.Object,
+									Name:     "ip",
+									Optional: true,
+									Type:     smd.String,
 								},
 								{
-									Name:     "updatedAt",
+									Name:     "requestId",
 									Optional: true,
-									Ref:      "#/definitions/time.Time",
-									Type:     smd.Object,
+									Type:     smd.String,
 								},
 								{
-									Name: "publicationDate",
+									Name: "createdAt",
 									Ref:  "#/definitions/time.Time",
 									Type: smd.Object,
 								},
 								{
-									Name:     "category",
-									Optional: true,
-									Ref:      "#/definitions/CategorySummary",
-									Type:     smd.Object,
-								},
-								{
-									Name:     "status",
+									Name:     "user",
 									Optional: true,
-									Ref:      "#/definitions/Status",
+									Ref:      "#/definitions/UserSummary",
 									Type:     smd.Object,
 								},
 							},
@@ -1005,7 +1210,7 @@ func (NewsService) SMD() smd.ServiceInfo {
 							Type:       "object",
 							Properties: smd.PropertyList{},
 						},
-						"CategorySummary": {
+						"UserSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -1013,12 +1218,28 @@ func (NewsService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "title",
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "login",
 									Type: smd.String,
 								},
 								{
-									Name: "orderNumber",
-									Type: smd.Integer,
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
 								},
 								{
 									Name:     "status",
@@ -1051,324 +1272,332 @@ func (NewsService) SMD() smd.ServiceInfo {
 					500: "Internal Error",
 				},
 			},
-			"GetByID": {
-				Description: `GetByID returns a News by its ID.`,
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s AuditService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.AuditService.Count:
+		var args = struct {
+			Search *AuditLogSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.AuditService.Get:
+		var args = struct {
+			Search  *AuditLogSearch `json:"search"`
+			ViewOps *ViewOps        `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (CategoryService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count Categories according to conditions in search params.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `News`,
-					Optional:    true,
-					Type:        smd.Object,
-					TypeName:    "News",
-					Properties: smd.PropertyList{
-						{
-							Name: "id",
-							Type: smd.Integer,
-						},
-						{
-							Name: "title",
-							Type: smd.String,
-						},
-						{
-							Name: "alias",
-							Type: smd.String,
-						},
-						{
-							Name:     "content",
-							Optional: true,
-							Type:     smd.String,
-						},
-						{
-							Name: "categoryId",
-							Type: smd.Integer,
-						},
-						{
-							Name: "createdAt",
-							Ref:  "#/definitions/time.Time",
-							Type: smd.Object,
-						},
-						{
-							Name:     "updatedAt",
-							Optional: true,
-							Ref:      "#/definitions/time.Time",
-							Type:     smd.Object,
-						},
-						{
-							Name: "publicationDate",
-							Ref:  "#/definitions/time.Time",
-							Type: smd.Object,
-						},
-						{
-							Name: "tagIds",
-							Type: smd.Array,
-							Items: map[string]string{
-								"type": smd.Integer,
+						Name:        "search",
+						Optional:    true,
+						Description: `CategorySearch`,
+						Type:        smd.Object,
+						TypeName:    "CategorySearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
 							},
-						},
-						{
-							Name: "statusId",
-							Type: smd.Integer,
-						},
-						{
-							Name:     "category",
-							Optional: true,
-							Ref:      "#/definitions/CategorySummary",
-							Type:     smd.Object,
-						},
-						{
-							Name:     "status",
-							Optional: true,
-							Ref:      "#/definitions/Status",
-							Type:     smd.Object,
-						},
-					},
-					Definitions: map[string]smd.Definition{
-						"time.Time": {
-							Type:       "object",
-							Properties: smd.PropertyList{},
-						},
-						"CategorySummary": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
-								},
-								{
-									Name: "orderNumber",
-									Type: smd.Integer,
-								},
-								{
-									Name:     "status",
-									Optional: true,
-									Ref:      "#/definitions/Status",
-									Type:     smd.Object,
+							{
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "orderNumber",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
 								},
 							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by categoryFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
 						},
-						"Status": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "alias",
-									Type: smd.String,
+						Definitions: map[string]smd.Definition{
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
 								},
-								{
-									Name: "title",
-									Type: smd.String,
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
 								},
 							},
 						},
 					},
 				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
 				Errors: map[int]string{
+					400: "Validation Error",
 					500: "Internal Error",
-					404: "Not Found",
 				},
 			},
-			"Add": {
-				Description: `Add adds a News from the query.`,
+			"Get": {
+				Description: `Get returns а list of Categories according to conditions in search params.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "news",
-						Description: `News`,
+						Name:        "search",
+						Optional:    true,
+						Description: `CategorySearch`,
 						Type:        smd.Object,
-						TypeName:    "News",
+						TypeName:    "CategorySearch",
 						Properties: smd.PropertyList{
 							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "alias",
-								Type: smd.String,
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "content",
+								Name:     "title",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name: "categoryId",
-								Type: smd.Integer,
-							},
-							{
-								Name: "createdAt",
-								Ref:  "#/definitions/time.Time",
-								Type: smd.Object,
-							},
-							{
-								Name:     "updatedAt",
+								Name:     "orderNumber",
 								Optional: true,
-								Ref:      "#/definitions/time.Time",
-								Type:     smd.Object,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "publicationDate",
-								Ref:  "#/definitions/time.Time",
-								Type: smd.Object,
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "tagIds",
+								Name: "ids",
 								Type: smd.Array,
 								Items: map[string]string{
 									"type": smd.Integer,
 								},
 							},
 							{
-								Name: "statusId",
-								Type: smd.Integer,
-							},
-							{
-								Name:     "category",
-								Optional: true,
-								Ref:      "#/definitions/CategorySummary",
-								Type:     smd.Object,
-							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by categoryFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
 							},
 						},
 						Definitions: map[string]smd.Definition{
-							"time.Time": {
-								Type:       "object",
-								Properties: smd.PropertyList{},
-							},
-							"CategorySummary": {
+							"FilterGroup": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
-										Name: "id",
-										Type: smd.Integer,
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
 									},
 									{
-										Name: "title",
-										Type: smd.String,
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
 									},
 									{
-										Name: "orderNumber",
-										Type: smd.Integer,
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
 									},
 									{
-										Name:     "status",
-										Optional: true,
-										Ref:      "#/definitions/Status",
-										Type:     smd.Object,
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
 									},
 								},
 							},
-							"Status": {
+							"Filter": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
-										Name: "id",
-										Type: smd.Integer,
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
 									},
 									{
-										Name: "alias",
-										Type: smd.String,
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
 									},
 									{
-										Name: "title",
-										Type: smd.String,
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
 									},
 								},
 							},
 						},
 					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `News`,
-					Optional:    true,
-					Type:        smd.Object,
-					TypeName:    "News",
-					Properties: smd.PropertyList{
-						{
-							Name: "id",
-							Type: smd.Integer,
-						},
-						{
-							Name: "title",
-							Type: smd.String,
-						},
-						{
-							Name: "alias",
-							Type: smd.String,
-						},
-						{
-							Name:     "content",
-							Optional: true,
-							Type:     smd.String,
-						},
-						{
-							Name: "categoryId",
-							Type: smd.Integer,
-						},
-						{
-							Name: "createdAt",
-							Ref:  "#/definitions/time.Time",
-							Type: smd.Object,
-						},
-						{
-							Name:     "updatedAt",
-							Optional: true,
-							Ref:      "#/definitions/time.Time",
-							Type:     smd.Object,
-						},
-						{
-							Name: "publicationDate",
-							Ref:  "#/definitions/time.Time",
-							Type: smd.Object,
-						},
-						{
-							Name: "tagIds",
-							Type: smd.Array,
-							Items: map[string]string{
-								"type": smd.Integer,
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
 							},
 						},
-						{
-							Name: "statusId",
-							Type: smd.Integer,
-						},
-						{
-							Name:     "category",
-							Optional: true,
-							Ref:      "#/definitions/CategorySummary",
-							Type:     smd.Object,
-						},
-						{
-							Name:     "status",
-							Optional: true,
-							Ref:      "#/definitions/Status",
-							Type:     smd.Object,
-						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]CategorySummary`,
+					Type:        smd.Array,
+					TypeName:    "[]CategorySummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/CategorySummary",
 					},
 					Definitions: map[string]smd.Definition{
-						"time.Time": {
-							Type:       "object",
-							Properties: smd.PropertyList{},
-						},
 						"CategorySummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
@@ -1376,6 +1605,11 @@ func (NewsService) SMD() smd.ServiceInfo {
 									Name: "id",
 									Type: smd.Integer,
 								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
 								{
 									Name: "title",
 									Type: smd.String,
@@ -1384,6 +1618,12 @@ func (NewsService) SMD() smd.ServiceInfo {
 									Name: "orderNumber",
 									Type: smd.Integer,
 								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
 								{
 									Name:     "status",
 									Optional: true,
@@ -1392,6 +1632,24 @@ func (NewsService) SMD() smd.ServiceInfo {
 								},
 							},
 						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
 						"Status": {
 							Type: "object",
 							Properties: smd.PropertyList{
@@ -1412,218 +1670,398 @@ func (NewsService) SMD() smd.ServiceInfo {
 					},
 				},
 				Errors: map[int]string{
-					500: "Internal Error",
 					400: "Validation Error",
+					500: "Internal Error",
 				},
 			},
-			"Update": {
-				Description: `Update updates the News data identified by id from the query.`,
+			"List": {
+				Description: `List returns а page of Categories and total count according to conditions in search params.
+Next pages are selected by cursor, page number is used only without cursor.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:     "news",
-						Type:     smd.Object,
-						TypeName: "News",
+						Name:        "search",
+						Optional:    true,
+						Description: `CategorySearch`,
+						Type:        smd.Object,
+						TypeName:    "CategorySearch",
 						Properties: smd.PropertyList{
 							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "alias",
-								Type: smd.String,
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "content",
+								Name:     "title",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name: "categoryId",
-								Type: smd.Integer,
-							},
-							{
-								Name: "createdAt",
-								Ref:  "#/definitions/time.Time",
-								Type: smd.Object,
-							},
-							{
-								Name:     "updatedAt",
+								Name:     "orderNumber",
 								Optional: true,
-								Ref:      "#/definitions/time.Time",
-								Type:     smd.Object,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "publicationDate",
-								Ref:  "#/definitions/time.Time",
-								Type: smd.Object,
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "tagIds",
+								Name: "ids",
 								Type: smd.Array,
 								Items: map[string]string{
 									"type": smd.Integer,
 								},
 							},
 							{
-								Name: "statusId",
-								Type: smd.Integer,
-							},
-							{
-								Name:     "category",
-								Optional: true,
-								Ref:      "#/definitions/CategorySummary",
-								Type:     smd.Object,
-							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by categoryFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
 							},
 						},
 						Definitions: map[string]smd.Definition{
-							"time.Time": {
-								Type:       "object",
-								Properties: smd.PropertyList{},
-							},
-							"CategorySummary": {
+							"FilterGroup": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
-										Name: "id",
-										Type: smd.Integer,
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
 									},
 									{
-										Name: "title",
-										Type: smd.String,
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
 									},
 									{
-										Name: "orderNumber",
-										Type: smd.Integer,
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
 									},
 									{
-										Name:     "status",
-										Optional: true,
-										Ref:      "#/definitions/Status",
-										Type:     smd.Object,
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
 									},
 								},
 							},
-							"Status": {
+							"Filter": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
-										Name: "id",
-										Type: smd.Integer,
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
 									},
 									{
-										Name: "alias",
-										Type: smd.String,
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
 									},
 									{
-										Name: "title",
-										Type: smd.String,
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
 									},
 								},
 							},
 						},
 					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `News`,
-					Type:        smd.Boolean,
-					TypeName:    "News",
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
-				},
-			},
-			"Delete": {
-				Description: `Delete deletes the News by its ID.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `isDeleted`,
-					Type:        smd.Boolean,
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
-				},
-			},
-			"Validate": {
-				Description: `Validate verifies that News data is valid.`,
-				Parameters: []smd.JSONSchema{
 					{
-						Name:        "news",
-						Description: `News`,
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
 						Type:        smd.Object,
-						TypeName:    "News",
+						TypeName:    "ViewOps",
 						Properties: smd.PropertyList{
 							{
-								Name: "id",
-								Type: smd.Integer,
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
 							},
 							{
-								Name: "title",
-								Type: smd.String,
-							},
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
 							{
-								Name: "alias",
-								Type: smd.String,
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
 							},
 							{
-								Name:     "content",
-								Optional: true,
-								Type:     smd.String,
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
 							},
 							{
-								Name: "categoryId",
-								Type: smd.Integer,
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `CategoryList`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "CategoryList",
+					Properties: smd.PropertyList{
+						{
+							Name: "items",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/CategorySummary",
+							},
+						},
+						{
+							Name: "total",
+							Type: smd.Integer,
+						},
+						{
+							Name:        "nextCursor",
+							Optional:    true,
+							Description: `cursor of next page, null on the last page`,
+							Type:        smd.String,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "orderNumber",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a Category by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Category`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Category",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "parentCategoryId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "orderNumber",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "coverImage",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "cover",
+							Optional: true,
+							Ref:      "#/definitions/VfsHashImage",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
 							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Add": {
+				Description: `Add adds a Category from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "category",
+						Description: `Category`,
+						Type:        smd.Object,
+						TypeName:    "Category",
+						Properties: smd.PropertyList{
 							{
-								Name: "createdAt",
-								Ref:  "#/definitions/time.Time",
-								Type: smd.Object,
+								Name: "id",
+								Type: smd.Integer,
 							},
 							{
-								Name:     "updatedAt",
+								Name:     "parentCategoryId",
 								Optional: true,
-								Ref:      "#/definitions/time.Time",
-								Type:     smd.Object,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "publicationDate",
-								Ref:  "#/definitions/time.Time",
-								Type: smd.Object,
+								Name: "title",
+								Type: smd.String,
 							},
 							{
-								Name: "tagIds",
-								Type: smd.Array,
-								Items: map[string]string{
-									"type": smd.Integer,
-								},
+								Name: "orderNumber",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "coverImage",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
 							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
 							{
-								Name:     "category",
+								Name:     "cover",
 								Optional: true,
-								Ref:      "#/definitions/CategorySummary",
+								Ref:      "#/definitions/VfsHashImage",
 								Type:     smd.Object,
 							},
 							{
@@ -1634,30 +2072,21 @@ func (NewsService) SMD() smd.ServiceInfo {
 							},
 						},
 						Definitions: map[string]smd.Definition{
-							"time.Time": {
-								Type:       "object",
-								Properties: smd.PropertyList{},
-							},
-							"CategorySummary": {
+							"VfsHashImage": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "title",
+										Name: "hash",
 										Type: smd.String,
 									},
 									{
-										Name: "orderNumber",
-										Type: smd.Integer,
+										Name: "webPath",
+										Type: smd.String,
 									},
 									{
-										Name:     "status",
-										Optional: true,
-										Ref:      "#/definitions/Status",
-										Type:     smd.Object,
+										Name:        "sizes",
+										Description: `web paths by preset size`,
+										Type:        smd.Object,
 									},
 								},
 							},
@@ -1682,45 +2111,87 @@ func (NewsService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]FieldError`,
-					Type:        smd.Array,
-					TypeName:    "[]FieldError",
-					Items: map[string]string{
-						"$ref": "#/definitions/FieldError",
-					},
+					Description: `Category`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Category",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "parentCategoryId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "orderNumber",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "coverImage",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "cover",
+							Optional: true,
+							Ref:      "#/definitions/VfsHashImage",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
 					Definitions: map[string]smd.Definition{
-						"FieldError": {
+						"VfsHashImage": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name: "field",
+									Name: "hash",
 									Type: smd.String,
 								},
 								{
-									Name: "error",
+									Name: "webPath",
 									Type: smd.String,
 								},
 								{
-									Name:        "constraint",
-									Optional:    true,
-									Description: `Help with generating an error message.`,
-									Ref:         "#/definitions/FieldErrorConstraint",
+									Name:        "sizes",
+									Description: `web paths by preset size`,
 									Type:        smd.Object,
 								},
 							},
 						},
-						"FieldErrorConstraint": {
+						"Status": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name:        "max",
-									Description: `Max value for field.`,
-									Type:        smd.Integer,
+									Name: "id",
+									Type: smd.Integer,
 								},
 								{
-									Name:        "min",
-									Description: `Min value for field.`,
-									Type:        smd.Integer,
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
 								},
 							},
 						},
@@ -1728,229 +2199,143 @@ func (NewsService) SMD() smd.ServiceInfo {
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
+					400: "Validation Error",
 				},
 			},
-		},
-	}
-}
-
-// Invoke is as generated code from zenrpc cmd
-func (s NewsService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
-	resp := zenrpc.Response{}
-	var err error
-
-	switch method {
-	case RPC.NewsService.Count:
-		var args = struct {
-			Search *NewsSearch `json:"search"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Count(ctx, args.Search))
-
-	case RPC.NewsService.Get:
-		var args = struct {
-			Search  *NewsSearch `json:"search"`
-			ViewOps *ViewOps    `json:"viewOps"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
-
-	case RPC.NewsService.GetByID:
-		var args = struct {
-			Id int `json:"id"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.GetByID(ctx, args.Id))
-
-	case RPC.NewsService.Add:
-		var args = struct {
-			News News `json:"news"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Add(ctx, args.News))
-
-	case RPC.NewsService.Update:
-		var args = struct {
-			News News `json:"news"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Update(ctx, args.News))
-
-	case RPC.NewsService.Delete:
-		var args = struct {
-			Id int `json:"id"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Delete(ctx, args.Id))
-
-	case RPC.NewsService.Validate:
-		var args = struct {
-			News News `json:"news"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Validate(ctx, args.News))
-
-	default:
-		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
-	}
-
-	return resp
-}
-
-func (TagService) SMD() smd.ServiceInfo {
-	return smd.ServiceInfo{
-		Methods: map[string]smd.Service{
-			"Count": {
-				Description: `Count returns count Tags according to conditions in search params.`,
+			"Update": {
+				Description: `Update updates the Category data identified by id from the query.
+Update is rejected with 409 if Category was changed since its version from Get, version is required.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "search",
-						Optional:    true,
-						Description: `TagSearch`,
-						Type:        smd.Object,
-						TypeName:    "TagSearch",
+						Name:     "category",
+						Type:     smd.Object,
+						TypeName: "Category",
 						Properties: smd.PropertyList{
 							{
-								Name:     "id",
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "parentCategoryId",
 								Optional: true,
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "orderNumber",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "coverImage",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "statusId",
-								Optional: true,
-								Type:     smd.Integer,
+								Name: "version",
+								Type: smd.Integer,
 							},
 							{
-								Name: "ids",
-								Type: smd.Array,
-								Items: map[string]string{
-									"type": smd.Integer,
-								},
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "cover",
+								Optional: true,
+								Ref:      "#/definitions/VfsHashImage",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"VfsHashImage": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "hash",
+										Type: smd.String,
+									},
+									{
+										Name: "webPath",
+										Type: smd.String,
+									},
+									{
+										Name:        "sizes",
+										Description: `web paths by preset size`,
+										Type:        smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
 							},
 						},
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `int`,
-					Type:        smd.Integer,
+					Description: `Category`,
+					Type:        smd.Boolean,
+					TypeName:    "Category",
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+					409: "Version Conflict",
 				},
 			},
-			"Get": {
-				Description: `Get returns а list of Tags according to conditions in search params.`,
+			"Delete": {
+				Description: `Delete deletes the Category by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "search",
-						Optional:    true,
-						Description: `TagSearch`,
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"UpdateStatus": {
+				Description: `UpdateStatus sets status for Categories identified by ids in a single transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "statusUpdate",
+						Description: `StatusUpdate`,
 						Type:        smd.Object,
-						TypeName:    "TagSearch",
+						TypeName:    "StatusUpdate",
 						Properties: smd.PropertyList{
 							{
-								Name:     "id",
-								Optional: true,
-								Type:     smd.Integer,
-							},
-							{
-								Name:     "title",
-								Optional: true,
-								Type:     smd.String,
-							},
-							{
-								Name:     "statusId",
-								Optional: true,
-								Type:     smd.Integer,
+								Name: "statusId",
+								Type: smd.Integer,
 							},
 							{
 								Name: "ids",
@@ -1961,122 +2346,16 @@ func (TagService) SMD() smd.ServiceInfo {
 							},
 						},
 					},
-					{
-						Name:        "viewOps",
-						Optional:    true,
-						Description: `ViewOps`,
-						Type:        smd.Object,
-						TypeName:    "ViewOps",
-						Properties: smd.PropertyList{
-							{
-								Name:        "page",
-								Description: `page number, default - 1`,
-								Type:        smd.Integer,
-							},
-							{
-								Name:        "pageSize",
-								Description: `items count per page, max - 500`,
-								Type:        smd.Integer,
-							},
-							{
-								Name:        "sortColumn",
-								Description: `sort by column name`,
-								Type:        smd.String,
-							},
-							{
-								Name:        "sortDesc",
-								Description: `descending sort`,
-								Type:        smd.Boolean,
-							},
-						},
-					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]TagSummary`,
+					Description: `[]StatusUpdateResult`,
 					Type:        smd.Array,
-					TypeName:    "[]TagSummary",
+					TypeName:    "[]StatusUpdateResult",
 					Items: map[string]string{
-						"$ref": "#/definitions/TagSummary",
-					},
-					Definitions: map[string]smd.Definition{
-						"TagSummary": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
-								},
-								{
-									Name:     "status",
-									Optional: true,
-									Ref:      "#/definitions/Status",
-									Type:     smd.Object,
-								},
-							},
-						},
-						"Status": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "alias",
-									Type: smd.String,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
-								},
-							},
-						},
-					},
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-				},
-			},
-			"GetByID": {
-				Description: `GetByID returns a Tag by its ID.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `Tag`,
-					Optional:    true,
-					Type:        smd.Object,
-					TypeName:    "Tag",
-					Properties: smd.PropertyList{
-						{
-							Name: "id",
-							Type: smd.Integer,
-						},
-						{
-							Name: "title",
-							Type: smd.String,
-						},
-						{
-							Name: "statusId",
-							Type: smd.Integer,
-						},
-						{
-							Name:     "status",
-							Optional: true,
-							Ref:      "#/definitions/Status",
-							Type:     smd.Object,
-						},
+						"$ref": "#/definitions/StatusUpdateResult",
 					},
 					Definitions: map[string]smd.Definition{
-						"Status": {
+						"StatusUpdateResult": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -2084,12 +2363,13 @@ func (TagService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "alias",
-									Type: smd.String,
+									Name: "isUpdated",
+									Type: smd.Boolean,
 								},
 								{
-									Name: "title",
-									Type: smd.String,
+									Name:        "error",
+									Description: `reason why object was not updated: notFound, forbidden, unpublished or conflict`,
+									Type:        smd.String,
 								},
 							},
 						},
@@ -2097,30 +2377,54 @@ func (TagService) SMD() smd.ServiceInfo {
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
-					404: "Not Found",
+					400: "Validation Error",
 				},
 			},
-			"Add": {
-				Description: `Add adds a Tag from the query.`,
+			"Validate": {
+				Description: `Validate verifies that Category data is valid.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "tag",
-						Description: `Tag`,
+						Name:        "category",
+						Description: `Category`,
 						Type:        smd.Object,
-						TypeName:    "Tag",
+						TypeName:    "Category",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
+							{
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
 							{
 								Name: "title",
 								Type: smd.String,
 							},
+							{
+								Name: "orderNumber",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "coverImage",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:     "cover",
+								Optional: true,
+								Ref:      "#/definitions/VfsHashImage",
+								Type:     smd.Object,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -2129,6 +2433,24 @@ func (TagService) SMD() smd.ServiceInfo {
 							},
 						},
 						Definitions: map[string]smd.Definition{
+							"VfsHashImage": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "hash",
+										Type: smd.String,
+									},
+									{
+										Name: "webPath",
+										Type: smd.String,
+									},
+									{
+										Name:        "sizes",
+										Description: `web paths by preset size`,
+										Type:        smd.Object,
+									},
+								},
+							},
 							"Status": {
 								Type: "object",
 								Properties: smd.PropertyList{
@@ -2150,228 +2472,4947 @@ func (TagService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `Tag`,
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Tree": {
+				Description: `Tree returns all Categories as nested structure ordered by orderNumber.`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `[]CategoryTree`,
+					Type:        smd.Array,
+					TypeName:    "[]CategoryTree",
+					Items: map[string]string{
+						"$ref": "#/definitions/CategoryTree",
+					},
+					Definitions: map[string]smd.Definition{
+						"CategoryTree": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "orderNumber",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+								{
+									Name: "children",
+									Type: smd.Array,
+									Items: map[string]string{
+										"$ref": "#/definitions/CategoryTree",
+									},
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Move": {
+				Description: `Move moves the Category to the parent at the given position and rewrites orderNumber of siblings.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `Category id`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "parentCategoryId",
+						Optional:    true,
+						Description: `new parent Category id, null for root`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "position",
+						Description: `1-based position among siblings`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `bool`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Reorder": {
+				Description: `Reorder sets order of all children of the parent Category by ids.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "parentCategoryId",
+						Optional:    true,
+						Description: `parent Category id, null for root`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "ids",
+						Description: `ids of all children in new order`,
+						Type:        smd.Array,
+						TypeName:    "[]",
+						Items: map[string]string{
+							"type": smd.Integer,
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `bool`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s CategoryService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.CategoryService.Count:
+		var args = struct {
+			Search *CategorySearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.CategoryService.Get:
+		var args = struct {
+			Search  *CategorySearch `json:"search"`
+			ViewOps *ViewOps        `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.CategoryService.List:
+		var args = struct {
+			Search  *CategorySearch `json:"search"`
+			ViewOps *ViewOps        `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.List(ctx, args.Search, args.ViewOps))
+
+	case RPC.CategoryService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.CategoryService.Add:
+		var args = struct {
+			Category Category `json:"category"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.Category))
+
+	case RPC.CategoryService.Update:
+		var args = struct {
+			Category Category `json:"category"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.Category))
+
+	case RPC.CategoryService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.CategoryService.UpdateStatus:
+		var args = struct {
+			StatusUpdate StatusUpdate `json:"statusUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"statusUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.UpdateStatus(ctx, args.StatusUpdate))
+
+	case RPC.CategoryService.Validate:
+		var args = struct {
+			Category Category `json:"category"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.Category))
+
+	case RPC.CategoryService.Tree:
+		resp.Set(s.Tree(ctx))
+
+	case RPC.CategoryService.Move:
+		var args = struct {
+			Id               int  `json:"id"`
+			ParentCategoryId *int `json:"parentCategoryId"`
+			Position         int  `json:"position"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id", "parentCategoryId", "position"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Move(ctx, args.Id, args.ParentCategoryId, args.Position))
+
+	case RPC.CategoryService.Reorder:
+		var args = struct {
+			ParentCategoryId *int  `json:"parentCategoryId"`
+			Ids              []int `json:"ids"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"parentCategoryId", "ids"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Reorder(ctx, args.ParentCategoryId, args.Ids))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (NewsService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count News according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `NewsSearch`,
+						Type:        smd.Object,
+						TypeName:    "NewsSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "categoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "createdAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "updatedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "publicationDate",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:        "isScheduled",
+								Optional:    true,
+								Description: `enabled news waiting for publication date`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "isExpired",
+								Optional:    true,
+								Description: `news with passed unpublish date`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "query",
+								Optional:    true,
+								Description: `full-text search by title and content`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "withSubcategories",
+								Optional:    true,
+								Description: `include news from descendants of categoryId`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by newsFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get returns а list of News according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `NewsSearch`,
+						Type:        smd.Object,
+						TypeName:    "NewsSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "categoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "createdAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "updatedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "publicationDate",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:        "isScheduled",
+								Optional:    true,
+								Description: `enabled news waiting for publication date`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "isExpired",
+								Optional:    true,
+								Description: `news with passed unpublish date`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "query",
+								Optional:    true,
+								Description: `full-text search by title and content`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "withSubcategories",
+								Optional:    true,
+								Description: `include news from descendants of categoryId`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by newsFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "content",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "categoryId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name:     "updatedAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "publicationDate",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name:     "unpublishDate",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "publishedAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "unpublishedAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name:        "headline",
+									Optional:    true,
+									Description: `content snippet with highlighted words of full-text query`,
+									Type:        smd.String,
+								},
+								{
+									Name:     "category",
+									Optional: true,
+									Ref:      "#/definitions/CategorySummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "orderNumber",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"List": {
+				Description: `List returns а page of News and total count according to conditions in search params.
+Next pages are selected by cursor, page number is used only without cursor. Full-text results are not sorted by rank.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `NewsSearch`,
+						Type:        smd.Object,
+						TypeName:    "NewsSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "categoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "createdAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "updatedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "publicationDate",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:        "isScheduled",
+								Optional:    true,
+								Description: `enabled news waiting for publication date`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "isExpired",
+								Optional:    true,
+								Description: `news with passed unpublish date`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "query",
+								Optional:    true,
+								Description: `full-text search by title and content`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "withSubcategories",
+								Optional:    true,
+								Description: `include news from descendants of categoryId`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by newsFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `NewsList`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "NewsList",
+					Properties: smd.PropertyList{
+						{
+							Name: "items",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/NewsSummary",
+							},
+						},
+						{
+							Name: "total",
+							Type: smd.Integer,
+						},
+						{
+							Name:        "nextCursor",
+							Optional:    true,
+							Description: `cursor of next page, null on the last page`,
+							Type:        smd.String,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "content",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "categoryId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name:     "updatedAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "publicationDate",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name:     "unpublishDate",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "publishedAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "unpublishedAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name:        "headline",
+									Optional:    true,
+									Description: `content snippet with highlighted words of full-text query`,
+									Type:        smd.String,
+								},
+								{
+									Name:     "category",
+									Optional: true,
+									Ref:      "#/definitions/CategorySummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "orderNumber",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a News by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `News`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "News",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name:     "content",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "categoryId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "createdAt",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+						{
+							Name:     "updatedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name: "publicationDate",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+						{
+							Name:     "unpublishDate",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "publishedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "unpublishedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name: "tagIds",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:     "coverImage",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "galleryImages",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.String,
+							},
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "category",
+							Optional: true,
+							Ref:      "#/definitions/CategorySummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "cover",
+							Optional: true,
+							Ref:      "#/definitions/VfsHashImage",
+							Type:     smd.Object,
+						},
+						{
+							Name: "gallery",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/VfsHashImage",
+							},
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "orderNumber",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Add": {
+				Description: `Add adds a News from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "news",
+						Description: `News`,
+						Type:        smd.Object,
+						TypeName:    "News",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "categoryId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "createdAt",
+								Ref:  "#/definitions/time.Time",
+								Type: smd.Object,
+							},
+							{
+								Name:     "updatedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name: "publicationDate",
+								Ref:  "#/definitions/time.Time",
+								Type: smd.Object,
+							},
+							{
+								Name:     "unpublishDate",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "publishedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "unpublishedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name: "tagIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "coverImage",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "galleryImages",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.String,
+								},
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "category",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "cover",
+								Optional: true,
+								Ref:      "#/definitions/VfsHashImage",
+								Type:     smd.Object,
+							},
+							{
+								Name: "gallery",
+								Type: smd.Array,
+								Items: map[string]string{
+									"$ref": "#/definitions/VfsHashImage",
+								},
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "orderNumber",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "cover",
+										Optional: true,
+										Ref:      "#/definitions/VfsHashImage",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"VfsHashImage": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "hash",
+										Type: smd.String,
+									},
+									{
+										Name: "webPath",
+										Type: smd.String,
+									},
+									{
+										Name:        "sizes",
+										Description: `web paths by preset size`,
+										Type:        smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `News`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "News",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name:     "content",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "categoryId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "createdAt",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+						{
+							Name:     "updatedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name: "publicationDate",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+						{
+							Name:     "unpublishDate",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "publishedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "unpublishedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name: "tagIds",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:     "coverImage",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "galleryImages",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.String,
+							},
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "category",
+							Optional: true,
+							Ref:      "#/definitions/CategorySummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "cover",
+							Optional: true,
+							Ref:      "#/definitions/VfsHashImage",
+							Type:     smd.Object,
+						},
+						{
+							Name: "gallery",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/VfsHashImage",
+							},
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "orderNumber",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Update": {
+				Description: `Update updates the News data identified by id from the query.
+Update is rejected with 409 if News was changed since its version from Get, version is required.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "news",
+						Type:     smd.Object,
+						TypeName: "News",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "categoryId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "createdAt",
+								Ref:  "#/definitions/time.Time",
+								Type: smd.Object,
+							},
+							{
+								Name:     "updatedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name: "publicationDate",
+								Ref:  "#/definitions/time.Time",
+								Type: smd.Object,
+							},
+							{
+								Name:     "unpublishDate",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "publishedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "unpublishedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name: "tagIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "coverImage",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "galleryImages",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.String,
+								},
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "category",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "cover",
+								Optional: true,
+								Ref:      "#/definitions/VfsHashImage",
+								Type:     smd.Object,
+							},
+							{
+								Name: "gallery",
+								Type: smd.Array,
+								Items: map[string]string{
+									"$ref": "#/definitions/VfsHashImage",
+								},
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "orderNumber",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "cover",
+										Optional: true,
+										Ref:      "#/definitions/VfsHashImage",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"VfsHashImage": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "hash",
+										Type: smd.String,
+									},
+									{
+										Name: "webPath",
+										Type: smd.String,
+									},
+									{
+										Name:        "sizes",
+										Description: `web paths by preset size`,
+										Type:        smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `News`,
+					Type:        smd.Boolean,
+					TypeName:    "News",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the News by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"Revisions": {
+				Description: `Revisions returns a list of News revisions, newest first.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "newsId",
+						Description: `News id`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsRevisionSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsRevisionSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsRevisionSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsRevisionSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "newsId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "content",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "categoryId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "tagIds",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.Integer,
+									},
+								},
+								{
+									Name: "statusId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"RevisionDiff": {
+				Description: `RevisionDiff returns a field-level diff between two revisions of the same News.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "fromId",
+						Description: `Older revision id`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "toId",
+						Description: `Newer revision id`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsRevisionDiff`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsRevisionDiff",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsRevisionDiff",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsRevisionDiff": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "field",
+									Description: `field name`,
+									Type:        smd.String,
+								},
+								{
+									Name:        "from",
+									Description: `value in the older revision`,
+									Type:        smd.String,
+								},
+								{
+									Name:        "to",
+									Description: `value in the newer revision`,
+									Type:        smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"RestoreRevision": {
+				Description: `RestoreRevision restores News fields from the revision and saves them as a new revision.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "revisionId",
+						Description: `Revision id`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `News`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "News",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name:     "content",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "categoryId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "createdAt",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+						{
+							Name:     "updatedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name: "publicationDate",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+						{
+							Name:     "unpublishDate",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "publishedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "unpublishedAt",
+							Optional: true,
+							Ref:      "#/definitions/time.Time",
+							Type:     smd.Object,
+						},
+						{
+							Name: "tagIds",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:     "coverImage",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "galleryImages",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.String,
+							},
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "category",
+							Optional: true,
+							Ref:      "#/definitions/CategorySummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "cover",
+							Optional: true,
+							Ref:      "#/definitions/VfsHashImage",
+							Type:     smd.Object,
+						},
+						{
+							Name: "gallery",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/VfsHashImage",
+							},
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "orderNumber",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "cover",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
+								{
+									Name:        "sizes",
+									Description: `web paths by preset size`,
+									Type:        smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+			"UpdateStatus": {
+				Description: `UpdateStatus sets status for News identified by ids in a single transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "statusUpdate",
+						Description: `StatusUpdate`,
+						Type:        smd.Object,
+						TypeName:    "StatusUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]StatusUpdateResult`,
+					Type:        smd.Array,
+					TypeName:    "[]StatusUpdateResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/StatusUpdateResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"StatusUpdateResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "isUpdated",
+									Type: smd.Boolean,
+								},
+								{
+									Name:        "error",
+									Description: `reason why object was not updated: notFound, forbidden, unpublished or conflict`,
+									Type:        smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that News data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "news",
+						Description: `News`,
+						Type:        smd.Object,
+						TypeName:    "News",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "categoryId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "createdAt",
+								Ref:  "#/definitions/time.Time",
+								Type: smd.Object,
+							},
+							{
+								Name:     "updatedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name: "publicationDate",
+								Ref:  "#/definitions/time.Time",
+								Type: smd.Object,
+							},
+							{
+								Name:     "unpublishDate",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "publishedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "unpublishedAt",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name: "tagIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "coverImage",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "galleryImages",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.String,
+								},
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "category",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "cover",
+								Optional: true,
+								Ref:      "#/definitions/VfsHashImage",
+								Type:     smd.Object,
+							},
+							{
+								Name: "gallery",
+								Type: smd.Array,
+								Items: map[string]string{
+									"$ref": "#/definitions/VfsHashImage",
+								},
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name: "orderNumber",
+										Type: smd.Integer,
+									},
+									{
+										Name:     "cover",
+										Optional: true,
+										Ref:      "#/definitions/VfsHashImage",
+										Type:     smd.Object,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"VfsHashImage": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "hash",
+										Type: smd.String,
+									},
+									{
+										Name: "webPath",
+										Type: smd.String,
+									},
+									{
+										Name:        "sizes",
+										Description: `web paths by preset size`,
+										Type:        smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s NewsService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.NewsService.Count:
+		var args = struct {
+			Search *NewsSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.NewsService.Get:
+		var args = struct {
+			Search  *NewsSearch `json:"search"`
+			ViewOps *ViewOps    `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.NewsService.List:
+		var args = struct {
+			Search  *NewsSearch `json:"search"`
+			ViewOps *ViewOps    `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.List(ctx, args.Search, args.ViewOps))
+
+	case RPC.NewsService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.NewsService.Add:
+		var args = struct {
+			News News `json:"news"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.News))
+
+	case RPC.NewsService.Update:
+		var args = struct {
+			News News `json:"news"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.News))
+
+	case RPC.NewsService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.NewsService.Revisions:
+		var args = struct {
+			NewsId  int      `json:"newsId"`
+			ViewOps *ViewOps `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"newsId", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Revisions(ctx, args.NewsId, args.ViewOps))
+
+	case RPC.NewsService.RevisionDiff:
+		var args = struct {
+			FromId int `json:"fromId"`
+			ToId   int `json:"toId"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"fromId", "toId"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.RevisionDiff(ctx, args.FromId, args.ToId))
+
+	case RPC.NewsService.RestoreRevision:
+		var args = struct {
+			RevisionId int `json:"revisionId"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"revisionId"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.RestoreRevision(ctx, args.RevisionId))
+
+	case RPC.NewsService.UpdateStatus:
+		var args = struct {
+			StatusUpdate StatusUpdate `json:"statusUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"statusUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.UpdateStatus(ctx, args.StatusUpdate))
+
+	case RPC.NewsService.Validate:
+		var args = struct {
+			News News `json:"news"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.News))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (TagService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count Tags according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `TagSearch`,
+						Type:        smd.Object,
+						TypeName:    "TagSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by tagFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get returns а list of Tags according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `TagSearch`,
+						Type:        smd.Object,
+						TypeName:    "TagSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by tagFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]TagSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]TagSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/TagSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"TagSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:        "newsCount",
+									Description: `count of news with the tag`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"List": {
+				Description: `List returns а page of Tags and total count according to conditions in search params.
+Next pages are selected by cursor, page number is used only without cursor.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `TagSearch`,
+						Type:        smd.Object,
+						TypeName:    "TagSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by tagFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `TagList`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "TagList",
+					Properties: smd.PropertyList{
+						{
+							Name: "items",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/TagSummary",
+							},
+						},
+						{
+							Name: "total",
+							Type: smd.Integer,
+						},
+						{
+							Name:        "nextCursor",
+							Optional:    true,
+							Description: `cursor of next page, null on the last page`,
+							Type:        smd.String,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"TagSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:        "newsCount",
+									Description: `count of news with the tag`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a Tag by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Tag`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Tag",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Add": {
+				Description: `Add adds a Tag from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "tag",
+						Description: `Tag`,
+						Type:        smd.Object,
+						TypeName:    "Tag",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Tag`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Tag",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Update": {
+				Description: `Update updates the Tag data identified by id from the query.
+Update is rejected with 409 if Tag was changed since its version from Get, version is required.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "tag",
+						Type:     smd.Object,
+						TypeName: "Tag",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Tag`,
+					Type:        smd.Boolean,
+					TypeName:    "Tag",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the Tag by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"UpdateStatus": {
+				Description: `UpdateStatus sets status for Tags identified by ids in a single transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "statusUpdate",
+						Description: `StatusUpdate`,
+						Type:        smd.Object,
+						TypeName:    "StatusUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]StatusUpdateResult`,
+					Type:        smd.Array,
+					TypeName:    "[]StatusUpdateResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/StatusUpdateResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"StatusUpdateResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "isUpdated",
+									Type: smd.Boolean,
+								},
+								{
+									Name:        "error",
+									Description: `reason why object was not updated: notFound, forbidden, unpublished or conflict`,
+									Type:        smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that Tag data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "tag",
+						Description: `Tag`,
+						Type:        smd.Object,
+						TypeName:    "Tag",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s TagService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.TagService.Count:
+		var args = struct {
+			Search *TagSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.TagService.Get:
+		var args = struct {
+			Search  *TagSearch `json:"search"`
+			ViewOps *ViewOps   `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.TagService.List:
+		var args = struct {
+			Search  *TagSearch `json:"search"`
+			ViewOps *ViewOps   `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.List(ctx, args.Search, args.ViewOps))
+
+	case RPC.TagService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.TagService.Add:
+		var args = struct {
+			Tag Tag `json:"tag"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.Tag))
+
+	case RPC.TagService.Update:
+		var args = struct {
+			Tag Tag `json:"tag"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.Tag))
+
+	case RPC.TagService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.TagService.UpdateStatus:
+		var args = struct {
+			StatusUpdate StatusUpdate `json:"statusUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"statusUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.UpdateStatus(ctx, args.StatusUpdate))
+
+	case RPC.TagService.Validate:
+		var args = struct {
+			Tag Tag `json:"tag"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.Tag))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (RoleService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Get": {
+				Description: `Get returns all roles with their permissions.`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `[]Role`,
+					Type:        smd.Array,
+					TypeName:    "[]Role",
+					Items: map[string]string{
+						"$ref": "#/definitions/Role",
+					},
+					Definitions: map[string]smd.Definition{
+						"Role": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "permissions",
+									Ref:  "#/definitions/Permissions",
+									Type: smd.Object,
+								},
+							},
+						},
+						"Permissions": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+					},
+				},
+			},
+			"Assign": {
+				Description: `Assign sets role for the User identified by id. Current user role can't be changed.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "userId",
+						Description: `User id`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "role",
+						Description: `Role id`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isUpdated`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					403: "Forbidden",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s RoleService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.RoleService.Get:
+		resp.Set(s.Get(ctx))
+
+	case RPC.RoleService.Assign:
+		var args = struct {
+			UserId int    `json:"userId"`
+			Role   string `json:"role"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"userId", "role"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Assign(ctx, args.UserId, args.Role))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (VfsService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"SignedURL": {
+				Description: `SignedURL returns url of the file, which is valid for ttl seconds. It is required for files of private folders.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "fileId",
+						Description: `File id`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "ttl",
+						Optional:    true,
+						Description: `Url lifetime in seconds, max - 604800`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `VfsSignedURL`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "VfsSignedURL",
+					Properties: smd.PropertyList{
+						{
+							Name: "url",
+							Type: smd.String,
+						},
+						{
+							Name: "expiresAt",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					404: "Not Found",
+					500: "Internal Error",
+					501: "Signed urls are not configured",
+				},
+			},
+			"SetFolderPrivate": {
+				Description: `SetFolderPrivate sets private flag of the folder, files of private folders are served only by signed urls.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "folderId",
+						Description: `Folder id`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "isPrivate",
+						Description: `Private flag`,
+						Type:        smd.Boolean,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isUpdated`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					404: "Not Found",
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s VfsService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.VfsService.SignedURL:
+		var args = struct {
+			FileId int  `json:"fileId"`
+			Ttl    *int `json:"ttl"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"fileId", "ttl"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		//zenrpc:ttl=3600 Url lifetime in seconds, max - 604800
+		if args.Ttl == nil {
+			var v int = 3600
+			args.Ttl = &v
+		}
+
+		resp.Set(s.SignedURL(ctx, args.FileId, args.Ttl))
+
+	case RPC.VfsService.SetFolderPrivate:
+		var args = struct {
+			FolderId  int  `json:"folderId"`
+			IsPrivate bool `json:"isPrivate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"folderId", "isPrivate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.SetFolderPrivate(ctx, args.FolderId, args.IsPrivate))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (AuthService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"LoginSecondFactor": {
+				Description: `LoginSecondFactor completes login with TOTP or recovery code and starts new session.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "challengeToken",
+						Description: `Challenge token from auth.login error data`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "code",
+						Description: `TOTP or recovery code`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `User authentication key`,
+					Type:        smd.String,
+				},
+				Errors: map[int]string{
+					400: "Invalid code or challenge token",
+					429: "Too many login attempts",
+					500: "Internal Error",
+				},
+			},
+			"TotpEnroll": {
+				Description: `TotpEnroll starts two-factor authentication enrollment for current user.
+Enrollment must be confirmed by auth.totpEnable with code from authenticator app.`,
+				Parameters: []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `TotpEnrollment`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "TotpEnrollment",
+					Properties: smd.PropertyList{
+						{
+							Name: "secret",
+							Type: smd.String,
+						},
+						{
+							Name: "provisioningUri",
+							Type: smd.String,
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "Two-factor authentication is already enabled",
+					401: "Invalid authentication credentials",
+					409: "Version Conflict",
+					500: "Internal Error",
+				},
+			},
+			"TotpEnable": {
+				Description: `TotpEnable confirms enrollment with code from authenticator app and enables two-factor authentication.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "code",
+						Description: `TOTP code`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Recovery codes, they are shown only once`,
+					Type:        smd.Array,
+					TypeName:    "[]",
+					Items: map[string]string{
+						"type": smd.String,
+					},
+				},
+				Errors: map[int]string{
+					400: "Invalid code",
+					401: "Invalid authentication credentials",
+					409: "Version Conflict",
+					500: "Internal Error",
+				},
+			},
+			"TotpDisable": {
+				Description: `TotpDisable disables two-factor authentication for current user.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "code",
+						Description: `TOTP or recovery code`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDisabled`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					400: "Invalid code",
+					401: "Invalid authentication credentials",
+					409: "Version Conflict",
+					500: "Internal Error",
+				},
+			},
+			"TotpRecoveryCodes": {
+				Description: `TotpRecoveryCodes replaces recovery codes of current user with new ones.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "code",
+						Description: `TOTP code`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Recovery codes, they are shown only once`,
+					Type:        smd.Array,
+					TypeName:    "[]",
+					Items: map[string]string{
+						"type": smd.String,
+					},
+				},
+				Errors: map[int]string{
+					400: "Invalid code",
+					401: "Invalid authentication credentials",
+					409: "Version Conflict",
+					500: "Internal Error",
+				},
+			},
+			"RequestPasswordReset": {
+				Description: `RequestPasswordReset sends password reset link to the email of enabled user.
+It returns true even if user is not found or email is not sent.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "email",
+						Description: `User email`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isRequested`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"ResetPassword": {
+				Description: `ResetPassword sets new password by password reset token and ends all user sessions.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "token",
+						Description: `Password reset token`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "password",
+						Description: `New user password`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isReset`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					400: "Invalid or expired token or validation error",
+					500: "Internal Error",
+				},
+			},
+			"AcceptInvite": {
+				Description: `AcceptInvite sets password by invite token, enables invited user and starts new session.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "token",
+						Description: `Invite token`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "password",
+						Description: `User password`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `User authentication key`,
+					Type:        smd.String,
+				},
+				Errors: map[int]string{
+					400: "Invalid or expired token or validation error",
+					500: "Internal Error",
+				},
+			},
+			"Login": {
+				Description: `Login authenticates user and starts new session.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "login",
+						Description: `User login`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "password",
+						Description: `User password`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "remember",
+						Description: `Remember for month`,
+						Type:        smd.Boolean,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `User authentication key`,
+					Type:        smd.String,
+				},
+				Errors: map[int]string{
+					400: "Invalid login or password",
+					428: "Second factor required, challenge token for auth.loginSecondFactor is in data",
+					429: "Too many login attempts",
+					500: "Internal Error",
+				},
+			},
+			"Logout": {
+				Description: `Logout ends current user session.`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `Successful logout`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					401: "Invalid authentication credentials",
+					500: "Internal Error",
+				},
+			},
+			"Profile": {
+				Description: `Profile is a function that returns current user profile`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `UserProfile`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "Tag",
+					TypeName:    "UserProfile",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
 						{
-							Name: "title",
+							Name: "createdAt",
+							Ref:  "#/definitions/time.Time",
+							Type: smd.Object,
+						},
+						{
+							Name: "login",
 							Type: smd.String,
 						},
 						{
-							Name: "statusId",
-							Type: smd.Integer,
+							Name:     "email",
+							Optional: true,
+							Type:     smd.String,
 						},
 						{
-							Name:     "status",
+							Name:     "lastActivityAt",
 							Optional: true,
-							Ref:      "#/definitions/Status",
+							Ref:      "#/definitions/time.Time",
 							Type:     smd.Object,
 						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "role",
+							Type: smd.String,
+						},
+						{
+							Name: "isTotpEnabled",
+							Type: smd.Boolean,
+						},
+						{
+							Name: "permissions",
+							Ref:  "#/definitions/Permissions",
+							Type: smd.Object,
+						},
 					},
 					Definitions: map[string]smd.Definition{
-						"Status": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "alias",
-									Type: smd.String,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
-								},
-							},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"Permissions": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
 						},
 					},
 				},
 				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
+					401: "Invalid authentication credentials",
 				},
 			},
-			"Update": {
-				Description: `Update updates the Tag data identified by id from the query.`,
+			"ChangePassword": {
+				Description: `ChangePassword changes current user password, ends all user sessions and starts new one.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:     "tag",
-						Type:     smd.Object,
-						TypeName: "Tag",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
-							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
-							},
-						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
-								},
-							},
-						},
+						Name:        "password",
+						Description: `New user password`,
+						Type:        smd.String,
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `Tag`,
-					Type:        smd.Boolean,
-					TypeName:    "Tag",
+					Description: `New user authentication key`,
+					Type:        smd.String,
 				},
 				Errors: map[int]string{
-					500: "Internal Error",
 					400: "Validation Error",
-					404: "Not Found",
+					401: "Invalid authentication credentials",
+					409: "Version Conflict",
+					500: "Internal Error",
 				},
 			},
-			"Delete": {
-				Description: `Delete deletes the Tag by its ID.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
-					},
-				},
+			"VfsAuthToken": {
+				Description: `VfsAuthToken get auth token for VFS requests`,
+				Parameters:  []smd.JSONSchema{},
 				Returns: smd.JSONSchema{
-					Description: `isDeleted`,
-					Type:        smd.Boolean,
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
+					Type: smd.String,
 				},
 			},
-			"Validate": {
-				Description: `Validate verifies that Tag data is valid.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "tag",
-						Description: `Tag`,
-						Type:        smd.Object,
-						TypeName:    "Tag",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
-							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
-							},
-						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
-								},
-							},
-						},
-					},
-				},
+			"Sessions": {
+				Description: `Sessions returns active sessions of current user, newest activity first.`,
+				Parameters:  []smd.JSONSchema{},
 				Returns: smd.JSONSchema{
-					Description: `[]FieldError`,
+					Description: `[]Session`,
 					Type:        smd.Array,
-					TypeName:    "[]FieldError",
+					TypeName:    "[]Session",
 					Items: map[string]string{
-						"$ref": "#/definitions/FieldError",
+						"$ref": "#/definitions/Session",
 					},
 					Definitions: map[string]smd.Definition{
-						"FieldError": {
+						"Session": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name: "field",
-									Type: smd.String,
+									Name: "id",
+									Type: smd.Integer,
 								},
 								{
-									Name: "error",
-									Type: smd.String,
+									Name:     "device",
+									Optional: true,
+									Type:     smd.String,
 								},
 								{
-									Name:        "constraint",
-									Optional:    true,
-									Description: `Help with generating an error message.`,
-									Ref:         "#/definitions/FieldErrorConstraint",
-									Type:        smd.Object,
+									Name:     "ip",
+									Optional: true,
+									Type:     smd.String,
 								},
-							},
-						},
-						"FieldErrorConstraint": {
-							Type: "object",
-							Properties: smd.PropertyList{
 								{
-									Name:        "max",
-									Description: `Max value for field.`,
-									Type:        smd.Integer,
+									Name:     "userAgent",
+									Optional: true,
+									Type:     smd.String,
 								},
 								{
-									Name:        "min",
-									Description: `Min value for field.`,
-									Type:        smd.Integer,
+									Name: "isRemember",
+									Type: smd.Boolean,
+								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "lastActivityAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "expiresAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "idleExpiresAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "isCurrent",
+									Type: smd.Boolean,
 								},
 							},
 						},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+					},
+				},
+				Errors: map[int]string{
+					401: "Invalid authentication credentials",
+					500: "Internal Error",
+				},
+			},
+			"RevokeSession": {
+				Description: `RevokeSession ends current user session identified by id.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `Session id`,
+						Type:        smd.Integer,
 					},
 				},
+				Returns: smd.JSONSchema{
+					Description: `isRevoked`,
+					Type:        smd.Boolean,
+				},
 				Errors: map[int]string{
+					401: "Invalid authentication credentials",
+					404: "Not Found",
 					500: "Internal Error",
 				},
 			},
@@ -2380,18 +7421,19 @@ func (TagService) SMD() smd.ServiceInfo {
 }
 
 // Invoke is as generated code from zenrpc cmd
-func (s TagService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+func (s AuthService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
 	resp := zenrpc.Response{}
 	var err error
 
 	switch method {
-	case RPC.TagService.Count:
+	case RPC.AuthService.LoginSecondFactor:
 		var args = struct {
-			Search *TagSearch `json:"search"`
+			ChallengeToken string `json:"challengeToken"`
+			Code           string `json:"code"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"challengeToken", "code"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2402,16 +7444,18 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 			}
 		}
 
-		resp.Set(s.Count(ctx, args.Search))
+		resp.Set(s.LoginSecondFactor(ctx, args.ChallengeToken, args.Code))
 
-	case RPC.TagService.Get:
+	case RPC.AuthService.TotpEnroll:
+		resp.Set(s.TotpEnroll(ctx))
+
+	case RPC.AuthService.TotpEnable:
 		var args = struct {
-			Search  *TagSearch `json:"search"`
-			ViewOps *ViewOps   `json:"viewOps"`
+			Code string `json:"code"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"code"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2422,15 +7466,15 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 			}
 		}
 
-		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+		resp.Set(s.TotpEnable(ctx, args.Code))
 
-	case RPC.TagService.GetByID:
+	case RPC.AuthService.TotpDisable:
 		var args = struct {
-			Id int `json:"id"`
+			Code string `json:"code"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"code"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2441,15 +7485,15 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 			}
 		}
 
-		resp.Set(s.GetByID(ctx, args.Id))
+		resp.Set(s.TotpDisable(ctx, args.Code))
 
-	case RPC.TagService.Add:
+	case RPC.AuthService.TotpRecoveryCodes:
 		var args = struct {
-			Tag Tag `json:"tag"`
+			Code string `json:"code"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"code"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2460,15 +7504,15 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 			}
 		}
 
-		resp.Set(s.Add(ctx, args.Tag))
+		resp.Set(s.TotpRecoveryCodes(ctx, args.Code))
 
-	case RPC.TagService.Update:
+	case RPC.AuthService.RequestPasswordReset:
 		var args = struct {
-			Tag Tag `json:"tag"`
+			Email string `json:"email"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"email"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2479,15 +7523,16 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 			}
 		}
 
-		resp.Set(s.Update(ctx, args.Tag))
+		resp.Set(s.RequestPasswordReset(ctx, args.Email))
 
-	case RPC.TagService.Delete:
+	case RPC.AuthService.ResetPassword:
 		var args = struct {
-			Id int `json:"id"`
+			Token    string `json:"token"`
+			Password string `json:"password"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"token", "password"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2498,15 +7543,16 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 			}
 		}
 
-		resp.Set(s.Delete(ctx, args.Id))
+		resp.Set(s.ResetPassword(ctx, args.Token, args.Password))
 
-	case RPC.TagService.Validate:
+	case RPC.AuthService.AcceptInvite:
 		var args = struct {
-			Tag Tag `json:"tag"`
+			Token    string `json:"token"`
+			Password string `json:"password"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"token", "password"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2517,7 +7563,78 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 			}
 		}
 
-		resp.Set(s.Validate(ctx, args.Tag))
+		resp.Set(s.AcceptInvite(ctx, args.Token, args.Password))
+
+	case RPC.AuthService.Login:
+		var args = struct {
+			Login    string `json:"login"`
+			Password string `json:"password"`
+			Remember bool   `json:"remember"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"login", "password", "remember"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Login(ctx, args.Login, args.Password, args.Remember))
+
+	case RPC.AuthService.Logout:
+		resp.Set(s.Logout(ctx))
+
+	case RPC.AuthService.Profile:
+		resp.Set(s.Profile(ctx))
+
+	case RPC.AuthService.ChangePassword:
+		var args = struct {
+			Password string `json:"password"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"password"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.ChangePassword(ctx, args.Password))
+
+	case RPC.AuthService.VfsAuthToken:
+		resp.Set(s.VfsAuthToken(ctx))
+
+	case RPC.AuthService.Sessions:
+		resp.Set(s.Sessions(ctx))
+
+	case RPC.AuthService.RevokeSession:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.RevokeSession(ctx, args.Id))
 
 	default:
 		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
@@ -2526,57 +7643,38 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 	return resp
 }
 
-func (AuthService) SMD() smd.ServiceInfo {
+func (UserService) SMD() smd.ServiceInfo {
 	return smd.ServiceInfo{
 		Methods: map[string]smd.Service{
-			"Login": {
-				Description: `Login authenticates user.`,
+			"Invite": {
+				Description: `Invite adds disabled User and sends invitation email, user is enabled after auth.acceptInvite.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "login",
-						Description: `User login`,
-						Type:        smd.String,
-					},
-					{
-						Name:        "password",
-						Description: `User password`,
-						Type:        smd.String,
-					},
-					{
-						Name:        "remember",
-						Description: `Remember for week`,
-						Type:        smd.Boolean,
+						Name:        "invite",
+						Description: `UserInvite`,
+						Type:        smd.Object,
+						TypeName:    "UserInvite",
+						Properties: smd.PropertyList{
+							{
+								Name: "login",
+								Type: smd.String,
+							},
+							{
+								Name: "email",
+								Type: smd.String,
+							},
+							{
+								Name: "role",
+								Type: smd.String,
+							},
+						},
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `User authentication key`,
-					Type:        smd.String,
-				},
-				Errors: map[int]string{
-					400: "Invalid login or password",
-					500: "Internal Error",
-				},
-			},
-			"Logout": {
-				Description: `Logout current user from every session`,
-				Parameters:  []smd.JSONSchema{},
-				Returns: smd.JSONSchema{
-					Description: `Successful logout`,
-					Type:        smd.Boolean,
-				},
-				Errors: map[int]string{
-					401: "Invalid authentication credentials",
-					500: "Internal Error",
-				},
-			},
-			"Profile": {
-				Description: `Profile is a function that returns current user profile`,
-				Parameters:  []smd.JSONSchema{},
-				Returns: smd.JSONSchema{
-					Description: `UserProfile`,
+					Description: `User`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "UserProfile",
+					TypeName:    "User",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
@@ -2591,124 +7689,210 @@ func (AuthService) SMD() smd.ServiceInfo {
 							Name: "login",
 							Type: smd.String,
 						},
+						{
+							Name:     "email",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "password",
+							Type: smd.String,
+						},
 						{
 							Name:     "lastActivityAt",
 							Optional: true,
 							Ref:      "#/definitions/time.Time",
 							Type:     smd.Object,
 						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
-					},
-					Definitions: map[string]smd.Definition{
-						"time.Time": {
-							Type:       "object",
-							Properties: smd.PropertyList{},
+						{
+							Name:        "role",
+							Description: `read-only, use role.assign to change`,
+							Type:        smd.String,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Count": {
+				Description: `Count Users according to conditions in search params`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `UserSearch`,
+						Type:        smd.Object,
+						TypeName:    "UserSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "login",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "role",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "lastActivityAtFrom",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "lastActivityAtTo",
+								Optional: true,
+								Ref:      "#/definitions/time.Time",
+								Type:     smd.Object,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by userFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
 						},
 					},
 				},
-				Errors: map[int]string{
-					401: "Invalid authentication credentials",
-				},
-			},
-			"ChangePassword": {
-				Description: `ChangePassword changes current user password.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "password",
-						Description: `New user password`,
-						Type:        smd.String,
-					},
-				},
 				Returns: smd.JSONSchema{
-					Description: `New user authentication key`,
-					Type:        smd.String,
+					Description: `int`,
+					Type:        smd.Integer,
 				},
 				Errors: map[int]string{
-					401: "Invalid authentication credentials",
+					400: "Validation Error",
 					500: "Internal Error",
 				},
 			},
-			"VfsAuthToken": {
-				Description: `VfsAuthToken get auth token for VFS requests`,
-				Parameters:  []smd.JSONSchema{},
-				Returns: smd.JSONSchema{
-					Type: smd.String,
-				},
-			},
-		},
-	}
-}
-
-// Invoke is as generated code from zenrpc cmd
-func (s AuthService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
-	resp := zenrpc.Response{}
-	var err error
-
-	switch method {
-	case RPC.AuthService.Login:
-		var args = struct {
-			Login    string `json:"login"`
-			Password string `json:"password"`
-			Remember bool   `json:"remember"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"login", "password", "remember"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Login(ctx, args.Login, args.Password, args.Remember))
-
-	case RPC.AuthService.Logout:
-		resp.Set(s.Logout(ctx))
-
-	case RPC.AuthService.Profile:
-		resp.Set(s.Profile(ctx))
-
-	case RPC.AuthService.ChangePassword:
-		var args = struct {
-			Password string `json:"password"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"password"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.ChangePassword(ctx, args.Password))
-
-	case RPC.AuthService.VfsAuthToken:
-		resp.Set(s.VfsAuthToken(ctx))
-
-	default:
-		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
-	}
-
-	return resp
-}
-
-func (UserService) SMD() smd.ServiceInfo {
-	return smd.ServiceInfo{
-		Methods: map[string]smd.Service{
-			"Count": {
-				Description: `Count Users according to conditions in search params`,
+			"Get": {
+				Description: `Get а list of Users according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
@@ -2727,6 +7911,16 @@ func (UserService) SMD() smd.ServiceInfo {
 								Optional: true,
 								Type:     smd.String,
 							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "role",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name:     "statusId",
 								Optional: true,
@@ -2750,31 +7944,194 @@ func (UserService) SMD() smd.ServiceInfo {
 								Items: map[string]string{
 									"type": smd.Integer,
 								},
-							},
-							{
-								Name:     "notId",
-								Optional: true,
-								Type:     smd.Integer,
+							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by userFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"time.Time": {
+								Type:       "object",
+								Properties: smd.PropertyList{},
+							},
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]UserSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]UserSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/UserSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Ref:  "#/definitions/time.Time",
+									Type: smd.Object,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Ref:      "#/definitions/time.Time",
+									Type:     smd.Object,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
-						Definitions: map[string]smd.Definition{
-							"time.Time": {
-								Type:       "object",
-								Properties: smd.PropertyList{},
+						"time.Time": {
+							Type:       "object",
+							Properties: smd.PropertyList{},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
 							},
 						},
 					},
 				},
-				Returns: smd.JSONSchema{
-					Description: `int`,
-					Type:        smd.Integer,
-				},
 				Errors: map[int]string{
+					400: "Validation Error",
 					500: "Internal Error",
 				},
 			},
-			"Get": {
-				Description: `Get а list of Users according to conditions in search params`,
+			"List": {
+				Description: `List returns а page of Users and total count according to conditions in search params.
+Next pages are selected by cursor, page number is used only without cursor.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
@@ -2793,6 +8150,16 @@ func (UserService) SMD() smd.ServiceInfo {
 								Optional: true,
 								Type:     smd.String,
 							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "role",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name:     "statusId",
 								Optional: true,
@@ -2822,12 +8189,73 @@ func (UserService) SMD() smd.ServiceInfo {
 								Optional: true,
 								Type:     smd.Integer,
 							},
+							{
+								Name:        "filter",
+								Optional:    true,
+								Description: `advanced filter by userFilterFields`,
+								Ref:         "#/definitions/FilterGroup",
+								Type:        smd.Object,
+							},
 						},
 						Definitions: map[string]smd.Definition{
 							"time.Time": {
 								Type:       "object",
 								Properties: smd.PropertyList{},
 							},
+							"FilterGroup": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "or",
+										Description: `join conditions by OR, by AND otherwise`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name:        "not",
+										Description: `negate the whole group`,
+										Type:        smd.Boolean,
+									},
+									{
+										Name: "filters",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/Filter",
+										},
+									},
+									{
+										Name: "groups",
+										Type: smd.Array,
+										Items: map[string]string{
+											"$ref": "#/definitions/FilterGroup",
+										},
+									},
+								},
+							},
+							"Filter": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name:        "field",
+										Description: `field from service filter fields`,
+										Type:        smd.String,
+									},
+									{
+										Name:        "type",
+										Description: `search type, see db.SearchType* constants`,
+										Type:        smd.Integer,
+									},
+									{
+										Name:        "value",
+										Description: `value for search type: array for array types, null for null type`,
+										Type:        smd.Object,
+									},
+									{
+										Name:        "exclude",
+										Description: `negate condition`,
+										Type:        smd.Boolean,
+									},
+								},
+							},
 						},
 					},
 					{
@@ -2857,15 +8285,37 @@ func (UserService) SMD() smd.ServiceInfo {
 								Description: `descending sort`,
 								Type:        smd.Boolean,
 							},
+							{
+								Name:        "cursor",
+								Description: `cursor of next page from list result, used by List methods instead of page`,
+								Type:        smd.String,
+							},
 						},
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]UserSummary`,
-					Type:        smd.Array,
-					TypeName:    "[]UserSummary",
-					Items: map[string]string{
-						"$ref": "#/definitions/UserSummary",
+					Description: `UserList`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "UserList",
+					Properties: smd.PropertyList{
+						{
+							Name: "items",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/UserSummary",
+							},
+						},
+						{
+							Name: "total",
+							Type: smd.Integer,
+						},
+						{
+							Name:        "nextCursor",
+							Optional:    true,
+							Description: `cursor of next page, null on the last page`,
+							Type:        smd.String,
+						},
 					},
 					Definitions: map[string]smd.Definition{
 						"UserSummary": {
@@ -2884,12 +8334,21 @@ func (UserService) SMD() smd.ServiceInfo {
 									Name: "login",
 									Type: smd.String,
 								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
 								{
 									Name:     "lastActivityAt",
 									Optional: true,
 									Ref:      "#/definitions/time.Time",
 									Type:     smd.Object,
 								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
 								{
 									Name:     "status",
 									Optional: true,
@@ -2922,6 +8381,7 @@ func (UserService) SMD() smd.ServiceInfo {
 					},
 				},
 				Errors: map[int]string{
+					400: "Validation Error",
 					500: "Internal Error",
 				},
 			},
@@ -2953,6 +8413,11 @@ func (UserService) SMD() smd.ServiceInfo {
 							Name: "login",
 							Type: smd.String,
 						},
+						{
+							Name:     "email",
+							Optional: true,
+							Type:     smd.String,
+						},
 						{
 							Name: "password",
 							Type: smd.String,
@@ -2963,10 +8428,19 @@ func (UserService) SMD() smd.ServiceInfo {
 							Ref:      "#/definitions/time.Time",
 							Type:     smd.Object,
 						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
+						{
+							Name:        "role",
+							Description: `read-only, use role.assign to change`,
+							Type:        smd.String,
+						},
 						{
 							Name:     "status",
 							Optional: true,
@@ -3025,6 +8499,11 @@ func (UserService) SMD() smd.ServiceInfo {
 								Name: "login",
 								Type: smd.String,
 							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "password",
 								Type: smd.String,
@@ -3035,10 +8514,19 @@ func (UserService) SMD() smd.ServiceInfo {
 								Ref:      "#/definitions/time.Time",
 								Type:     smd.Object,
 							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:        "role",
+								Description: `read-only, use role.assign to change`,
+								Type:        smd.String,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -3090,6 +8578,11 @@ func (UserService) SMD() smd.ServiceInfo {
 							Name: "login",
 							Type: smd.String,
 						},
+						{
+							Name:     "email",
+							Optional: true,
+							Type:     smd.String,
+						},
 						{
 							Name: "password",
 							Type: smd.String,
@@ -3100,10 +8593,19 @@ func (UserService) SMD() smd.ServiceInfo {
 							Ref:      "#/definitions/time.Time",
 							Type:     smd.Object,
 						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
+						{
+							Name:        "role",
+							Description: `read-only, use role.assign to change`,
+							Type:        smd.String,
+						},
 						{
 							Name:     "status",
 							Optional: true,
@@ -3141,7 +8643,8 @@ func (UserService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Update": {
-				Description: `Update updates the User data identified by id from the query`,
+				Description: `Update updates the User data identified by id from the query
+Update is rejected with 409 if User was changed since its version from Get, version is required.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:     "user",
@@ -3161,6 +8664,11 @@ func (UserService) SMD() smd.ServiceInfo {
 								Name: "login",
 								Type: smd.String,
 							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "password",
 								Type: smd.String,
@@ -3171,10 +8679,19 @@ func (UserService) SMD() smd.ServiceInfo {
 								Ref:      "#/definitions/time.Time",
 								Type:     smd.Object,
 							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:        "role",
+								Description: `read-only, use role.assign to change`,
+								Type:        smd.String,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -3216,10 +8733,11 @@ func (UserService) SMD() smd.ServiceInfo {
 					500: "Internal Error",
 					400: "Validation Error",
 					404: "Not Found",
+					409: "Version Conflict",
 				},
 			},
 			"Delete": {
-				Description: `Delete deletes the User by its ID.`,
+				Description: `Delete deletes the User by its ID. Current user can't be deleted.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "id",
@@ -3234,9 +8752,67 @@ func (UserService) SMD() smd.ServiceInfo {
 				Errors: map[int]string{
 					500: "Internal Error",
 					400: "Validation Error",
+					403: "Forbidden",
 					404: "Not Found",
 				},
 			},
+			"UpdateStatus": {
+				Description: `UpdateStatus sets status for Users identified by ids in a single transaction.
+Current user status can't be changed.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "statusUpdate",
+						Description: `StatusUpdate`,
+						Type:        smd.Object,
+						TypeName:    "StatusUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]StatusUpdateResult`,
+					Type:        smd.Array,
+					TypeName:    "[]StatusUpdateResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/StatusUpdateResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"StatusUpdateResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "isUpdated",
+									Type: smd.Boolean,
+								},
+								{
+									Name:        "error",
+									Description: `reason why object was not updated: notFound, forbidden, unpublished or conflict`,
+									Type:        smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
 			"Validate": {
 				Description: `Validate Verifies that User data is valid.`,
 				Parameters: []smd.JSONSchema{
@@ -3259,6 +8835,11 @@ func (UserService) SMD() smd.ServiceInfo {
 								Name: "login",
 								Type: smd.String,
 							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "password",
 								Type: smd.String,
@@ -3269,10 +8850,19 @@ func (UserService) SMD() smd.ServiceInfo {
 								Ref:      "#/definitions/time.Time",
 								Type:     smd.Object,
 							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:        "role",
+								Description: `read-only, use role.assign to change`,
+								Type:        smd.String,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -3364,6 +8954,25 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 	var err error
 
 	switch method {
+	case RPC.UserService.Invite:
+		var args = struct {
+			Invite UserInvite `json:"invite"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"invite"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Invite(ctx, args.Invite))
+
 	case RPC.UserService.Count:
 		var args = struct {
 			Search *UserSearch `json:"search"`
@@ -3403,6 +9012,26 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 
 		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
 
+	case RPC.UserService.List:
+		var args = struct {
+			Search  *UserSearch `json:"search"`
+			ViewOps *ViewOps    `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.List(ctx, args.Search, args.ViewOps))
+
 	case RPC.UserService.GetByID:
 		var args = struct {
 			Id int `json:"id"`
@@ -3479,6 +9108,25 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 
 		resp.Set(s.Delete(ctx, args.Id))
 
+	case RPC.UserService.UpdateStatus:
+		var args = struct {
+			StatusUpdate StatusUpdate `json:"statusUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"statusUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.UpdateStatus(ctx, args.StatusUpdate))
+
 	case RPC.UserService.Validate:
 		var args = struct {
 			User User `json:"user"`